@@ -0,0 +1,161 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+)
+
+// 設定ファイルで定義するユーザー独自のアクション
+type CustomActionConfig struct {
+	Name    string   `toml:"name"`
+	Command string   `toml:"command"`
+	Args    []string `toml:"args"`
+	Help    string   `toml:"help"`
+	Confirm bool     `toml:"confirm"`
+	// 破壊的なアクションとして、失われる可能性がある内容を表示して確認する
+	Destructive bool `toml:"destructive"`
+	// リポジトリ毎の設定ファイルで定義されたアクションか (組み込みのアクションを置き換えられない)
+	Local bool `toml:"-"`
+}
+
+type ActionsConfig struct {
+	Actions []CustomActionConfig `toml:"actions"`
+}
+
+// 設定ファイルの内容
+//
+//	confirm_destructive = false
+//
+//	[[branch.actions]]
+//	name = "push force with lease"
+//	command = "git"
+//	args = ["push", "--force-with-lease", "origin", "{{.Name}}"]
+//	help = "Force push the branch to origin"
+//	confirm = true
+type Config struct {
+	// 破壊的なアクションの実行前に確認するか (未指定の場合は確認する)
+	// 環境変数 GITMAN_CONFIRM_DESTRUCTIVE が設定されている場合はそちらを優先する
+	ConfirmDestructive *bool `toml:"confirm_destructive"`
+
+	Branch   ActionsConfig `toml:"branch"`
+	Commit   ActionsConfig `toml:"commit"`
+	Reflog   ActionsConfig `toml:"reflog"`
+	Stash    ActionsConfig `toml:"stash"`
+	Tag      ActionsConfig `toml:"tag"`
+	Worktree ActionsConfig `toml:"worktree"`
+	Remote   ActionsConfig `toml:"remote"`
+	Status   ActionsConfig `toml:"status"`
+}
+
+// ユーザー全体の設定ファイルのパスを返す ($XDG_CONFIG_HOME/gitman/config.toml)
+func GlobalConfigPath() string {
+	configHome := GetEnvWithString("XDG_CONFIG_HOME", "")
+	if configHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		configHome = filepath.Join(home, ".config")
+	}
+	return filepath.Join(configHome, "gitman", "config.toml")
+}
+
+// リポジトリ毎の設定ファイルのパスを返す (<リポジトリのルート>/.gitman.toml)
+func LocalConfigPath(topLevelDir string) string {
+	return filepath.Join(topLevelDir, ".gitman.toml")
+}
+
+// ユーザー全体の設定ファイルとリポジトリ毎の設定ファイルを順に読み込んでマージする
+// 存在しないファイルは無視する
+// リポジトリ毎の設定ファイルは clone したリポジトリに含まれるため信頼せず、ユーザー全体の設定ファイルのアクションを上書きできない
+func LoadConfig(globalPath string, localPath string) (*Config, error) {
+	config := &Config{}
+	if err := config.load(globalPath, false); err != nil {
+		return nil, err
+	}
+	if err := config.load(localPath, true); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func (config *Config) load(path string, local bool) error {
+	if path == "" {
+		return nil
+	}
+
+	var c Config
+	if _, err := toml.DecodeFile(path, &c); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("failed to load config file %s: %w", path, err)
+	}
+
+	if c.ConfirmDestructive != nil {
+		config.ConfirmDestructive = c.ConfirmDestructive
+	}
+	if err := config.mergeActions(c, local); err != nil {
+		return fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return nil
+}
+
+func (config *Config) mergeActions(c Config, local bool) error {
+	if err := config.Branch.merge(c.Branch, local); err != nil {
+		return err
+	}
+	if err := config.Commit.merge(c.Commit, local); err != nil {
+		return err
+	}
+	if err := config.Reflog.merge(c.Reflog, local); err != nil {
+		return err
+	}
+	if err := config.Stash.merge(c.Stash, local); err != nil {
+		return err
+	}
+	if err := config.Tag.merge(c.Tag, local); err != nil {
+		return err
+	}
+	if err := config.Worktree.merge(c.Worktree, local); err != nil {
+		return err
+	}
+	if err := config.Remote.merge(c.Remote, local); err != nil {
+		return err
+	}
+	return config.Status.merge(c.Status, local)
+}
+
+// 同じファイル内の同名のアクションは後のもので上書きする
+// リポジトリ毎の設定ファイルのアクションが、それまでに読み込んだアクションと同名の場合はエラーを返す
+func (a *ActionsConfig) merge(other ActionsConfig, local bool) error {
+	loaded := make(map[string]bool, len(a.Actions))
+	for _, action := range a.Actions {
+		loaded[action.Name] = true
+	}
+
+	for _, action := range other.Actions {
+		if local && loaded[action.Name] {
+			return fmt.Errorf("action %q conflicts with an action in the user config", action.Name)
+		}
+		action.Local = local
+
+		replaced := false
+		for i := range a.Actions {
+			if a.Actions[i].Name == action.Name {
+				a.Actions[i] = action
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			a.Actions = append(a.Actions, action)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package common
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		global string
+		local  string
+	}
+	tests := []struct {
+		name           string
+		args           args
+		want           *Config
+		wantErr        bool
+		wantErrMessage error
+	}{
+		{
+			name: "設定ファイルがない場合は空の設定を返却すること",
+			args: args{
+				global: "",
+				local:  "",
+			},
+			want:           &Config{},
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "リポジトリ毎の設定ファイルのアクションはLocalとして追加すること",
+			args: args{
+				global: "[[branch.actions]]\nname = \"push force\"\ncommand = \"git\"\n",
+				local:  "[[branch.actions]]\nname = \"deploy\"\ncommand = \"make\"\n",
+			},
+			want: &Config{
+				Branch: ActionsConfig{
+					Actions: []CustomActionConfig{
+						{Name: "push force", Command: "git"},
+						{Name: "deploy", Command: "make", Local: true},
+					},
+				},
+			},
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "リポジトリ毎の設定ファイルがユーザー全体の設定ファイルのアクションを上書きしようとした場合、errorを返却すること",
+			args: args{
+				global: "[[branch.actions]]\nname = \"push force\"\ncommand = \"git\"\n",
+				local:  "[[branch.actions]]\nname = \"push force\"\ncommand = \"sh\"\n",
+			},
+			want:           nil,
+			wantErr:        true,
+			wantErrMessage: errors.New("invalid config file .gitman.toml: action \"push force\" conflicts with an action in the user config"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			globalPath := writeConfig(t, dir, "config.toml", tt.args.global)
+			localPath := writeConfig(t, dir, ".gitman.toml", tt.args.local)
+
+			got, err := LoadConfig(globalPath, localPath)
+			// エラーメッセージは一時ディレクトリに依存しないようにファイル名だけで比較する
+			if err != nil {
+				err = errors.New(strings.ReplaceAll(err.Error(), dir+string(filepath.Separator), ""))
+			}
+			if (err != nil) != tt.wantErr || err != nil && err.Error() != tt.wantErrMessage.Error() {
+				t.Errorf("LoadConfig() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("LoadConfig() error = %v, wantErrMessage %v", err, tt.wantErrMessage)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LoadConfig() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// 内容が空の場合は設定ファイルを作らずにパスだけを返す
+func writeConfig(t *testing.T, dir string, name string, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if content == "" {
+		return path
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
@@ -2,6 +2,7 @@ package common
 
 import (
 	"fmt"
+	"strings"
 )
 
 // 起動オプション
@@ -9,26 +10,71 @@ func Usage() string {
 	branchCmd := GetEnvWithString("GITMAN_BRANCH_ALIAS", "br")
 	logCmd := GetEnvWithString("GITMAN_LOG_ALIAS", "l")
 	reflogCmd := GetEnvWithString("GITMAN_REFLOG_ALIAS", "rl")
+	stashCmd := GetEnvWithString("GITMAN_STASH_ALIAS", "st")
+	tagCmd := GetEnvWithString("GITMAN_TAG_ALIAS", "tg")
+	worktreeCmd := GetEnvWithString("GITMAN_WORKTREE_ALIAS", "wt")
+	remoteCmd := GetEnvWithString("GITMAN_REMOTE_ALIAS", "rt")
+	statusCmd := GetEnvWithString("GITMAN_STATUS_ALIAS", "s")
+	conflictsCmd := GetEnvWithString("GITMAN_CONFLICTS_ALIAS", "cf")
+	undoCmd := GetEnvWithString("GITMAN_UNDO_ALIAS", "u")
 
-	return fmt.Sprintf(`usage: gitman [options] [command]
+	return fmt.Sprintf(`usage: gitman [options] [command] [log options] [<revision range> | <ref>] [-- <path>...]
 
 options:
   -h, --help       show this usage
   -v, --version    display the version
   -d, --debug      enable debug mode
+  -n, --dry-run    print the command of the selected action instead of executing it
+  -q, --query      start the finder with the query (select without fzf when exactly matched)
+  -1, --select-1   automatically select the only match
+  -0, --exit-0     exit immediately when there is no match
+  --action         run the action with the name without selecting it in fzf
+  --list           print the items instead of launching fzf
+  --format         output format of --list: json or tsv (default: "json")
+
+log options:
+  --author         show commits by the author
+  --since          show commits (or reflog entries) more recent than the date (e.g. "2 weeks ago")
+  --until          show commits older than the date
+  --grep           show commits whose message matches the pattern
+  --all            show commits of all refs
+  --first-parent   follow only the first parent of merge commits
+
+branch options:
+  --prune          select merged, upstream gone or inactive branches and delete them
+  --base           base branch to check whether branches are merged (default: origin/HEAD, required when it is not set)
 
 commands:
   branch, %s       show current branch
   log, %s           show commit log
-  reflog, %s       show reflog
+  reflog, %s       show reflog of the ref (default: HEAD)
+  stash, %s        show stash list
+  tag, %s          show tags
+  worktree, %s     show worktrees
+  remote, %s       show remotes
+  status, %s        show changed files
+  conflicts, %s    resolve conflicts of an in-progress merge, rebase, cherry-pick or revert
+  undo, %s          restore the state before a destructive action
 
 environment variables:
   GITMAN_DEBUG                debug mode (default: "false")
+  GITMAN_CONFIRM_DESTRUCTIVE  confirm before destructive actions (default: confirm_destructive in the config file, or "true")
   GITMAN_FZF_LAYOUT           change fzf layout (default: "reverse")
   GITMAN_LOG_ALIAS            change log command alias (default: "l")
-  GITMAN_LOG_DISPLAY_LIMIT    change log display limit (default: "100")
+  GITMAN_LOG_DISPLAY_LIMIT    change log display limit (default: unlimited)
   GITMAN_BRANCH_ALIAS         change branch command alias (default: "br")
-  GITMAN_REFLOG_ALIAS         change reflog command alias (default: "rl")`, branchCmd, logCmd, reflogCmd)
+  GITMAN_PROTECTED_BRANCHES   branch patterns that are never force-pushed (default: "main,master")
+  GITMAN_PRUNE_STALE_DAYS     days without commits to treat a branch as inactive in --prune (default: 90)
+  GITMAN_REFLOG_ALIAS         change reflog command alias (default: "rl")
+  GITMAN_REFLOG_DISPLAY_LIMIT change reflog display limit (default: 50)
+  GITMAN_SNAPSHOT_LIMIT       number of undo snapshots to keep, 0 keeps all (default: 50)
+  GITMAN_STASH_ALIAS          change stash command alias (default: "st")
+  GITMAN_TAG_ALIAS            change tag command alias (default: "tg")
+  GITMAN_WORKTREE_ALIAS       change worktree command alias (default: "wt")
+  GITMAN_REMOTE_ALIAS         change remote command alias (default: "rt")
+  GITMAN_STATUS_ALIAS         change status command alias (default: "s")
+  GITMAN_CONFLICTS_ALIAS      change conflicts command alias (default: "cf")
+  GITMAN_UNDO_ALIAS           change undo command alias (default: "u")`, branchCmd, logCmd, reflogCmd, stashCmd, tagCmd, worktreeCmd, remoteCmd, statusCmd, conflictsCmd, undoCmd)
 }
 
 type (
@@ -37,19 +83,73 @@ type (
 		Version bool
 		Log     bool
 		Debug   bool
-		Branch  bool
-		Reflog  bool
+		DryRun  bool
+		Query   string
+		Select1 bool
+		Exit0   bool
+		Action  string
+		List    bool
+		Format  string
+		// log コマンドの絞り込み条件
+		Author      string
+		Since       string
+		Until       string
+		Grep        string
+		All         bool
+		FirstParent bool
+		Revision    string
+		Paths       []string
+		// reflog コマンドの対象の参照
+		Ref string
+		// branch コマンドで不要なブランチを削除するか
+		Prune bool
+		// 不要なブランチを探すときにマージ済みか判定する基準のブランチ
+		Base      string
+		Branch    bool
+		Reflog    bool
+		Stash     bool
+		Tag       bool
+		Worktree  bool
+		Remote    bool
+		Status    bool
+		Conflicts bool
+		Undo      bool
 	}
 )
 
 func newOptions() *Options {
 	return &Options{
-		Help:    false,
-		Version: false,
-		Debug:   false,
-		Log:     false,
-		Branch:  false,
-		Reflog:  false,
+		Help:        false,
+		Version:     false,
+		Debug:       false,
+		DryRun:      false,
+		Query:       "",
+		Select1:     false,
+		Exit0:       false,
+		Action:      "",
+		List:        false,
+		Format:      "json",
+		Author:      "",
+		Since:       "",
+		Until:       "",
+		Grep:        "",
+		All:         false,
+		FirstParent: false,
+		Revision:    "",
+		Paths:       nil,
+		Ref:         "",
+		Prune:       false,
+		Base:        "",
+		Log:         false,
+		Branch:      false,
+		Reflog:      false,
+		Stash:       false,
+		Tag:         false,
+		Worktree:    false,
+		Remote:      false,
+		Status:      false,
+		Conflicts:   false,
+		Undo:        false,
 	}
 }
 
@@ -57,26 +157,123 @@ type Args []string
 
 func ParseOptions(args Args) *Options {
 	opts := newOptions()
+	// コマンドより前に書かれても扱えるように、コマンドでもオプションでもない引数はループの後で割り当てる
+	var positionals []string
 	for i := 1; i < len(args); i++ {
 		arg := args[i]
-		switch arg {
+
+		// "--" 以降はすべてパスとして扱う
+		if arg == "--" {
+			opts.Paths = append(opts.Paths, args[i+1:]...)
+			break
+		}
+
+		// --name=value 形式は --name value と同じように扱う
+		name, inlineValue, hasInlineValue := arg, "", false
+		if strings.HasPrefix(arg, "--") {
+			name, inlineValue, hasInlineValue = strings.Cut(arg, "=")
+		}
+		// 値を取るオプションの値を返す (--name=value 形式でなければ次の引数を値として扱う)
+		value := func() string {
+			if hasInlineValue {
+				return inlineValue
+			}
+			if i+1 >= len(args) {
+				fmt.Printf("option %s requires a value\n", name)
+				opts.Help = true
+				return ""
+			}
+			i++
+			return args[i]
+		}
+
+		// reflog コマンドの後の引数は reflog の対象の参照 (例: main, refs/stash) として扱う
+		// "stash" などコマンド名と同じ名前の参照も指定できるように、コマンドの判定より先に扱う
+		if opts.Reflog && opts.Ref == "" && !strings.HasPrefix(arg, "-") {
+			opts.Ref = arg
+			continue
+		}
+
+		switch name {
 		case "-h", "--help":
 			opts.Help = true
 		case "-v", "--version":
 			opts.Version = true
 		case "-d", "--debug":
 			opts.Debug = true
+		case "-n", "--dry-run":
+			opts.DryRun = true
+		case "-q", "--query":
+			opts.Query = value()
+		case "-1", "--select-1":
+			opts.Select1 = true
+		case "-0", "--exit-0":
+			opts.Exit0 = true
+		case "--action":
+			opts.Action = value()
+		case "--list":
+			opts.List = true
+		case "--format":
+			opts.Format = value()
+		case "--author":
+			opts.Author = value()
+		case "--since":
+			opts.Since = value()
+		case "--until":
+			opts.Until = value()
+		case "--grep":
+			opts.Grep = value()
+		case "--all":
+			opts.All = true
+		case "--first-parent":
+			opts.FirstParent = true
+		case "--prune":
+			opts.Prune = true
+		case "--base":
+			opts.Base = value()
 		case "log", GetEnvWithString("GITMAN_LOG_ALIAS", "l"):
 			opts.Log = true
 		case "branch", GetEnvWithString("GITMAN_BRANCH_ALIAS", "br"):
 			opts.Branch = true
 		case "reflog", GetEnvWithString("GITMAN_REFLOG_ALIAS", "rl"):
 			opts.Reflog = true
+		case "stash", GetEnvWithString("GITMAN_STASH_ALIAS", "st"):
+			opts.Stash = true
+		case "tag", GetEnvWithString("GITMAN_TAG_ALIAS", "tg"):
+			opts.Tag = true
+		case "worktree", GetEnvWithString("GITMAN_WORKTREE_ALIAS", "wt"):
+			opts.Worktree = true
+		case "remote", GetEnvWithString("GITMAN_REMOTE_ALIAS", "rt"):
+			opts.Remote = true
+		case "status", GetEnvWithString("GITMAN_STATUS_ALIAS", "s"):
+			opts.Status = true
+		case "conflicts", GetEnvWithString("GITMAN_CONFLICTS_ALIAS", "cf"):
+			opts.Conflicts = true
+		case "undo", GetEnvWithString("GITMAN_UNDO_ALIAS", "u"):
+			opts.Undo = true
 		default:
-			fmt.Printf("unrecognized option %s", arg)
+			if !strings.HasPrefix(arg, "-") {
+				positionals = append(positionals, arg)
+				continue
+			}
+			fmt.Printf("unrecognized option %s\n", arg)
 			// 不明なオプションがあった場合はヘルプを表示
 			opts.Help = true
 		}
 	}
+
+	for _, positional := range positionals {
+		switch {
+		// reflog コマンドの引数は reflog の対象の参照として扱う
+		case opts.Reflog && opts.Ref == "":
+			opts.Ref = positional
+		// log コマンドの引数はリビジョンの範囲 (例: main..HEAD) として扱う
+		case opts.Log && opts.Revision == "":
+			opts.Revision = positional
+		default:
+			fmt.Printf("unrecognized option %s\n", positional)
+			opts.Help = true
+		}
+	}
 	return opts
 }
@@ -0,0 +1,155 @@
+package common
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseOptions(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		args Args
+	}
+	tests := []struct {
+		name string
+		args args
+		want *Options
+	}{
+		{
+			name: "引数がない場合は既定値を返すこと",
+			args: args{
+				args: Args{"gitman"},
+			},
+			want: &Options{Format: "json"},
+		},
+		{
+			name: "logコマンドの絞り込み条件とリビジョンの範囲とパスを取得すること",
+			args: args{
+				args: Args{"gitman", "log", "--author", "alice", "--since=2.weeks", "--first-parent", "main..HEAD", "--", "a.go", "b.go"},
+			},
+			want: &Options{
+				Format:      "json",
+				Log:         true,
+				Author:      "alice",
+				Since:       "2.weeks",
+				FirstParent: true,
+				Revision:    "main..HEAD",
+				Paths:       []string{"a.go", "b.go"},
+			},
+		},
+		{
+			name: "reflogコマンドの後の引数はコマンド名と同じでも参照として扱うこと",
+			args: args{
+				args: Args{"gitman", "reflog", "stash"},
+			},
+			want: &Options{
+				Format: "json",
+				Reflog: true,
+				Ref:    "stash",
+			},
+		},
+		{
+			name: "fzfを開かずに選択するオプションを取得すること",
+			args: args{
+				args: Args{"gitman", "-n", "-q", "feature", "-1", "-0", "--action", "switch track", "br"},
+			},
+			want: &Options{
+				Format:  "json",
+				DryRun:  true,
+				Query:   "feature",
+				Select1: true,
+				Exit0:   true,
+				Action:  "switch track",
+				Branch:  true,
+			},
+		},
+		{
+			name: "branchコマンドの不要なブランチの削除と一覧出力のオプションを取得すること",
+			args: args{
+				args: Args{"gitman", "branch", "--prune", "--base=main", "--list", "--format", "tsv"},
+			},
+			want: &Options{
+				Format: "tsv",
+				Branch: true,
+				Prune:  true,
+				Base:   "main",
+				List:   true,
+			},
+		},
+		{
+			name: "logコマンドより前に書かれたリビジョンの範囲を取得すること",
+			args: args{
+				args: Args{"gitman", "main..HEAD", "log"},
+			},
+			want: &Options{
+				Format:   "json",
+				Log:      true,
+				Revision: "main..HEAD",
+			},
+		},
+		{
+			name: "reflogコマンドより前に書かれた参照を取得すること",
+			args: args{
+				args: Args{"gitman", "main", "reflog"},
+			},
+			want: &Options{
+				Format: "json",
+				Reflog: true,
+				Ref:    "main",
+			},
+		},
+		{
+			name: "logとreflog以外のコマンドに引数が指定された場合はヘルプを表示すること",
+			args: args{
+				args: Args{"gitman", "branch", "main"},
+			},
+			want: &Options{
+				Format: "json",
+				Branch: true,
+				Help:   true,
+			},
+		},
+		{
+			name: "リビジョンの範囲が2つ指定された場合はヘルプを表示すること",
+			args: args{
+				args: Args{"gitman", "log", "main..HEAD", "HEAD~3"},
+			},
+			want: &Options{
+				Format:   "json",
+				Log:      true,
+				Revision: "main..HEAD",
+				Help:     true,
+			},
+		},
+		{
+			name: "不明なオプションが指定された場合はヘルプを表示すること",
+			args: args{
+				args: Args{"gitman", "--unknown"},
+			},
+			want: &Options{
+				Format: "json",
+				Help:   true,
+			},
+		},
+		{
+			name: "値を取るオプションに値がない場合はヘルプを表示すること",
+			args: args{
+				args: Args{"gitman", "log", "--author"},
+			},
+			want: &Options{
+				Format: "json",
+				Log:    true,
+				Help:   true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := ParseOptions(tt.args.args); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseOptions() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,37 +1,148 @@
 package di
 
 import (
+	"gitman/common"
+	"gitman/domain/model"
 	"gitman/domain/usecase"
 	"gitman/infrastructure/fzf"
 	"gitman/infrastructure/git"
+	"strings"
 )
 
 type Container struct {
-	GitBranchUsecase usecase.GitBranchUsecase
-	GitCommitUsecase usecase.GitCommitUsecase
-	GitReflogUsecase usecase.GitReflogUsecase
+	GitBranchUsecase   usecase.GitBranchUsecase
+	GitCommitUsecase   usecase.GitCommitUsecase
+	GitReflogUsecase   usecase.GitReflogUsecase
+	GitStashUsecase    usecase.GitStashUsecase
+	GitTagUsecase      usecase.GitTagUsecase
+	GitWorktreeUsecase usecase.GitWorktreeUsecase
+	GitRemoteUsecase   usecase.GitRemoteUsecase
+	GitStatusUsecase   usecase.GitStatusUsecase
+	GitConflictUsecase usecase.GitConflictUsecase
+	GitUndoUsecase     usecase.GitUndoUsecase
 }
 
-func NewContainer() Container {
+func NewContainer(opts *common.Options) (Container, error) {
 	// infrastructureの初期化
-	gm, err := git.NewGitManager()
+	gm, err := git.NewGitManager(opts.DryRun)
 	if err != nil {
-		panic(err)
+		return Container{}, err
 	}
 
-	fm, err := fzf.NewFzfManager()
+	fm, err := fzf.NewFzfManager(fzf.SelectOptions{
+		Query:   opts.Query,
+		Select1: opts.Select1,
+		Exit0:   opts.Exit0,
+		Action:  opts.Action,
+	})
 	if err != nil {
-		panic(err)
+		return Container{}, err
 	}
 
+	// 設定ファイルの読み込みと、設定ファイルで定義されたアクションの登録
+	config, err := loadConfig(gm)
+	if err != nil {
+		return Container{}, err
+	}
+	if err := registerCustomActionTypes(config); err != nil {
+		return Container{}, err
+	}
+	confirmDestructive := isDestructiveConfirmationEnabled(config)
+
 	// Usecaseの初期化
-	gbu := usecase.NewGitBranchUsecase(fm, gm)
-	gcu := usecase.NewGitCommitUsecase(fm, gm)
-	gru := usecase.NewGitReflogUsecase(fm, gm)
+	gbu := usecase.NewGitBranchUsecase(fm, gm, opts.Base, confirmDestructive)
+	gcu := usecase.NewGitCommitUsecase(fm, gm, model.CommitFilter{
+		Author:      opts.Author,
+		Since:       opts.Since,
+		Until:       opts.Until,
+		Grep:        opts.Grep,
+		All:         opts.All,
+		FirstParent: opts.FirstParent,
+		Revision:    opts.Revision,
+		Paths:       opts.Paths,
+	}, opts.Query, confirmDestructive)
+	gru := usecase.NewGitReflogUsecase(fm, gm, model.ReflogFilter{
+		Ref:   opts.Ref,
+		Since: opts.Since,
+	}, confirmDestructive)
+	gsu := usecase.NewGitStashUsecase(fm, gm, confirmDestructive)
+	gtu := usecase.NewGitTagUsecase(fm, gm, confirmDestructive)
+	gwu := usecase.NewGitWorktreeUsecase(fm, gm, confirmDestructive)
+	grmu := usecase.NewGitRemoteUsecase(fm, gm, confirmDestructive)
+	gsau := usecase.NewGitStatusUsecase(fm, gm, confirmDestructive)
+	gcfu := usecase.NewGitConflictUsecase(fm, gm, confirmDestructive)
+	guu := usecase.NewGitUndoUsecase(fm, gm, confirmDestructive)
 
 	return Container{
-		GitBranchUsecase: gbu,
-		GitCommitUsecase: gcu,
-		GitReflogUsecase: gru,
+		GitBranchUsecase:   gbu,
+		GitCommitUsecase:   gcu,
+		GitReflogUsecase:   gru,
+		GitStashUsecase:    gsu,
+		GitTagUsecase:      gtu,
+		GitWorktreeUsecase: gwu,
+		GitRemoteUsecase:   grmu,
+		GitStatusUsecase:   gsau,
+		GitConflictUsecase: gcfu,
+		GitUndoUsecase:     guu,
+	}, nil
+}
+
+// ユーザー全体の設定ファイルとリポジトリ毎の設定ファイルを読み込む
+func loadConfig(gm git.GitManager) (*common.Config, error) {
+	localPath := ""
+	// gitリポジトリ外で実行された場合はリポジトリ毎の設定ファイルを読み込まない
+	if topLevelDir, err := gm.GetTopLevelDir(); err == nil {
+		localPath = common.LocalConfigPath(topLevelDir)
+	}
+	return common.LoadConfig(common.GlobalConfigPath(), localPath)
+}
+
+// GITMAN_CONFIRM_DESTRUCTIVE=false の場合は破壊的なアクションの確認を省略する
+// 環境変数が設定されていない場合は設定ファイルの confirm_destructive に従い、どちらもない場合は確認する
+func isDestructiveConfirmationEnabled(config *common.Config) bool {
+	if value := common.GetEnvWithString("GITMAN_CONFIRM_DESTRUCTIVE", ""); value != "" {
+		return strings.ToLower(value) != "false"
+	}
+	if config.ConfirmDestructive != nil {
+		return *config.ConfirmDestructive
+	}
+	return true
+}
+
+// 設定ファイルで定義された独自のアクションを登録する
+func registerCustomActionTypes(config *common.Config) error {
+	if err := model.SetCustomBranchActionTypes(toCustomActionTypes(config.Branch)); err != nil {
+		return err
+	}
+	if err := model.SetCustomCommitActionTypes(toCustomActionTypes(config.Commit)); err != nil {
+		return err
+	}
+	if err := model.SetCustomReflogActionTypes(toCustomActionTypes(config.Reflog)); err != nil {
+		return err
+	}
+	if err := model.SetCustomStashActionTypes(toCustomActionTypes(config.Stash)); err != nil {
+		return err
+	}
+	if err := model.SetCustomTagActionTypes(toCustomActionTypes(config.Tag)); err != nil {
+		return err
+	}
+	if err := model.SetCustomWorktreeActionTypes(toCustomActionTypes(config.Worktree)); err != nil {
+		return err
+	}
+	if err := model.SetCustomRemoteActionTypes(toCustomActionTypes(config.Remote)); err != nil {
+		return err
+	}
+	return model.SetCustomFileStatusActionTypes(toCustomActionTypes(config.Status))
+}
+
+func toCustomActionTypes(config common.ActionsConfig) []model.ActionType {
+	var ret []model.ActionType
+	for _, action := range config.Actions {
+		if action.Local {
+			ret = append(ret, model.NewLocalCustomActionType(action.Name, action.Command, action.Args, action.Help, action.Confirm, action.Destructive))
+			continue
+		}
+		ret = append(ret, model.NewCustomActionType(action.Name, action.Command, action.Args, action.Help, action.Confirm, action.Destructive))
 	}
+	return ret
 }
@@ -0,0 +1,149 @@
+package model
+
+// アクションを表示するための条件
+// ActionType.Conditions の全てを満たす場合だけアクションを選択できる
+type ActionCondition int
+
+const (
+	// ローカルブランチである
+	ConditionLocalBranch ActionCondition = iota + 1
+	// リモート追跡ブランチである
+	ConditionRemoteBranch
+	// 現在のブランチである
+	ConditionCurrentBranch
+	// 現在のブランチではない
+	ConditionNotCurrentBranch
+	// 上流ブランチが設定されている (削除されている場合も含む)
+	ConditionUpstreamConfigured
+	// 上流ブランチが存在する
+	ConditionUpstreamExists
+	// 上流ブランチがない (設定されていない、または削除された) ローカルブランチである
+	ConditionNoUpstream
+	// 上流ブランチより進んでいる
+	ConditionAhead
+	// 上流ブランチより進んでいない
+	ConditionNotAhead
+	// 上流ブランチより遅れている
+	ConditionBehind
+	// 上流ブランチより遅れていない
+	ConditionNotBehind
+	// 保護されたブランチではない
+	ConditionNotProtectedBranch
+	// マージコミットではない (マージコミットの revert や cherry-pick には -m が必要)
+	ConditionNotMergeCommit
+	// 作業ツリーとインデックスに未コミットの変更がない
+	ConditionCleanWorkingTree
+	// インデックスにステージされた変更がある
+	ConditionStagedChanges
+	// rebase や merge などの途中ではない
+	ConditionNoOperationInProgress
+	// rebase や merge などの途中である
+	ConditionOperationInProgress
+	// 途中の操作が --skip で対象を飛ばせる (merge 以外)
+	ConditionSkippableOperation
+	// 競合しているファイルがない
+	ConditionNoConflicts
+	// 対象のファイルが選択されている
+	ConditionFilesSelected
+	// 注釈のない (軽量) タグである
+	ConditionLightweightTag
+	// push 先のリモートが決まっている
+	ConditionPushRemote
+	// メインのワークツリーではない (メインのワークツリーは削除やロックができない)
+	ConditionNotMainWorktree
+	// ロックされたワークツリーである
+	ConditionLockedWorktree
+	// ロックされていないワークツリーである
+	ConditionUnlockedWorktree
+	// 追跡しているファイルである (git restore は追跡していないファイルを指定すると失敗する)
+	ConditionTrackedFile
+	// 追跡していないファイルである
+	ConditionUntrackedFile
+	// インデックスにステージされた変更があるファイルである
+	ConditionStagedFile
+	// 作業ツリーにステージされていない変更がある、追跡しているファイルである
+	ConditionUnstagedFile
+)
+
+// アクションを表示するか判定するときに使うリポジトリの状態
+type RepoState struct {
+	// 作業ツリーかインデックスに未コミットの変更があるか (追跡していないファイルは含まない)
+	Dirty bool
+	// インデックスにステージされた変更があるか
+	StagedChanges bool
+	// 競合しているファイルがあるか
+	Conflicted bool
+	// 途中で止まっている操作 (例: rebase, merge, cherry-pick, revert) 何もない場合は空文字
+	OperationInProgress string
+	// 強制的な push を表示しない保護されたブランチのパターン (例: main, release/*)
+	ProtectedBranches []string
+}
+
+// 追跡しているファイルの状態と途中で止まっている操作からリポジトリの状態を作る
+// 追跡していないファイルは rebase 等の妨げにならないため未コミットの変更とみなさない
+func NewRepoState(fileStatuses []*FileStatus, operation string) RepoState {
+	state := RepoState{OperationInProgress: operation}
+	for _, fileStatus := range fileStatuses {
+		if fileStatus.Untracked {
+			continue
+		}
+		state.Dirty = true
+		state.StagedChanges = state.StagedChanges || fileStatus.Staged
+		state.Conflicted = state.Conflicted || fileStatus.Conflicted
+	}
+	return state
+}
+
+// リポジトリの状態で判定する条件の場合は、判定結果と true を返す
+func (s RepoState) meetsCondition(condition ActionCondition) (bool, bool) {
+	switch condition {
+	case ConditionCleanWorkingTree:
+		return !s.Dirty, true
+	case ConditionStagedChanges:
+		return s.StagedChanges, true
+	case ConditionNoOperationInProgress:
+		return s.OperationInProgress == "", true
+	case ConditionOperationInProgress:
+		return s.OperationInProgress != "", true
+	case ConditionSkippableOperation:
+		return s.OperationInProgress != "" && s.OperationInProgress != "merge", true
+	case ConditionNoConflicts:
+		return !s.Conflicted, true
+	}
+	return false, false
+}
+
+// アクションの対象 (ブランチ、コミットなど) ごとの条件を判定する
+// 対象に関係のない条件は満たしているとみなす
+type actionTarget interface {
+	meetsCondition(condition ActionCondition, state RepoState) bool
+}
+
+// リポジトリの状態と全ての対象がアクションの条件を満たすか
+func (a ActionType) isAvailable(state RepoState, targets ...actionTarget) bool {
+	for _, condition := range a.Conditions {
+		if met, ok := state.meetsCondition(condition); ok {
+			if !met {
+				return false
+			}
+			continue
+		}
+		for _, target := range targets {
+			if !target.meetsCondition(condition, state) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// リポジトリの状態と全ての対象が条件を満たすアクションだけを返す
+func filterAvailableActionTypes(actionTypes []ActionType, state RepoState, targets ...actionTarget) []ActionType {
+	var ret []ActionType
+	for _, actionType := range actionTypes {
+		if actionType.isAvailable(state, targets...) {
+			ret = append(ret, actionType)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,78 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewRepoState(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		fileStatuses []*FileStatus
+		operation    string
+	}
+	tests := []struct {
+		name string
+		args args
+		want RepoState
+	}{
+		{
+			name: "変更がない場合は未コミットの変更がないこと",
+			args: args{
+				fileStatuses: nil,
+				operation:    "",
+			},
+			want: RepoState{},
+		},
+		{
+			name: "追跡していないファイルは未コミットの変更とみなさないこと",
+			args: args{
+				fileStatuses: []*FileStatus{
+					{Path: "tmp.txt", IndexStatus: "?", WorktreeStatus: "?", Untracked: true},
+				},
+				operation: "",
+			},
+			want: RepoState{},
+		},
+		{
+			name: "ステージされた変更がある場合はステージされた変更があること",
+			args: args{
+				fileStatuses: []*FileStatus{
+					{Path: "main.go", IndexStatus: "M", WorktreeStatus: ".", Staged: true},
+					{Path: "README.md", IndexStatus: ".", WorktreeStatus: "M", Unstaged: true},
+				},
+				operation: "",
+			},
+			want: RepoState{Dirty: true, StagedChanges: true},
+		},
+		{
+			name: "ステージされていない変更だけの場合はステージされた変更がないこと",
+			args: args{
+				fileStatuses: []*FileStatus{
+					{Path: "README.md", IndexStatus: ".", WorktreeStatus: "M", Unstaged: true},
+				},
+				operation: "",
+			},
+			want: RepoState{Dirty: true},
+		},
+		{
+			name: "競合しているファイルがある場合は途中の操作と共に競合があること",
+			args: args{
+				fileStatuses: []*FileStatus{
+					{Path: "main.go", IndexStatus: "U", WorktreeStatus: "U", Conflicted: true},
+				},
+				operation: "rebase",
+			},
+			want: RepoState{Dirty: true, Conflicted: true, OperationInProgress: "rebase"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := NewRepoState(tt.args.fileStatuses, tt.args.operation); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewRepoState() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
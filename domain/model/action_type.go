@@ -1,17 +1,42 @@
 package model
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+)
 
 type ActionType struct {
 	Name    string
 	Command string
 	Options []string
 	Help    string
+	// 複数の対象をまとめて1つのコマンドで実行できるか
+	Multiple bool
+	// 設定ファイルで定義されたアクションか (Argsのテンプレートから引数を組み立てる)
+	Custom bool
+	// リポジトリ毎の設定ファイル (.gitman.toml) で定義されたアクションか (組み込みのアクションを置き換えられない)
+	Local bool
+	// Custom の場合のテンプレート形式の引数 (例: {{.Name}})
+	Args []string
+	// 実行前に確認するか
+	Confirm bool
+	// 作業内容や参照が失われる可能性があるか (実行前に失われる内容を表示して確認する)
+	Destructive bool
+	// 実行前にリポジトリの状態を記録し、gitman undo で復元できるようにするか
+	Snapshot bool
+	// 対象のIDをコマンドに渡す形式 (例: --fixup=%s, %s^)
+	// 空の場合はIDをそのまま渡す
+	TargetFormat string
+	// 対象の後に追加するオプション (例: 変更後のブランチ名)
+	TrailingOptions []string
+	// アクションを表示するための条件 (空の場合は常に表示する)
+	Conditions []ActionCondition
 }
 
 func (a ActionType) IsEqual(target ActionType) bool {
 	// 対象が定数のため名前だけで一致を確認する
-	return a.Name == target.Name
+	// 独自のアクションは組み込みのアクションと同じ名前で置き換えられるため、組み込みのアクションとは一致させない
+	return a.Name == target.Name && a.Custom == target.Custom
 }
 
 // optionsを半角スペース区切りで1つの文字列に変換する
@@ -23,3 +48,42 @@ func (a ActionType) GetOptions() string {
 	}
 	return ret
 }
+
+// optionsの末尾に引数を追加したActionTypeを返す
+// ブランチ名など実行時に決まる値をコマンドに含めるために使う
+func (a ActionType) WithOptions(options ...string) ActionType {
+	ret := a
+	ret.Options = make([]string, 0, len(a.Options)+len(options))
+	ret.Options = append(ret.Options, a.Options...)
+	ret.Options = append(ret.Options, options...)
+	return ret
+}
+
+// 対象の後に引数を追加したActionTypeを返す
+// git branch -m <対象> <新しい名前> のように対象の後に値を指定するコマンドで使う
+func (a ActionType) WithTrailingOptions(options ...string) ActionType {
+	ret := a
+	ret.TrailingOptions = make([]string, 0, len(a.TrailingOptions)+len(options))
+	ret.TrailingOptions = append(ret.TrailingOptions, a.TrailingOptions...)
+	ret.TrailingOptions = append(ret.TrailingOptions, options...)
+	return ret
+}
+
+// 対象のIDを TargetFormat の形式に変換する
+func (a ActionType) FormatTarget(target string) string {
+	if a.TargetFormat == "" {
+		return target
+	}
+	return fmt.Sprintf(a.TargetFormat, target)
+}
+
+// 複数の対象に対して実行できるアクションだけを返す
+func FilterMultipleActionTypes(actionTypes []ActionType) []ActionType {
+	var ret []ActionType
+	for _, actionType := range actionTypes {
+		if actionType.Multiple {
+			ret = append(ret, actionType)
+		}
+	}
+	return ret
+}
@@ -1,6 +1,9 @@
 package model
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+)
 
 func TestActionType_GetOptions(t *testing.T) {
 	t.Parallel()
@@ -56,3 +59,80 @@ func TestActionType_GetOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestActionType_WithOptions(t *testing.T) {
+	t.Parallel()
+	base := ActionType{
+		Name:    "branch from stash",
+		Command: "git",
+		Options: []string{"stash", "branch"},
+		Help:    "Create a new branch",
+	}
+
+	got := base.WithOptions("new-branch")
+
+	if want := []string{"stash", "branch", "new-branch"}; !reflect.DeepEqual(got.Options, want) {
+		t.Errorf("WithOptions() options = %v, want %v", got.Options, want)
+	}
+	if !got.IsEqual(base) {
+		t.Errorf("WithOptions() should keep the action name, got %v", got.Name)
+	}
+	// 元のActionTypeのoptionsは変更されないこと
+	if want := []string{"stash", "branch"}; !reflect.DeepEqual(base.Options, want) {
+		t.Errorf("WithOptions() must not modify the original options = %v, want %v", base.Options, want)
+	}
+}
+
+func TestFilterMultipleActionTypes(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		actionTypes []ActionType
+		want        []ActionType
+	}{
+		{
+			name:        "複数の対象に実行できるブランチアクションだけを返すこと",
+			actionTypes: BranchActionTypes.All(),
+			want:        []ActionType{BranchActionTypes.Delete, BranchActionTypes.ForceDelete},
+		},
+		{
+			name:        "複数の対象に実行できるアクションがない場合はnilを返すこと",
+			actionTypes: StashActionTypes.All(),
+			want:        nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := FilterMultipleActionTypes(tt.actionTypes); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FilterMultipleActionTypes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestActionType_Destructive(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		actionType ActionType
+		want       bool
+	}{
+		{name: "reset hardは破壊的なアクションであること", actionType: ReflogActionTypes.ResetHard, want: true},
+		{name: "ブランチの削除は破壊的なアクションであること", actionType: BranchActionTypes.Delete, want: true},
+		{name: "スタッシュの削除は破壊的なアクションであること", actionType: StashActionTypes.Drop, want: true},
+		{name: "変更の破棄は破壊的なアクションであること", actionType: FileStatusActionTypes.Discard, want: true},
+		{name: "ブランチの切り替えは破壊的なアクションではないこと", actionType: BranchActionTypes.Switch, want: false},
+		{name: "diffは破壊的なアクションではないこと", actionType: CommitActionTypes.Diff, want: false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.actionType.Destructive; got != tt.want {
+				t.Errorf("ActionType.Destructive = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,27 +3,49 @@ package model
 import (
 	"fmt"
 	"log/slog"
+	"path"
+	"strconv"
 	"strings"
+	"time"
 )
 
-// git branchで対象となったブランチを表す構造体
+// git for-each-ref で取得するブランチの出力形式(タブ区切り)
+// HEADか, 参照名, 短縮した参照名, コミットID, 上流ブランチ, 上流ブランチとの差分, 上流ブランチのリモート名, シンボリック参照の参照先, コミット日時, 作者, 件名
+const BranchFormat = "%(HEAD)%09%(refname)%09%(refname:short)%09%(objectname:short)%09%(upstream:short)%09%(upstream:track,nobracket)%09%(upstream:remotename)%09%(symref)%09%(committerdate:iso-strict)%09%(authorname)%09%(contents:subject)"
+
+// git for-each-ref で対象となったブランチを表す構造体
 type Branch struct {
-	Current             bool
-	Name                string
-	LastCommitId        string
-	LastCommitMessage   string
-	RawGirBranchMessage string
-	ActionTypes         []ActionType
+	Current bool
+	// 短縮した参照名 (例: main, origin/main)
+	Name string
+	// 参照名 (例: refs/heads/main, refs/remotes/origin/main)
+	FullRef string
+	// リモート追跡ブランチか
+	Remote bool
+	// リモート追跡ブランチのリモート名、またはローカルブランチの上流ブランチのリモート名
+	RemoteName string
+	// 上流ブランチ (例: origin/main)
+	Upstream string
+	// 上流ブランチより進んでいるコミット数
+	Ahead int
+	// 上流ブランチより遅れているコミット数
+	Behind int
+	// 上流ブランチが削除されているか
+	UpstreamGone      bool
+	LastCommitId      string
+	LastCommitMessage string
+	LastCommitDate    time.Time
+	LastCommitAuthor  string
+	ActionTypes       []ActionType
 }
 
-func NewBranch(current bool, name string, lastCommitId string, lastCommitMessage string, rawGirBranchMessage string) *Branch {
+func NewBranch(current bool, name string, lastCommitId string, lastCommitMessage string) *Branch {
 	return &Branch{
-		Current:             current,
-		Name:                name,
-		LastCommitId:        lastCommitId,
-		LastCommitMessage:   lastCommitMessage,
-		RawGirBranchMessage: rawGirBranchMessage,
-		ActionTypes:         BranchActionTypes.All(),
+		Current:           current,
+		Name:              name,
+		LastCommitId:      lastCommitId,
+		LastCommitMessage: lastCommitMessage,
+		ActionTypes:       BranchActionTypes.All(),
 	}
 }
 
@@ -31,6 +53,66 @@ func (b Branch) String() string {
 	return b.Name
 }
 
+// --list で出力する項目を返す
+func (b Branch) GetListItem() ListItem {
+	return ListItem{
+		{Key: "name", Value: b.Name},
+		{Key: "current", Value: b.Current},
+		{Key: "last_commit_id", Value: b.LastCommitId},
+		{Key: "last_commit_message", Value: b.LastCommitMessage},
+		{Key: "full_ref", Value: b.FullRef},
+		{Key: "remote", Value: b.Remote},
+		{Key: "remote_name", Value: b.RemoteName},
+		{Key: "upstream", Value: b.Upstream},
+		{Key: "ahead", Value: b.Ahead},
+		{Key: "behind", Value: b.Behind},
+		{Key: "upstream_gone", Value: b.UpstreamGone},
+		{Key: "last_commit_date", Value: formatCommitDate(b.LastCommitDate)},
+		{Key: "last_commit_author", Value: b.LastCommitAuthor},
+	}
+}
+
+// 上流ブランチとの関係を表す文字列を返す (上流ブランチがない場合は空文字)
+// 例: "origin/main: ahead 1, behind 2", "origin/main: gone"
+func (b Branch) GetTrackingLabel() string {
+	if b.Upstream == "" {
+		return ""
+	}
+
+	var states []string
+	if b.UpstreamGone {
+		states = append(states, "gone")
+	}
+	if b.Ahead > 0 {
+		states = append(states, fmt.Sprintf("ahead %d", b.Ahead))
+	}
+	if b.Behind > 0 {
+		states = append(states, fmt.Sprintf("behind %d", b.Behind))
+	}
+	if len(states) == 0 {
+		return b.Upstream
+	}
+	return fmt.Sprintf("%s: %s", b.Upstream, strings.Join(states, ", "))
+}
+
+// fzfの候補として表示する1行を返す
+// 先頭はブランチ名 (fzfのプレビューと選択結果のパースで使う)
+// 例: "main 1a2b3c4 [origin/main: ahead 1] 2024-01-02 alice add feature"
+func (b Branch) GetFzfLine() string {
+	fields := []string{b.Name, b.LastCommitId}
+	if label := b.GetTrackingLabel(); label != "" {
+		fields = append(fields, "["+label+"]")
+	}
+	if !b.LastCommitDate.IsZero() {
+		fields = append(fields, b.LastCommitDate.Format("2006-01-02"))
+	}
+	if b.LastCommitAuthor != "" {
+		fields = append(fields, b.LastCommitAuthor)
+	}
+	fields = append(fields, b.LastCommitMessage)
+	return strings.Join(fields, " ")
+}
+
 func FindBranchByBranchName(branches []*Branch, branchName string) (*Branch, error) {
 	for _, branch := range branches {
 		if branch.Name == branchName {
@@ -51,15 +133,180 @@ func (b Branch) GetFullCommand(actionType ActionType) string {
 }
 
 func (b Branch) GetOptionsWithBranchInfo(actionType ActionType) []string {
+	if actionType.Custom {
+		return actionType.GetCustomOptions(b)
+	}
+
 	ret := actionType.Options
 	slog.Debug("actionType", "actionType", actionType.Name)
 
-	if actionType.IsEqual(BranchActionTypes.GetLastCommitId) {
+	switch {
+	case actionType.IsEqual(BranchActionTypes.GetLastCommitId):
 		ret = append(ret, b.LastCommitId)
-	} else {
+	// 上流ブランチとやり取りするアクションは上流ブランチのリモートとブランチ名を指定する
+	case actionType.IsEqual(BranchActionTypes.Push), actionType.IsEqual(BranchActionTypes.PushForce):
+		ret = append(ret, b.RemoteName, b.getRefspec(b.Name, b.GetUpstreamBranchName()))
+	case actionType.IsEqual(BranchActionTypes.PullRebase):
+		ret = append(ret, b.RemoteName, b.GetUpstreamBranchName())
+	case actionType.IsEqual(BranchActionTypes.FastForward):
+		ret = append(ret, b.RemoteName, b.GetUpstreamBranchName()+":"+b.Name)
+	default:
 		ret = append(ret, b.Name)
 	}
-	return ret
+	return append(ret, actionType.TrailingOptions...)
+}
+
+// 上流ブランチのリモート上でのブランチ名を返す (例: origin/feature -> feature)
+func (b Branch) GetUpstreamBranchName() string {
+	return strings.TrimPrefix(b.Upstream, b.RemoteName+"/")
+}
+
+// push するときの refspec を返す (ブランチ名が同じ場合は省略する)
+func (b Branch) getRefspec(src string, dst string) string {
+	if src == dst {
+		return src
+	}
+	return src + ":" + dst
+}
+
+// ブランチ名が保護されたブランチのパターン (例: main, release/*) のいずれかに一致するか
+func (b Branch) IsProtected(patterns []string) bool {
+	for _, pattern := range patterns {
+		if matched, err := path.Match(pattern, b.GetLocalName()); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// 保護されたブランチを強制的に push するアクションの場合はエラーを返す
+// 独自のアクションも対象にするため、アクションの種類ではなく実行する引数から判定する
+func (b Branch) CheckForcePush(actionType ActionType, patterns []string) error {
+	if !IsForcePush(actionType.Command, b.GetOptionsWithBranchInfo(actionType)) {
+		return nil
+	}
+	return b.checkForcePushProtection(patterns)
+}
+
+// ブランチか、push 先 (上流ブランチ) が保護されたブランチの場合はエラーを返す
+func (b Branch) checkForcePushProtection(patterns []string) error {
+	upstream := Branch{Name: b.GetUpstreamBranchName()}
+	if b.IsProtected(patterns) || b.Upstream != "" && upstream.IsProtected(patterns) {
+		return fmt.Errorf("refusing to force-push the protected branch %s (GITMAN_PROTECTED_BRANCHES)", b.GetLocalName())
+	}
+	return nil
+}
+
+// 実行する引数が強制的な push か
+// 例: git push --force, git push -f, git push --force-with-lease, git push origin +main
+func IsForcePush(command string, options []string) bool {
+	if command != "git" {
+		return false
+	}
+	pushed := false
+	for _, option := range options {
+		if !pushed {
+			pushed = option == "push"
+			continue
+		}
+		switch {
+		case option == "--force", option == "--mirror", strings.HasPrefix(option, "--force-with-lease"):
+			return true
+		// -f は -uf のようにまとめて指定できる
+		case !strings.HasPrefix(option, "--") && strings.HasPrefix(option, "-") && strings.Contains(option, "f"):
+			return true
+		// + で始まる refspec は強制的に更新する
+		case strings.HasPrefix(option, "+"):
+			return true
+		}
+	}
+	return false
+}
+
+// ブランチの種類や状態、リポジトリの状態に応じて実行できるアクションだけを返す
+func (b Branch) GetAvailableActionTypes(state RepoState) []ActionType {
+	return filterAvailableActionTypes(b.ActionTypes, state, b)
+}
+
+// ブランチの種類や上流ブランチとの関係がアクションの条件を満たすか
+func (b Branch) meetsCondition(condition ActionCondition, state RepoState) bool {
+	hasUpstream := !b.Remote && b.Upstream != "" && !b.UpstreamGone
+
+	switch condition {
+	case ConditionLocalBranch:
+		return !b.Remote
+	case ConditionRemoteBranch:
+		return b.Remote
+	case ConditionCurrentBranch:
+		return b.Current
+	case ConditionNotCurrentBranch:
+		return !b.Current
+	case ConditionUpstreamConfigured:
+		return !b.Remote && b.Upstream != ""
+	case ConditionUpstreamExists:
+		return hasUpstream
+	case ConditionNoUpstream:
+		return !b.Remote && !hasUpstream
+	case ConditionAhead:
+		return b.Ahead > 0
+	case ConditionNotAhead:
+		return b.Ahead == 0
+	case ConditionBehind:
+		return b.Behind > 0
+	case ConditionNotBehind:
+		return b.Behind == 0
+	case ConditionNotProtectedBranch:
+		return !b.IsProtected(state.ProtectedBranches)
+	}
+	return true
+}
+
+// リモート追跡ブランチの場合はリモート名を除いたブランチ名を返す (例: origin/feature -> feature)
+func (b Branch) GetLocalName() string {
+	if !b.Remote {
+		return b.Name
+	}
+	return strings.TrimPrefix(b.Name, b.RemoteName+"/")
+}
+
+// 破壊的なアクションの確認で表示する、失われる可能性がある内容の対象を返す
+// 削除は対象のブランチの未プッシュのコミット、強制的な push は上流ブランチだけにあるコミットを表示する
+func (b Branch) GetDestructiveScope(actionType ActionType) DestructiveScope {
+	switch {
+	case actionType.IsEqual(BranchActionTypes.Delete), actionType.IsEqual(BranchActionTypes.ForceDelete):
+		return DestructiveScope{UnpushedRefs: []string{b.Name}}
+	case actionType.IsEqual(BranchActionTypes.PushForce):
+		if b.Upstream == "" || b.UpstreamGone {
+			return DestructiveScope{}
+		}
+		return DestructiveScope{OverwrittenRefs: []OverwrittenRef{{Ref: b.Name, Upstream: b.Upstream}}}
+	default:
+		return defaultDestructiveScope(b.Name)
+	}
+}
+
+// 新しいワークツリーで開くアクションを、作成先のパスを指定して返す
+// リモート追跡ブランチの場合は HEAD が切り離されないように、同じ名前のローカルブランチを作成して追跡させる
+// 例: git worktree add --track -b feature ../repo-feature origin/feature
+func (b Branch) GetWorktreeActionType(path string) ActionType {
+	if b.Remote {
+		return BranchActionTypes.Worktree.WithOptions("--track", "-b", b.GetLocalName(), path)
+	}
+	return BranchActionTypes.Worktree.WithOptions(path)
+}
+
+// ワークツリーで開くブランチを返す
+// リモート追跡ブランチと同じ名前のローカルブランチがある場合は、新しく作成せずにローカルブランチを開く
+func ResolveWorktreeBranch(branches []*Branch, branch *Branch) *Branch {
+	if !branch.Remote {
+		return branch
+	}
+	for _, local := range branches {
+		if !local.Remote && local.Name == branch.GetLocalName() {
+			return local
+		}
+	}
+	return branch
 }
 
 func (b Branch) GetFzfInputForSelectActionType(actionType ActionType) string {
@@ -68,46 +315,149 @@ func (b Branch) GetFzfInputForSelectActionType(actionType ActionType) string {
 	return fmt.Sprintf("%s\tDescription : %s\tCommand     : %s\n", actionType.Name, actionType.Help, b.GetFullCommand(actionType))
 }
 
-func ParseBranches(log string) ([]*Branch, error) {
+// git for-each-ref --format=BranchFormat の形式をパースして、Branch構造体のスライスを返す
+func ParseBranches(refs string) ([]*Branch, error) {
 	var branches []*Branch
 
-	lines := strings.Split(strings.TrimSpace(log), "\n")
-	for _, line := range lines {
-		if line == "" {
+	for _, line := range strings.Split(strings.Trim(refs, "\n"), "\n") {
+		if strings.TrimSpace(line) == "" {
 			continue
 		}
 
-		isCurrent := false
-		line = strings.TrimSpace(line)
-		if strings.HasPrefix(line, "*") {
-			isCurrent = true
-			line = strings.TrimSpace(line[1:]) // 先頭の "*" を除去
+		fields := strings.SplitN(line, "\t", 11)
+		if len(fields) != 11 {
+			// 不正な行はスキップ
+			slog.Debug("skip invalid branch line", "line", line)
+			continue
 		}
 
-		// 特殊ケース: "remotes/origin/HEAD -> origin/main"
-		if strings.Contains(line, "->") {
-			parts := strings.SplitN(line, "->", 2)
-			name := strings.TrimSpace(parts[0])
-			msg := "-> " + strings.TrimSpace(parts[1])
-			branch := NewBranch(isCurrent, name, "NOTHING", msg, line)
-			branches = append(branches, branch)
+		// origin/HEAD のようなシンボリック参照は参照先のブランチと重複するためスキップ
+		if fields[7] != "" {
 			continue
 		}
 
-		fields := strings.Fields(line)
-		if len(fields) < 2 {
-			// 不正な行はスキップ
-			continue
+		branch := NewBranch(fields[0] == "*", fields[2], fields[3], fields[10])
+		branch.FullRef = fields[1]
+		branch.Upstream = fields[4]
+		branch.RemoteName = fields[6]
+		branch.LastCommitAuthor = fields[9]
+
+		if remoteBranch, ok := strings.CutPrefix(branch.FullRef, "refs/remotes/"); ok {
+			branch.Remote = true
+			branch.RemoteName = strings.SplitN(remoteBranch, "/", 2)[0]
+		}
+
+		ahead, behind, gone, err := parseUpstreamTrack(fields[5])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse upstream of %s: %w", branch.Name, err)
 		}
+		branch.Ahead = ahead
+		branch.Behind = behind
+		branch.UpstreamGone = gone
 
-		name := fields[0]
-		commit := fields[1]
-		branchLog := strings.Join(fields[2:], " ")
+		if fields[8] != "" {
+			lastCommitDate, err := time.Parse(time.RFC3339, fields[8])
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse commit date of %s: %w", branch.Name, err)
+			}
+			branch.LastCommitDate = lastCommitDate
+		}
 
-		branch := NewBranch(isCurrent, name, commit, branchLog, line)
 		branches = append(branches, branch)
 	}
 
 	slog.Debug("get branches from git", "branches", branches)
 	return branches, nil
 }
+
+// %(upstream:track,nobracket) の出力 (例: "ahead 1, behind 2", "gone") をパースする
+func parseUpstreamTrack(track string) (ahead int, behind int, gone bool, err error) {
+	if strings.TrimSpace(track) == "" {
+		return 0, 0, false, nil
+	}
+
+	for _, state := range strings.Split(track, ",") {
+		fields := strings.Fields(state)
+		switch {
+		case len(fields) == 1 && fields[0] == "gone":
+			gone = true
+		case len(fields) == 2 && fields[0] == "ahead":
+			if ahead, err = strconv.Atoi(fields[1]); err != nil {
+				return 0, 0, false, err
+			}
+		case len(fields) == 2 && fields[0] == "behind":
+			if behind, err = strconv.Atoi(fields[1]); err != nil {
+				return 0, 0, false, err
+			}
+		default:
+			return 0, 0, false, fmt.Errorf("unknown upstream track: %s", track)
+		}
+	}
+	return ahead, behind, gone, nil
+}
+
+// 複数選択されたブランチをまとめて扱うための型
+type Branches []*Branch
+
+func (bs Branches) GetFullCommand(actionType ActionType) string {
+	options := bs.GetOptionsWithBranchNames(actionType)
+	onelineOptions := strings.Join(options, " ")
+
+	fullCommand := fmt.Sprintf("%s %s", actionType.Command, onelineOptions)
+	slog.Debug("Command:", "Command", actionType.Name, "fullCommand", fullCommand)
+
+	return fullCommand
+}
+
+func (bs Branches) GetOptionsWithBranchNames(actionType ActionType) []string {
+	ret := actionType.Options
+	for _, b := range bs {
+		ret = append(ret, b.Name)
+	}
+	return ret
+}
+
+// ブランチ名の一覧を返す
+func (bs Branches) GetNames() []string {
+	var ret []string
+	for _, b := range bs {
+		ret = append(ret, b.Name)
+	}
+	return ret
+}
+
+// 保護されたブランチを含めて強制的に push するアクションの場合はエラーを返す
+func (bs Branches) CheckForcePush(actionType ActionType, patterns []string) error {
+	if !IsForcePush(actionType.Command, bs.GetOptionsWithBranchNames(actionType)) {
+		return nil
+	}
+	// 強制的な push かはまとめて実行するコマンドで判定し、保護の確認は1つのブランチの場合と同じく上流ブランチも対象にする
+	for _, b := range bs {
+		if err := b.checkForcePushProtection(patterns); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 選択された全てのブランチに対して実行できるアクションを返す
+func (bs Branches) GetAvailableActionTypes(state RepoState) []ActionType {
+	targets := make([]actionTarget, 0, len(bs))
+	for _, b := range bs {
+		targets = append(targets, b)
+	}
+	return filterAvailableActionTypes(FilterMultipleActionTypes(BranchActionTypes.All()), state, targets...)
+}
+
+// 破壊的なアクションの確認で表示する、失われる可能性がある内容の対象を返す
+func (bs Branches) GetDestructiveScope(actionType ActionType) DestructiveScope {
+	if actionType.IsEqual(BranchActionTypes.Delete) || actionType.IsEqual(BranchActionTypes.ForceDelete) {
+		return DestructiveScope{UnpushedRefs: bs.GetNames()}
+	}
+	return defaultDestructiveScope(bs.GetNames()...)
+}
+
+func (bs Branches) GetFzfInputForSelectActionType(actionType ActionType) string {
+	// fzfに渡す形式: "アクション名\tフルコマンド\t説明文"
+	return fmt.Sprintf("%s\tDescription : %s\tCommand     : %s\n", actionType.Name, actionType.Help, bs.GetFullCommand(actionType))
+}
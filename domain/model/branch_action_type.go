@@ -8,21 +8,108 @@ import (
 
 type BranchActionTypeMap struct {
 	Switch            ActionType
+	SwitchTrack       ActionType
+	Create            ActionType
+	Rename            ActionType
+	SetUpstream       ActionType
+	UnsetUpstream     ActionType
+	Push              ActionType
+	PushSetUpstream   ActionType
+	PushForce         ActionType
+	PullRebase        ActionType
+	FastForward       ActionType
 	GetLastCommitId   ActionType
 	Diff              ActionType
 	RebaseInteractive ActionType
 	Rebase            ActionType
 	Merge             ActionType
 	Delete            ActionType
+	ForceDelete       ActionType
+	Worktree          ActionType
 	Unknown           ActionType
+	// 設定ファイルで定義されたアクション
+	Custom []ActionType
 }
 
 var BranchActionTypes = BranchActionTypeMap{
 	Switch: ActionType{
-		Name:    "switch",
-		Command: "git",
-		Options: []string{"switch"},
-		Help:    "Switch branch to selected branch",
+		Name:       "switch",
+		Command:    "git",
+		Options:    []string{"switch"},
+		Help:       "Switch branch to selected branch",
+		Conditions: []ActionCondition{ConditionLocalBranch, ConditionNotCurrentBranch, ConditionNoOperationInProgress},
+	},
+	SwitchTrack: ActionType{
+		Name:       "switch and track",
+		Command:    "git",
+		Options:    []string{"switch", "--track"},
+		Help:       "Create a local branch tracking the remote branch and switch to it",
+		Conditions: []ActionCondition{ConditionRemoteBranch, ConditionNoOperationInProgress},
+	},
+	Create: ActionType{
+		Name:       "create branch",
+		Command:    "git",
+		Options:    []string{"switch", "-c"},
+		Help:       "Create a new branch from the selected branch and switch to it",
+		Conditions: []ActionCondition{ConditionNoOperationInProgress},
+	},
+	Rename: ActionType{
+		Name:       "rename",
+		Command:    "git",
+		Options:    []string{"branch", "-m"},
+		Help:       "Rename the branch",
+		Conditions: []ActionCondition{ConditionLocalBranch},
+	},
+	SetUpstream: ActionType{
+		Name:       "set upstream",
+		Command:    "git",
+		Options:    []string{"branch"},
+		Help:       "Set the upstream of the branch to a remote branch",
+		Conditions: []ActionCondition{ConditionLocalBranch},
+	},
+	UnsetUpstream: ActionType{
+		Name:       "unset upstream",
+		Command:    "git",
+		Options:    []string{"branch", "--unset-upstream"},
+		Help:       "Remove the upstream of the branch",
+		Conditions: []ActionCondition{ConditionUpstreamConfigured},
+	},
+	Push: ActionType{
+		Name:       "push",
+		Command:    "git",
+		Options:    []string{"push"},
+		Help:       "Push the commits to the upstream branch",
+		Conditions: []ActionCondition{ConditionUpstreamExists, ConditionAhead, ConditionNotBehind},
+	},
+	PushSetUpstream: ActionType{
+		Name:       "push set upstream",
+		Command:    "git",
+		Options:    []string{"push", "--set-upstream"},
+		Help:       "Push the branch to a remote and set it as the upstream",
+		Conditions: []ActionCondition{ConditionNoUpstream},
+	},
+	PushForce: ActionType{
+		Name:        "push force with lease",
+		Command:     "git",
+		Options:     []string{"push", "--force-with-lease"},
+		Help:        "Overwrite the upstream branch unless someone else has pushed to it",
+		Destructive: true,
+		Conditions:  []ActionCondition{ConditionUpstreamExists, ConditionAhead, ConditionBehind, ConditionNotProtectedBranch},
+	},
+	PullRebase: ActionType{
+		Name:       "pull rebase",
+		Command:    "git",
+		Options:    []string{"pull", "--rebase"},
+		Help:       "Fetch the upstream branch and rebase the current branch onto it",
+		Snapshot:   true,
+		Conditions: []ActionCondition{ConditionUpstreamExists, ConditionCurrentBranch, ConditionBehind, ConditionCleanWorkingTree, ConditionNoOperationInProgress},
+	},
+	FastForward: ActionType{
+		Name:       "fast-forward",
+		Command:    "git",
+		Options:    []string{"fetch"},
+		Help:       "Fetch the upstream branch and fast-forward the branch without checking it out",
+		Conditions: []ActionCondition{ConditionUpstreamExists, ConditionNotCurrentBranch, ConditionBehind, ConditionNotAhead},
 	},
 	GetLastCommitId: ActionType{
 		Name:    "get last commit",
@@ -31,34 +118,61 @@ var BranchActionTypes = BranchActionTypeMap{
 		Help:    "print branch last commit id",
 	},
 	Diff: ActionType{
-		Name:    "diff",
-		Command: "git",
-		Options: []string{"diff"},
-		Help:    "Show changes between current branch and selected branch",
+		Name:       "diff",
+		Command:    "git",
+		Options:    []string{"diff"},
+		Help:       "Show changes between current branch and selected branch",
+		Conditions: []ActionCondition{ConditionNotCurrentBranch},
 	},
 	RebaseInteractive: ActionType{
-		Name:    "rebase interactive",
-		Command: "git",
-		Options: []string{"rebase", "-i"},
-		Help:    "Interactive rebase to selected branch",
+		Name:       "rebase interactive",
+		Command:    "git",
+		Options:    []string{"rebase", "-i"},
+		Help:       "Interactive rebase to selected branch",
+		Snapshot:   true,
+		Conditions: []ActionCondition{ConditionNotCurrentBranch, ConditionCleanWorkingTree, ConditionNoOperationInProgress},
 	},
 	Rebase: ActionType{
-		Name:    "rebase",
-		Command: "git",
-		Options: []string{"rebase"},
-		Help:    "Rebase to selected branch",
+		Name:       "rebase",
+		Command:    "git",
+		Options:    []string{"rebase"},
+		Help:       "Rebase to selected branch",
+		Snapshot:   true,
+		Conditions: []ActionCondition{ConditionNotCurrentBranch, ConditionCleanWorkingTree, ConditionNoOperationInProgress},
 	},
 	Merge: ActionType{
-		Name:    "merge",
-		Command: "git",
-		Options: []string{"merge"},
-		Help:    "Merge to selected branch",
+		Name:       "merge",
+		Command:    "git",
+		Options:    []string{"merge"},
+		Help:       "Merge to selected branch",
+		Conditions: []ActionCondition{ConditionNotCurrentBranch, ConditionNoOperationInProgress},
 	},
 	Delete: ActionType{
-		Name:    "delete",
-		Command: "git",
-		Options: []string{"branch", "-d"},
-		Help:    "Delete branch",
+		Name:        "delete",
+		Command:     "git",
+		Options:     []string{"branch", "-d"},
+		Help:        "Delete branch",
+		Multiple:    true,
+		Destructive: true,
+		Snapshot:    true,
+		Conditions:  []ActionCondition{ConditionLocalBranch, ConditionNotCurrentBranch},
+	},
+	ForceDelete: ActionType{
+		Name:        "force delete",
+		Command:     "git",
+		Options:     []string{"branch", "-D"},
+		Help:        "Delete branch even if it is not merged",
+		Multiple:    true,
+		Destructive: true,
+		Snapshot:    true,
+		Conditions:  []ActionCondition{ConditionLocalBranch, ConditionNotCurrentBranch},
+	},
+	Worktree: ActionType{
+		Name:       "open in new worktree",
+		Command:    "git",
+		Options:    []string{"worktree", "add"},
+		Help:       "Check out the branch in a new worktree",
+		Conditions: []ActionCondition{ConditionNotCurrentBranch},
 	},
 	Unknown: ActionType{
 		Name:    "unknown",
@@ -69,21 +183,59 @@ var BranchActionTypes = BranchActionTypeMap{
 }
 
 func (b BranchActionTypeMap) All() []ActionType {
-	return []ActionType{
+	builtins := []ActionType{
 		b.Switch,
+		b.SwitchTrack,
+		b.Create,
+		b.Rename,
+		b.SetUpstream,
+		b.UnsetUpstream,
+		b.Push,
+		b.PushSetUpstream,
+		b.PushForce,
+		b.PullRebase,
+		b.FastForward,
 		b.Diff,
 		b.Delete,
+		b.ForceDelete,
 		b.RebaseInteractive,
 		b.Rebase,
 		b.Merge,
 		b.GetLastCommitId,
+		b.Worktree,
 	}
+	return mergeCustomActionTypes(builtins, b.Custom)
 }
 
 func (b BranchActionTypeMap) GetBranchActionTypes(action string) (ActionType, error) {
+	// 組み込みのアクションと同じ名前の独自のアクションは、組み込みのアクションを置き換える
+	if custom, ok := findCustomActionType(b.Custom, action); ok {
+		return custom, nil
+	}
+
 	switch action {
 	case "switch":
 		return b.Switch, nil
+	case "switch and track":
+		return b.SwitchTrack, nil
+	case "create branch":
+		return b.Create, nil
+	case "rename":
+		return b.Rename, nil
+	case "set upstream":
+		return b.SetUpstream, nil
+	case "unset upstream":
+		return b.UnsetUpstream, nil
+	case "push":
+		return b.Push, nil
+	case "push set upstream":
+		return b.PushSetUpstream, nil
+	case "push force with lease":
+		return b.PushForce, nil
+	case "pull rebase":
+		return b.PullRebase, nil
+	case "fast-forward":
+		return b.FastForward, nil
 	case "get last commit":
 		return b.GetLastCommitId, nil
 	case "diff":
@@ -96,6 +248,10 @@ func (b BranchActionTypeMap) GetBranchActionTypes(action string) (ActionType, er
 		return b.Merge, nil
 	case "delete":
 		return b.Delete, nil
+	case "force delete":
+		return b.ForceDelete, nil
+	case "open in new worktree":
+		return b.Worktree, nil
 	default:
 		return b.Unknown, fmt.Errorf("unknown action: %s", action)
 	}
@@ -81,6 +81,15 @@ func TestBranchActionTypeMap_GetBranchActionTypes(t *testing.T) {
 			wantErr:        false,
 			wantErrMessage: nil,
 		},
+		{
+			name: "対応するブランチアクション(Worktree)を取得すること",
+			args: args{
+				action: "open in new worktree",
+			},
+			want:           BranchActionTypes.Worktree,
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
 		{
 			name: "不明なアクションが指定された場合、errorを返却すること",
 			args: args{
@@ -117,12 +126,24 @@ func TestBranchActionTypeMap_All(t *testing.T) {
 			name: "Unknown以外の全てのブランチアクションを取得すること",
 			want: []ActionType{
 				BranchActionTypes.Switch,
+				BranchActionTypes.SwitchTrack,
+				BranchActionTypes.Create,
+				BranchActionTypes.Rename,
+				BranchActionTypes.SetUpstream,
+				BranchActionTypes.UnsetUpstream,
+				BranchActionTypes.Push,
+				BranchActionTypes.PushSetUpstream,
+				BranchActionTypes.PushForce,
+				BranchActionTypes.PullRebase,
+				BranchActionTypes.FastForward,
 				BranchActionTypes.Diff,
 				BranchActionTypes.Delete,
+				BranchActionTypes.ForceDelete,
 				BranchActionTypes.RebaseInteractive,
 				BranchActionTypes.Rebase,
 				BranchActionTypes.Merge,
 				BranchActionTypes.GetLastCommitId,
+				BranchActionTypes.Worktree,
 			},
 		},
 	}
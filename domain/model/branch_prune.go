@@ -0,0 +1,128 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// 不要なブランチを探すときの基準
+type PruneRule struct {
+	// マージ済みか判定する基準のブランチ (例: origin/main)
+	Base string
+	// 指定した日数以上コミットがないブランチを不要とみなす (0 の場合は判定しない)
+	StaleDays int
+}
+
+// 削除候補のブランチと、不要と判定した理由
+type PruneCandidate struct {
+	Branch  *Branch
+	Reasons []string
+	// 基準のブランチにマージ済みか
+	Merged bool
+	// 一定期間コミットがないか
+	Stale bool
+}
+
+// git branch -D で強制的に削除する必要があるか
+// マージされていない、一定期間コミットがないだけのブランチは未プッシュのコミットを含む可能性がある
+// マージ済みや上流ブランチが削除済みのブランチは git branch -d で削除し、マージされていない場合は git に削除を拒否させる
+func (pc PruneCandidate) RequiresForceDelete() bool {
+	return pc.Stale && !pc.Merged
+}
+
+func (pc PruneCandidate) String() string {
+	return pc.Branch.Name
+}
+
+// --list で出力する項目を返す
+func (pc PruneCandidate) GetListItem() ListItem {
+	return ListItem{
+		{Key: "name", Value: pc.Branch.Name},
+		{Key: "reasons", Value: pc.Reasons},
+		{Key: "upstream", Value: pc.Branch.Upstream},
+		{Key: "last_commit_id", Value: pc.Branch.LastCommitId},
+		{Key: "last_commit_date", Value: formatCommitDate(pc.Branch.LastCommitDate)},
+		{Key: "last_commit_message", Value: pc.Branch.LastCommitMessage},
+	}
+}
+
+// fzfの候補として表示する1行を返す
+// 先頭はブランチ名 (fzfのプレビューと選択結果のパースで使う)
+// 例: "feature 1a2b3c4 [merged into origin/main, upstream gone] 2024-01-02 add feature"
+func (pc PruneCandidate) GetFzfLine() string {
+	fields := []string{pc.Branch.Name, pc.Branch.LastCommitId, "[" + strings.Join(pc.Reasons, ", ") + "]"}
+	if !pc.Branch.LastCommitDate.IsZero() {
+		fields = append(fields, pc.Branch.LastCommitDate.Format("2006-01-02"))
+	}
+	fields = append(fields, pc.Branch.LastCommitMessage)
+	return strings.Join(fields, " ")
+}
+
+func FindPruneCandidateByBranchName(candidates []*PruneCandidate, branchName string) (*PruneCandidate, error) {
+	for _, candidate := range candidates {
+		if candidate.Branch.Name == branchName {
+			return candidate, nil
+		}
+	}
+	return nil, fmt.Errorf("branch %s not found", branchName)
+}
+
+// ローカルブランチのうち、基準のブランチにマージ済み・上流ブランチが削除済み・一定期間コミットがないものを削除候補として返す
+// merged には基準のブランチにマージ済みのブランチ名を指定する
+// 現在のブランチと基準のブランチ (リモート追跡ブランチの場合は同名のローカルブランチ) は候補にしない
+func FindPruneCandidates(branches []*Branch, merged []string, rule PruneRule, now time.Time) []*PruneCandidate {
+	mergedNames := make(map[string]bool, len(merged))
+	for _, name := range merged {
+		mergedNames[name] = true
+	}
+
+	var candidates []*PruneCandidate
+	for _, branch := range branches {
+		if branch.Remote || branch.Current || isBaseBranch(branch, rule.Base) {
+			continue
+		}
+
+		candidate := &PruneCandidate{Branch: branch}
+		if mergedNames[branch.Name] {
+			candidate.Merged = true
+			candidate.Reasons = append(candidate.Reasons, "merged into "+rule.Base)
+		}
+		if branch.UpstreamGone {
+			candidate.Reasons = append(candidate.Reasons, "upstream gone")
+		}
+		if rule.StaleDays > 0 && !branch.LastCommitDate.IsZero() {
+			if days := int(now.Sub(branch.LastCommitDate).Hours() / 24); days >= rule.StaleDays {
+				candidate.Stale = true
+				candidate.Reasons = append(candidate.Reasons, fmt.Sprintf("no commits in %d days", days))
+			}
+		}
+
+		if len(candidate.Reasons) > 0 {
+			candidates = append(candidates, candidate)
+		}
+	}
+	return candidates
+}
+
+// 基準のブランチ自身か (例: 基準が origin/main の場合の main)
+func isBaseBranch(branch *Branch, base string) bool {
+	if branch.Name == base {
+		return true
+	}
+	_, localName, ok := strings.Cut(base, "/")
+	return ok && branch.Upstream == base && branch.Name == localName
+}
+
+// 削除候補のブランチを、git branch -d で削除するブランチと git branch -D で強制的に削除するブランチに分ける
+func SplitPruneCandidateBranches(candidates []*PruneCandidate) (Branches, Branches) {
+	var deletable, forceDeletable Branches
+	for _, candidate := range candidates {
+		if candidate.RequiresForceDelete() {
+			forceDeletable = append(forceDeletable, candidate.Branch)
+			continue
+		}
+		deletable = append(deletable, candidate.Branch)
+	}
+	return deletable, forceDeletable
+}
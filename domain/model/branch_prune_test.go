@@ -0,0 +1,151 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFindPruneCandidates(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	current := &Branch{Name: "current", Current: true, LastCommitId: "1a2b3c4", LastCommitMessage: "commit message", LastCommitDate: now, ActionTypes: BranchActionTypes.All()}
+	main := &Branch{Name: "main", Upstream: "origin/main", LastCommitId: "1a2b3c4", LastCommitMessage: "commit message", LastCommitDate: now, ActionTypes: BranchActionTypes.All()}
+	remote := &Branch{Name: "origin/merged", Remote: true, RemoteName: "origin", LastCommitId: "1a2b3c4", LastCommitMessage: "commit message", LastCommitDate: now, ActionTypes: BranchActionTypes.All()}
+	merged := &Branch{Name: "merged", LastCommitId: "1a2b3c4", LastCommitMessage: "commit message", LastCommitDate: now, ActionTypes: BranchActionTypes.All()}
+	gone := &Branch{Name: "gone", Upstream: "origin/gone", UpstreamGone: true, LastCommitId: "1a2b3c4", LastCommitMessage: "commit message", LastCommitDate: now, ActionTypes: BranchActionTypes.All()}
+	stale := &Branch{Name: "stale", LastCommitId: "1a2b3c4", LastCommitMessage: "commit message", LastCommitDate: now.AddDate(0, 0, -120), ActionTypes: BranchActionTypes.All()}
+	active := &Branch{Name: "active", LastCommitId: "1a2b3c4", LastCommitMessage: "commit message", LastCommitDate: now.AddDate(0, 0, -10), ActionTypes: BranchActionTypes.All()}
+
+	tests := []struct {
+		name     string
+		branches []*Branch
+		merged   []string
+		rule     PruneRule
+		want     []*PruneCandidate
+	}{
+		{
+			name:     "マージ済み、上流ブランチが削除済み、一定期間コミットがないブランチを理由と共に返すこと",
+			branches: []*Branch{merged, gone, stale, active},
+			merged:   []string{"merged"},
+			rule:     PruneRule{Base: "origin/main", StaleDays: 90},
+			want: []*PruneCandidate{
+				{Branch: merged, Reasons: []string{"merged into origin/main"}, Merged: true},
+				{Branch: gone, Reasons: []string{"upstream gone"}},
+				{Branch: stale, Reasons: []string{"no commits in 120 days"}, Stale: true},
+			},
+		},
+		{
+			name:     "現在のブランチ、基準のブランチ、リモート追跡ブランチは候補にしないこと",
+			branches: []*Branch{current, main, remote},
+			merged:   []string{"current", "main", "origin/merged"},
+			rule:     PruneRule{Base: "origin/main", StaleDays: 90},
+			want:     nil,
+		},
+		{
+			name:     "日数が0の場合はコミットがない期間で判定しないこと",
+			branches: []*Branch{stale},
+			merged:   nil,
+			rule:     PruneRule{Base: "main", StaleDays: 0},
+			want:     nil,
+		},
+		{
+			name:     "複数の理由に当てはまる場合は全ての理由を返すこと",
+			branches: []*Branch{gone, stale},
+			merged:   []string{"gone", "stale"},
+			rule:     PruneRule{Base: "main", StaleDays: 90},
+			want: []*PruneCandidate{
+				{Branch: gone, Reasons: []string{"merged into main", "upstream gone"}, Merged: true},
+				{Branch: stale, Reasons: []string{"merged into main", "no commits in 120 days"}, Merged: true, Stale: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := FindPruneCandidates(tt.branches, tt.merged, tt.rule, now); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindPruneCandidates() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPruneCandidate_GetFzfLine(t *testing.T) {
+	t.Parallel()
+
+	branch := NewBranch(false, "feature", "1a2b3c4", "add feature")
+	branch.LastCommitDate = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		candidate PruneCandidate
+		want      string
+	}{
+		{
+			name:      "ブランチ名、コミットID、理由、日付、件名の順に表示すること",
+			candidate: PruneCandidate{Branch: branch, Reasons: []string{"merged into origin/main", "upstream gone"}},
+			want:      "feature 1a2b3c4 [merged into origin/main, upstream gone] 2024-01-02 add feature",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.candidate.GetFzfLine(); got != tt.want {
+				t.Errorf("PruneCandidate.GetFzfLine() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitPruneCandidateBranches(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		candidates []*PruneCandidate
+	}
+	tests := []struct {
+		name               string
+		args               args
+		wantDeletable      Branches
+		wantForceDeletable Branches
+	}{
+		{
+			name: "マージ済みと上流ブランチが削除済みのブランチは強制的に削除しないこと",
+			args: args{
+				candidates: []*PruneCandidate{
+					{Branch: &Branch{Name: "merged"}, Reasons: []string{"merged into origin/main"}, Merged: true},
+					{Branch: &Branch{Name: "gone"}, Reasons: []string{"upstream gone"}},
+				},
+			},
+			wantDeletable:      Branches{{Name: "merged"}, {Name: "gone"}},
+			wantForceDeletable: nil,
+		},
+		{
+			name: "マージされていない、一定期間コミットがないブランチだけを強制的に削除すること",
+			args: args{
+				candidates: []*PruneCandidate{
+					{Branch: &Branch{Name: "stale-merged"}, Reasons: []string{"merged into origin/main", "no commits in 120 days"}, Merged: true, Stale: true},
+					{Branch: &Branch{Name: "stale"}, Reasons: []string{"no commits in 120 days"}, Stale: true},
+					{Branch: &Branch{Name: "stale-gone"}, Reasons: []string{"upstream gone", "no commits in 120 days"}, Stale: true},
+				},
+			},
+			wantDeletable:      Branches{{Name: "stale-merged"}},
+			wantForceDeletable: Branches{{Name: "stale"}, {Name: "stale-gone"}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			gotDeletable, gotForceDeletable := SplitPruneCandidateBranches(tt.args.candidates)
+			if !reflect.DeepEqual(gotDeletable, tt.wantDeletable) {
+				t.Errorf("SplitPruneCandidateBranches() deletable = %v, want %v", gotDeletable, tt.wantDeletable)
+			}
+			if !reflect.DeepEqual(gotForceDeletable, tt.wantForceDeletable) {
+				t.Errorf("SplitPruneCandidateBranches() forceDeletable = %v, want %v", gotForceDeletable, tt.wantForceDeletable)
+			}
+		})
+	}
+}
@@ -1,9 +1,11 @@
 package model
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestFindBranchById(t *testing.T) {
@@ -22,13 +24,13 @@ func TestFindBranchById(t *testing.T) {
 			name: "branchNameを指定して、branchを取得すること",
 			args: args{
 				branches: []*Branch{
-					NewBranch(true, "branch1", "branch1", "branch1", "branch1 branch1 branch1"),
-					NewBranch(false, "branch2", "branch2", "branch2", "branch2 branch2 branch2"),
-					NewBranch(false, "branch3", "branch3", "branch3", "branch3 branch3 branch3"),
+					NewBranch(true, "branch1", "branch1", "branch1"),
+					NewBranch(false, "branch2", "branch2", "branch2"),
+					NewBranch(false, "branch3", "branch3", "branch3"),
 				},
 				branchName: "branch2",
 			},
-			want:           NewBranch(false, "branch2", "branch2", "branch2", "branch2 branch2 branch2"),
+			want:           NewBranch(false, "branch2", "branch2", "branch2"),
 			wantErr:        false,
 			wantErrMessage: nil,
 		},
@@ -36,9 +38,9 @@ func TestFindBranchById(t *testing.T) {
 			name: "指定したbranchNameに該当するbranchが存在しない場合にエラーを返すこと",
 			args: args{
 				branches: []*Branch{
-					NewBranch(true, "branch1", "branch1", "branch1", "branch1 branch1 branch1"),
-					NewBranch(false, "branch2", "branch2", "branch2", "branch2 branch2 branch2"),
-					NewBranch(false, "branch3", "branch3", "branch3", "branch3 branch3 branch3"),
+					NewBranch(true, "branch1", "branch1", "branch1"),
+					NewBranch(false, "branch2", "branch2", "branch2"),
+					NewBranch(false, "branch3", "branch3", "branch3"),
 				},
 				branchName: "dummy",
 			},
@@ -71,3 +73,830 @@ func TestFindBranchById(t *testing.T) {
 		})
 	}
 }
+
+func TestBranches_GetFullCommand(t *testing.T) {
+	t.Parallel()
+	branches := Branches{
+		NewBranch(false, "feature/a", "aaa", "a"),
+		NewBranch(false, "feature/b", "bbb", "b"),
+	}
+	want := "git branch -d feature/a feature/b"
+	if got := branches.GetFullCommand(BranchActionTypes.Delete); got != want {
+		t.Errorf("Branches.GetFullCommand() = %v, want %v", got, want)
+	}
+}
+
+func TestBranches_GetNames(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		branches Branches
+	}
+	tests := []struct {
+		name string
+		args args
+		want []string
+	}{
+		{
+			name: "選択された順にブランチ名を返却すること",
+			args: args{
+				branches: Branches{
+					{Name: "feature/a"},
+					{Name: "feature/b"},
+				},
+			},
+			want: []string{"feature/a", "feature/b"},
+		},
+		{
+			name: "ブランチがない場合、nilを返却すること",
+			args: args{
+				branches: nil,
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.args.branches.GetNames(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Branches.GetNames() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBranch_GetWorktreeActionType(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		branch Branch
+		path   string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "ローカルブランチの場合はブランチをそのままチェックアウトすること",
+			args: args{
+				branch: Branch{Name: "feature"},
+				path:   "../repo-feature",
+			},
+			want: "git worktree add ../repo-feature feature",
+		},
+		{
+			name: "リモート追跡ブランチの場合は同じ名前のローカルブランチを作成して追跡すること",
+			args: args{
+				branch: Branch{Name: "origin/feature", Remote: true, RemoteName: "origin"},
+				path:   "../repo-feature",
+			},
+			want: "git worktree add --track -b feature ../repo-feature origin/feature",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			actionType := tt.args.branch.GetWorktreeActionType(tt.args.path)
+			if got := tt.args.branch.GetFullCommand(actionType); got != tt.want {
+				t.Errorf("Branch.GetWorktreeActionType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveWorktreeBranch(t *testing.T) {
+	t.Parallel()
+	local := &Branch{Name: "feature"}
+	remote := &Branch{Name: "origin/feature", Remote: true, RemoteName: "origin"}
+	other := &Branch{Name: "origin/other", Remote: true, RemoteName: "origin"}
+	type args struct {
+		branches []*Branch
+		branch   *Branch
+	}
+	tests := []struct {
+		name string
+		args args
+		want *Branch
+	}{
+		{
+			name: "同じ名前のローカルブランチがある場合はローカルブランチを返却すること",
+			args: args{
+				branches: []*Branch{local, remote, other},
+				branch:   remote,
+			},
+			want: local,
+		},
+		{
+			name: "同じ名前のローカルブランチがない場合はリモート追跡ブランチを返却すること",
+			args: args{
+				branches: []*Branch{local, remote, other},
+				branch:   other,
+			},
+			want: other,
+		},
+		{
+			name: "ローカルブランチの場合はそのまま返却すること",
+			args: args{
+				branches: []*Branch{local, remote},
+				branch:   local,
+			},
+			want: local,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := ResolveWorktreeBranch(tt.args.branches, tt.args.branch); got != tt.want {
+				t.Errorf("ResolveWorktreeBranch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBranch_GetOptionsWithBranchInfo(t *testing.T) {
+	t.Parallel()
+	branch := NewBranch(false, "feature", "1a2b3c4", "add feature")
+
+	// 上流ブランチの名前がローカルブランチと異なる場合
+	upstreamBranch := NewBranch(false, "feature", "1a2b3c4", "add feature")
+	upstreamBranch.Upstream = "upstream/topic"
+	upstreamBranch.RemoteName = "upstream"
+
+	tests := []struct {
+		name       string
+		branch     *Branch
+		actionType ActionType
+		want       []string
+	}{
+		{
+			name:       "ブランチを作成する場合は新しいブランチ名の後に作成元のブランチを指定すること",
+			branch:     branch,
+			actionType: BranchActionTypes.Create.WithOptions("feature-2"),
+			want:       []string{"switch", "-c", "feature-2", "feature"},
+		},
+		{
+			name:       "名前を変更する場合は対象のブランチの後に変更後のブランチ名を指定すること",
+			branch:     branch,
+			actionType: BranchActionTypes.Rename.WithTrailingOptions("feature-renamed"),
+			want:       []string{"branch", "-m", "feature", "feature-renamed"},
+		},
+		{
+			name:       "pushする場合は上流ブランチのリモートとブランチ名を指定すること",
+			branch:     upstreamBranch,
+			actionType: BranchActionTypes.Push,
+			want:       []string{"push", "upstream", "feature:topic"},
+		},
+		{
+			name:       "fast-forwardする場合は上流ブランチを対象のブランチにfetchすること",
+			branch:     upstreamBranch,
+			actionType: BranchActionTypes.FastForward,
+			want:       []string{"fetch", "upstream", "topic:feature"},
+		},
+		{
+			name:       "上流ブランチを設定する場合は--set-upstream-toの後に対象のブランチを指定すること",
+			branch:     branch,
+			actionType: BranchActionTypes.SetUpstream.WithOptions("--set-upstream-to=origin/feature"),
+			want:       []string{"branch", "--set-upstream-to=origin/feature", "feature"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.branch.GetOptionsWithBranchInfo(tt.actionType); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Branch.GetOptionsWithBranchInfo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsForcePush(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		command string
+		options []string
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "--force-with-leaseを指定したpushは強制的なpushとみなすこと",
+			args: args{
+				command: "git",
+				options: []string{"push", "--force-with-lease", "origin", "main"},
+			},
+			want: true,
+		},
+		{
+			name: "まとめて指定された-fも強制的なpushとみなすこと",
+			args: args{
+				command: "git",
+				options: []string{"push", "-uf", "origin", "main"},
+			},
+			want: true,
+		},
+		{
+			name: "+で始まるrefspecは強制的なpushとみなすこと",
+			args: args{
+				command: "git",
+				options: []string{"push", "origin", "+main"},
+			},
+			want: true,
+		},
+		{
+			name: "通常のpushは強制的なpushとみなさないこと",
+			args: args{
+				command: "git",
+				options: []string{"push", "--follow-tags", "origin", "main"},
+			},
+			want: false,
+		},
+		{
+			name: "push以外のコマンドの-fは強制的なpushとみなさないこと",
+			args: args{
+				command: "git",
+				options: []string{"checkout", "-f", "main"},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := IsForcePush(tt.args.command, tt.args.options); got != tt.want {
+				t.Errorf("IsForcePush() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBranch_CheckForcePush(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		branch     Branch
+		actionType ActionType
+		patterns   []string
+	}
+	tests := []struct {
+		name           string
+		args           args
+		wantErr        bool
+		wantErrMessage error
+	}{
+		{
+			name: "保護されたブランチを強制的にpushする場合はエラーを返すこと",
+			args: args{
+				branch:     Branch{Name: "main", Upstream: "origin/main", RemoteName: "origin"},
+				actionType: BranchActionTypes.PushForce,
+				patterns:   []string{"main", "release/*"},
+			},
+			wantErr:        true,
+			wantErrMessage: errors.New("refusing to force-push the protected branch main (GITMAN_PROTECTED_BRANCHES)"),
+		},
+		{
+			name: "独自のアクションで保護されたブランチを強制的にpushする場合もエラーを返すこと",
+			args: args{
+				branch: Branch{Name: "release/1.0", Upstream: "origin/release/1.0", RemoteName: "origin"},
+				actionType: ActionType{
+					Name:    "force push",
+					Command: "git",
+					Custom:  true,
+					Args:    []string{"push", "-f", "origin", "{{.Name}}"},
+				},
+				patterns: []string{"main", "release/*"},
+			},
+			wantErr:        true,
+			wantErrMessage: errors.New("refusing to force-push the protected branch release/1.0 (GITMAN_PROTECTED_BRANCHES)"),
+		},
+		{
+			name: "push先の上流ブランチが保護されたブランチの場合もエラーを返すこと",
+			args: args{
+				branch:     Branch{Name: "feature", Upstream: "origin/main", RemoteName: "origin"},
+				actionType: BranchActionTypes.PushForce,
+				patterns:   []string{"main"},
+			},
+			wantErr:        true,
+			wantErrMessage: errors.New("refusing to force-push the protected branch feature (GITMAN_PROTECTED_BRANCHES)"),
+		},
+		{
+			name: "保護されていないブランチは強制的にpushできること",
+			args: args{
+				branch:     Branch{Name: "feature", Upstream: "origin/feature", RemoteName: "origin"},
+				actionType: BranchActionTypes.PushForce,
+				patterns:   []string{"main"},
+			},
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "保護されたブランチでも強制的でないpushはできること",
+			args: args{
+				branch:     Branch{Name: "main", Upstream: "origin/main", RemoteName: "origin"},
+				actionType: BranchActionTypes.Push,
+				patterns:   []string{"main"},
+			},
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.args.branch.CheckForcePush(tt.args.actionType, tt.args.patterns)
+			if (err != nil) != tt.wantErr || err != nil && err.Error() != tt.wantErrMessage.Error() {
+				t.Errorf("Branch.CheckForcePush() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("Branch.CheckForcePush() error = %v, wantErrMessage %v", err, tt.wantErrMessage)
+			}
+		})
+	}
+}
+
+func TestBranches_CheckForcePush(t *testing.T) {
+	t.Parallel()
+	forcePush := ActionType{
+		Name:     "force push all",
+		Command:  "git",
+		Custom:   true,
+		Multiple: true,
+		Options:  []string{"push", "-f", "origin"},
+	}
+	type args struct {
+		branches   Branches
+		actionType ActionType
+		patterns   []string
+	}
+	tests := []struct {
+		name           string
+		args           args
+		wantErr        bool
+		wantErrMessage error
+	}{
+		{
+			name: "保護されたブランチを含めて強制的にpushする場合はエラーを返すこと",
+			args: args{
+				branches: Branches{
+					{Name: "feature", Upstream: "origin/feature", RemoteName: "origin"},
+					{Name: "main", Upstream: "origin/main", RemoteName: "origin"},
+				},
+				actionType: forcePush,
+				patterns:   []string{"main"},
+			},
+			wantErr:        true,
+			wantErrMessage: errors.New("refusing to force-push the protected branch main (GITMAN_PROTECTED_BRANCHES)"),
+		},
+		{
+			name: "push先の上流ブランチが保護されたブランチの場合もエラーを返すこと",
+			args: args{
+				branches: Branches{
+					{Name: "feature", Upstream: "origin/feature", RemoteName: "origin"},
+					{Name: "hotfix", Upstream: "origin/main", RemoteName: "origin"},
+				},
+				actionType: forcePush,
+				patterns:   []string{"main"},
+			},
+			wantErr:        true,
+			wantErrMessage: errors.New("refusing to force-push the protected branch hotfix (GITMAN_PROTECTED_BRANCHES)"),
+		},
+		{
+			name: "保護されていないブランチは強制的にpushできること",
+			args: args{
+				branches: Branches{
+					{Name: "feature", Upstream: "origin/feature", RemoteName: "origin"},
+					{Name: "hotfix", Upstream: "origin/hotfix", RemoteName: "origin"},
+				},
+				actionType: forcePush,
+				patterns:   []string{"main"},
+			},
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.args.branches.CheckForcePush(tt.args.actionType, tt.args.patterns)
+			if (err != nil) != tt.wantErr || err != nil && err.Error() != tt.wantErrMessage.Error() {
+				t.Errorf("Branches.CheckForcePush() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("Branches.CheckForcePush() error = %v, wantErrMessage %v", err, tt.wantErrMessage)
+			}
+		})
+	}
+}
+
+func TestBranch_GetAvailableActionTypes(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		branch Branch
+		state  RepoState
+	}
+	tests := []struct {
+		name string
+		args args
+		want []ActionType
+	}{
+		{
+			name: "リモート追跡ブランチは追跡するブランチを作成、差分、マージができること",
+			args: args{
+				branch: Branch{Name: "origin/feature", Remote: true, RemoteName: "origin", ActionTypes: BranchActionTypes.All()},
+				state:  RepoState{},
+			},
+			want: []ActionType{
+				BranchActionTypes.SwitchTrack,
+				BranchActionTypes.Create,
+				BranchActionTypes.Diff,
+				BranchActionTypes.RebaseInteractive,
+				BranchActionTypes.Rebase,
+				BranchActionTypes.Merge,
+				BranchActionTypes.GetLastCommitId,
+				BranchActionTypes.Worktree,
+			},
+		},
+		{
+			name: "上流ブランチと同じローカルブランチは上流ブランチの変更や解除ができること",
+			args: args{
+				branch: Branch{Name: "feature", Upstream: "origin/feature", RemoteName: "origin", ActionTypes: BranchActionTypes.All()},
+				state:  RepoState{},
+			},
+			want: []ActionType{
+				BranchActionTypes.Switch,
+				BranchActionTypes.Create,
+				BranchActionTypes.Rename,
+				BranchActionTypes.SetUpstream,
+				BranchActionTypes.UnsetUpstream,
+				BranchActionTypes.Diff,
+				BranchActionTypes.Delete,
+				BranchActionTypes.ForceDelete,
+				BranchActionTypes.RebaseInteractive,
+				BranchActionTypes.Rebase,
+				BranchActionTypes.Merge,
+				BranchActionTypes.GetLastCommitId,
+				BranchActionTypes.Worktree,
+			},
+		},
+		{
+			name: "上流ブランチがないローカルブランチは上流ブランチを設定してpushできること",
+			args: args{
+				branch: Branch{Name: "feature", ActionTypes: BranchActionTypes.All()},
+				state:  RepoState{},
+			},
+			want: []ActionType{
+				BranchActionTypes.Switch,
+				BranchActionTypes.Create,
+				BranchActionTypes.Rename,
+				BranchActionTypes.SetUpstream,
+				BranchActionTypes.PushSetUpstream,
+				BranchActionTypes.Diff,
+				BranchActionTypes.Delete,
+				BranchActionTypes.ForceDelete,
+				BranchActionTypes.RebaseInteractive,
+				BranchActionTypes.Rebase,
+				BranchActionTypes.Merge,
+				BranchActionTypes.GetLastCommitId,
+				BranchActionTypes.Worktree,
+			},
+		},
+		{
+			name: "上流ブランチより進んでいる現在のブランチはpushできること",
+			args: args{
+				branch: Branch{Name: "feature", Current: true, Upstream: "origin/feature", RemoteName: "origin", Ahead: 2, ActionTypes: BranchActionTypes.All()},
+				state:  RepoState{},
+			},
+			want: []ActionType{
+				BranchActionTypes.Create,
+				BranchActionTypes.Rename,
+				BranchActionTypes.SetUpstream,
+				BranchActionTypes.UnsetUpstream,
+				BranchActionTypes.Push,
+				BranchActionTypes.GetLastCommitId,
+			},
+		},
+		{
+			name: "上流ブランチと分岐している現在のブランチは強制的なpushとpull rebaseができること",
+			args: args{
+				branch: Branch{Name: "feature", Current: true, Upstream: "origin/feature", RemoteName: "origin", Ahead: 1, Behind: 1, ActionTypes: BranchActionTypes.All()},
+				state:  RepoState{},
+			},
+			want: []ActionType{
+				BranchActionTypes.Create,
+				BranchActionTypes.Rename,
+				BranchActionTypes.SetUpstream,
+				BranchActionTypes.UnsetUpstream,
+				BranchActionTypes.PushForce,
+				BranchActionTypes.PullRebase,
+				BranchActionTypes.GetLastCommitId,
+			},
+		},
+		{
+			name: "保護されたブランチは強制的にpushできないこと",
+			args: args{
+				branch: Branch{Name: "release/1.0", Current: true, Upstream: "origin/release/1.0", RemoteName: "origin", Ahead: 1, Behind: 1, ActionTypes: BranchActionTypes.All()},
+				state:  RepoState{ProtectedBranches: []string{"main", "release/*"}},
+			},
+			want: []ActionType{
+				BranchActionTypes.Create,
+				BranchActionTypes.Rename,
+				BranchActionTypes.SetUpstream,
+				BranchActionTypes.UnsetUpstream,
+				BranchActionTypes.PullRebase,
+				BranchActionTypes.GetLastCommitId,
+			},
+		},
+		{
+			name: "チェックアウトされていないブランチが遅れているだけの場合はfast-forwardできること",
+			args: args{
+				branch: Branch{Name: "feature", Upstream: "origin/feature", RemoteName: "origin", Behind: 3, ActionTypes: BranchActionTypes.All()},
+				state:  RepoState{},
+			},
+			want: []ActionType{
+				BranchActionTypes.Switch,
+				BranchActionTypes.Create,
+				BranchActionTypes.Rename,
+				BranchActionTypes.SetUpstream,
+				BranchActionTypes.UnsetUpstream,
+				BranchActionTypes.FastForward,
+				BranchActionTypes.Diff,
+				BranchActionTypes.Delete,
+				BranchActionTypes.ForceDelete,
+				BranchActionTypes.RebaseInteractive,
+				BranchActionTypes.Rebase,
+				BranchActionTypes.Merge,
+				BranchActionTypes.GetLastCommitId,
+				BranchActionTypes.Worktree,
+			},
+		},
+		{
+			name: "上流ブランチが削除された場合は上流ブランチを設定してpushできること",
+			args: args{
+				branch: Branch{Name: "feature", Upstream: "origin/feature", RemoteName: "origin", UpstreamGone: true, ActionTypes: BranchActionTypes.All()},
+				state:  RepoState{},
+			},
+			want: []ActionType{
+				BranchActionTypes.Switch,
+				BranchActionTypes.Create,
+				BranchActionTypes.Rename,
+				BranchActionTypes.SetUpstream,
+				BranchActionTypes.UnsetUpstream,
+				BranchActionTypes.PushSetUpstream,
+				BranchActionTypes.Diff,
+				BranchActionTypes.Delete,
+				BranchActionTypes.ForceDelete,
+				BranchActionTypes.RebaseInteractive,
+				BranchActionTypes.Rebase,
+				BranchActionTypes.Merge,
+				BranchActionTypes.GetLastCommitId,
+				BranchActionTypes.Worktree,
+			},
+		},
+		{
+			name: "未コミットの変更がある場合は現在のブランチをpull rebaseできないこと",
+			args: args{
+				branch: Branch{Name: "feature", Current: true, Upstream: "origin/feature", RemoteName: "origin", Behind: 1, ActionTypes: BranchActionTypes.All()},
+				state:  RepoState{Dirty: true},
+			},
+			want: []ActionType{
+				BranchActionTypes.Create,
+				BranchActionTypes.Rename,
+				BranchActionTypes.SetUpstream,
+				BranchActionTypes.UnsetUpstream,
+				BranchActionTypes.GetLastCommitId,
+			},
+		},
+		{
+			name: "未コミットの変更がある場合はrebaseできないが、マージはできること",
+			args: args{
+				branch: Branch{Name: "feature", Upstream: "origin/feature", RemoteName: "origin", ActionTypes: BranchActionTypes.All()},
+				state:  RepoState{Dirty: true},
+			},
+			want: []ActionType{
+				BranchActionTypes.Switch,
+				BranchActionTypes.Create,
+				BranchActionTypes.Rename,
+				BranchActionTypes.SetUpstream,
+				BranchActionTypes.UnsetUpstream,
+				BranchActionTypes.Diff,
+				BranchActionTypes.Delete,
+				BranchActionTypes.ForceDelete,
+				BranchActionTypes.Merge,
+				BranchActionTypes.GetLastCommitId,
+				BranchActionTypes.Worktree,
+			},
+		},
+		{
+			name: "rebaseの途中は切り替えやrebase、マージができないこと",
+			args: args{
+				branch: Branch{Name: "feature", Upstream: "origin/feature", RemoteName: "origin", ActionTypes: BranchActionTypes.All()},
+				state:  RepoState{OperationInProgress: "rebase"},
+			},
+			want: []ActionType{
+				BranchActionTypes.Rename,
+				BranchActionTypes.SetUpstream,
+				BranchActionTypes.UnsetUpstream,
+				BranchActionTypes.Diff,
+				BranchActionTypes.Delete,
+				BranchActionTypes.ForceDelete,
+				BranchActionTypes.GetLastCommitId,
+				BranchActionTypes.Worktree,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.args.branch.GetAvailableActionTypes(tt.args.state); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Branch.GetAvailableActionTypes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBranches_GetAvailableActionTypes(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		branches Branches
+		state    RepoState
+	}
+	tests := []struct {
+		name string
+		args args
+		want []ActionType
+	}{
+		{
+			name: "ローカルブランチだけを選択した場合は削除できること",
+			args: args{
+				branches: Branches{
+					&Branch{Name: "feature", ActionTypes: BranchActionTypes.All()},
+					&Branch{Name: "fix", ActionTypes: BranchActionTypes.All()},
+				},
+				state: RepoState{},
+			},
+			want: []ActionType{
+				BranchActionTypes.Delete,
+				BranchActionTypes.ForceDelete,
+			},
+		},
+		{
+			name: "現在のブランチが含まれる場合は削除できないこと",
+			args: args{
+				branches: Branches{
+					&Branch{Name: "main", Current: true, ActionTypes: BranchActionTypes.All()},
+					&Branch{Name: "fix", ActionTypes: BranchActionTypes.All()},
+				},
+				state: RepoState{},
+			},
+			want: nil,
+		},
+		{
+			name: "リモート追跡ブランチが含まれる場合は削除できないこと",
+			args: args{
+				branches: Branches{
+					&Branch{Name: "origin/feature", Remote: true, RemoteName: "origin", ActionTypes: BranchActionTypes.All()},
+					&Branch{Name: "fix", ActionTypes: BranchActionTypes.All()},
+				},
+				state: RepoState{},
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.args.branches.GetAvailableActionTypes(tt.args.state); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Branches.GetAvailableActionTypes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseBranches(t *testing.T) {
+	t.Parallel()
+
+	lastCommitDate, err := time.Parse(time.RFC3339, "2024-01-02T03:04:05Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mainBranch := NewBranch(true, "main", "06f96d1", "add feature")
+	mainBranch.FullRef = "refs/heads/main"
+	mainBranch.Upstream = "origin/main"
+	mainBranch.RemoteName = "origin"
+	mainBranch.Ahead = 1
+	mainBranch.Behind = 2
+	mainBranch.LastCommitDate = lastCommitDate
+	mainBranch.LastCommitAuthor = "alice"
+
+	gone := NewBranch(false, "feature/gone", "75d39af", "fix\tbug")
+	gone.FullRef = "refs/heads/feature/gone"
+	gone.Upstream = "origin/feature/gone"
+	gone.RemoteName = "origin"
+	gone.UpstreamGone = true
+	gone.LastCommitDate = lastCommitDate
+	gone.LastCommitAuthor = "bob"
+
+	remote := NewBranch(false, "origin/main", "4a77a8e", "initial commit")
+	remote.FullRef = "refs/remotes/origin/main"
+	remote.Remote = true
+	remote.RemoteName = "origin"
+	remote.LastCommitDate = lastCommitDate
+	remote.LastCommitAuthor = "alice"
+
+	tests := []struct {
+		name    string
+		refs    string
+		want    []*Branch
+		wantErr bool
+	}{
+		{
+			name: "git for-each-ref --format=BranchFormatの出力をパースできること",
+			refs: "*\trefs/heads/main\tmain\t06f96d1\torigin/main\tahead 1, behind 2\torigin\t\t2024-01-02T03:04:05Z\talice\tadd feature\n" +
+				" \trefs/heads/feature/gone\tfeature/gone\t75d39af\torigin/feature/gone\tgone\torigin\t\t2024-01-02T03:04:05Z\tbob\tfix\tbug\n" +
+				" \trefs/remotes/origin/HEAD\torigin\t4a77a8e\t\t\t\trefs/remotes/origin/main\t2024-01-02T03:04:05Z\talice\tinitial commit\n" +
+				" \trefs/remotes/origin/main\torigin/main\t4a77a8e\t\t\t\t\t2024-01-02T03:04:05Z\talice\tinitial commit\n",
+			want: []*Branch{mainBranch, gone, remote},
+		},
+		{
+			name: "先頭のブランチが現在のブランチでない場合もパースできること",
+			refs: " \trefs/heads/feature/gone\tfeature/gone\t75d39af\torigin/feature/gone\tgone\torigin\t\t2024-01-02T03:04:05Z\tbob\tfix\tbug\n" +
+				"*\trefs/heads/main\tmain\t06f96d1\torigin/main\tahead 1, behind 2\torigin\t\t2024-01-02T03:04:05Z\talice\tadd feature\n",
+			want: []*Branch{gone, mainBranch},
+		},
+		{
+			name:    "上流ブランチとの差分の形式が不正な場合はエラーを返すこと",
+			refs:    "*\trefs/heads/main\tmain\t06f96d1\torigin/main\tahead many\torigin\t\t2024-01-02T03:04:05Z\talice\tadd feature\n",
+			wantErr: true,
+		},
+		{
+			name: "ブランチが存在しない場合はnilを返すこと",
+			refs: "",
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseBranches(tt.refs)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseBranches() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseBranches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBranch_GetFzfLine(t *testing.T) {
+	t.Parallel()
+
+	tracking := NewBranch(true, "main", "06f96d1", "add feature")
+	tracking.Upstream = "origin/main"
+	tracking.Ahead = 1
+	tracking.Behind = 2
+	tracking.LastCommitDate = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	tracking.LastCommitAuthor = "alice"
+
+	gone := NewBranch(false, "feature/gone", "75d39af", "fix bug")
+	gone.Upstream = "origin/feature/gone"
+	gone.UpstreamGone = true
+
+	tests := []struct {
+		name   string
+		branch *Branch
+		want   string
+	}{
+		{
+			name:   "ブランチ名、コミットID、上流ブランチとの差分、日付、作者、件名の順に表示すること",
+			branch: tracking,
+			want:   "main 06f96d1 [origin/main: ahead 1, behind 2] 2024-01-02 alice add feature",
+		},
+		{
+			name:   "上流ブランチが削除されている場合はgoneを表示すること",
+			branch: gone,
+			want:   "feature/gone 75d39af [origin/feature/gone: gone] fix bug",
+		},
+		{
+			name:   "上流ブランチがない場合は表示しないこと",
+			branch: NewBranch(false, "feature/a", "aaa", "a"),
+			want:   "feature/a aaa a",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.branch.GetFzfLine(); got != tt.want {
+				t.Errorf("Branch.GetFzfLine() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
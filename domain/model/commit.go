@@ -4,22 +4,47 @@ import (
 	"fmt"
 	"log/slog"
 	"strings"
+	"time"
 )
 
+// git log -z で取得するコミットの出力形式 (フィールドはユニットセパレータ区切り、コミットはNUL区切り)
+// フルハッシュ, 短縮ハッシュ, 親のハッシュ, 作者, 作者の日時, コミッターの日時, 参照, 件名
+const CommitFormat = "%H%x1f%h%x1f%P%x1f%an%x1f%aI%x1f%cI%x1f%D%x1f%s"
+
+const commitFieldSeparator = "\x1f"
+
 // git logで対象となったコミットを表す構造体
 type Commit struct {
-	Id           string
-	Message      string
-	RawCommitLog string
-	ActionTypes  []ActionType
+	// 短縮ハッシュ (コマンドの引数に使う)
+	Id      string
+	FullId  string
+	Parents []string
+	Author  string
+	// 作者の日時
+	AuthorDate time.Time
+	// コミッターの日時
+	CommitterDate time.Time
+	// コミットを指す参照 (例: HEAD -> main, origin/main, tag: v1.0.0)
+	Refs []string
+	// 件名 (コミットメッセージの1行目)
+	Message     string
+	ActionTypes []ActionType
+}
+
+// クエリがコミットIDになりうるか (4文字以上の16進数)
+// コミットIDになりえないクエリの場合は、完全に一致するコミットを探さない
+func IsCommitIdLike(query string) bool {
+	if len(query) < 4 || len(query) > 64 {
+		return false
+	}
+	return strings.Trim(query, "0123456789abcdef") == ""
 }
 
-func NewCommit(id string, message string, rawCommitLog string) *Commit {
+func NewCommit(id string, message string) *Commit {
 	return &Commit{
-		Id:           id,
-		Message:      message,
-		RawCommitLog: rawCommitLog,
-		ActionTypes:  CommitActionTypes.All(),
+		Id:          id,
+		Message:     message,
+		ActionTypes: CommitActionTypes.All(),
 	}
 }
 
@@ -27,6 +52,77 @@ func (c Commit) String() string {
 	return c.Id
 }
 
+// --list で出力する項目を返す
+func (c Commit) GetListItem() ListItem {
+	return ListItem{
+		{Key: "id", Value: c.Id},
+		{Key: "message", Value: c.Message},
+		{Key: "full_id", Value: c.FullId},
+		{Key: "parents", Value: c.Parents},
+		{Key: "author", Value: c.Author},
+		{Key: "author_date", Value: formatCommitDate(c.AuthorDate)},
+		{Key: "committer_date", Value: formatCommitDate(c.CommitterDate)},
+		{Key: "refs", Value: c.Refs},
+	}
+}
+
+// マージコミットか (親が2つ以上あるか)
+func (c Commit) IsMerge() bool {
+	return len(c.Parents) > 1
+}
+
+// root コミット (親がないコミット) か
+// git log から取得していない場合 (FullId がない場合) は親が不明のため root とはみなさない
+func (c Commit) IsRoot() bool {
+	return c.FullId != "" && len(c.Parents) == 0
+}
+
+// リポジトリの状態に応じて実行できるアクションだけを返す
+func (c Commit) GetAvailableActionTypes(state RepoState) []ActionType {
+	var ret []ActionType
+	for _, actionType := range filterAvailableActionTypes(c.ActionTypes, state, c) {
+		// 2つのコミットの差分は、1つのコミットを選択した場合は表示しない
+		if actionType.IsEqual(CommitActionTypes.DiffCommits) {
+			continue
+		}
+		ret = append(ret, actionType)
+	}
+	return ret
+}
+
+// コミットの種類がアクションの条件を満たすか
+func (c Commit) meetsCondition(condition ActionCondition, state RepoState) bool {
+	if condition == ConditionNotMergeCommit {
+		return !c.IsMerge()
+	}
+	return true
+}
+
+// fzfの候補として表示する1行を返す
+// 先頭は短縮ハッシュ (fzfのプレビューと選択結果のパースで使う)
+// 例: "1a2b3c4 2024-01-02 alice (HEAD -> main, origin/main) add feature"
+func (c Commit) GetFzfLine() string {
+	fields := []string{c.Id}
+	if !c.AuthorDate.IsZero() {
+		fields = append(fields, c.AuthorDate.Format("2006-01-02"))
+	}
+	if c.Author != "" {
+		fields = append(fields, c.Author)
+	}
+	if len(c.Refs) > 0 {
+		fields = append(fields, "("+strings.Join(c.Refs, ", ")+")")
+	}
+	fields = append(fields, c.Message)
+	return strings.Join(fields, " ")
+}
+
+func formatCommitDate(date time.Time) string {
+	if date.IsZero() {
+		return ""
+	}
+	return date.Format(time.RFC3339)
+}
+
 func FindCommitById(commits []*Commit, id string) (*Commit, error) {
 	for _, commit := range commits {
 		if commit.Id == id {
@@ -47,36 +143,170 @@ func (c Commit) GetFullCommand(actionType ActionType) string {
 }
 
 func (c Commit) GetOptionsWithCommitId(actionType ActionType) []string {
+	if actionType.Custom {
+		return actionType.GetCustomOptions(c)
+	}
+
 	ret := actionType.Options
-	ret = append(ret, c.Id)
+	// root コミットには親がないため、親から rebase する場合は --root を指定する
+	if actionType.TargetFormat == parentTargetFormat && c.IsRoot() {
+		return append(ret, "--root")
+	}
+	ret = append(ret, actionType.FormatTarget(c.Id))
 	return ret
 }
 
+// 破壊的なアクションの確認で表示する、失われる可能性がある内容の対象を返す
+func (c Commit) GetDestructiveScope(actionType ActionType) DestructiveScope {
+	return defaultDestructiveScope()
+}
+
 func (c Commit) GetFzfInputForSelectActionType(actionType ActionType) string {
 	// fzfに渡す形式: "表示名\tフルコマンド\t説明文"
 	return fmt.Sprintf("%s\tDescription : %s\tCommand     : %s\n", actionType.Name, actionType.Help, c.GetFullCommand(actionType))
 }
 
-// git log --oneline の形式をパースして、Commit構造体のスライスを返す
+// git log -z --format=CommitFormat の形式をパースして、Commit構造体のスライスを返す
 func ParseCommits(log string) ([]*Commit, error) {
 	var commits []*Commit
 
-	lines := strings.Split(strings.TrimSpace(log), "\n")
-	for _, line := range lines {
-		if line == "" {
-			continue
+	for _, record := range strings.Split(log, "\x00") {
+		commit, err := ParseCommit(record)
+		if err != nil {
+			return nil, err
 		}
-		// 最初の空白で分割（commit id と残り）
-		parts := strings.SplitN(line, " ", 2)
-		if len(parts) < 2 {
+		if commit == nil {
 			continue
 		}
-
-		id := parts[0]
-		message := parts[1]
-
-		commit := NewCommit(id, message, line)
 		commits = append(commits, commit)
 	}
 	return commits, nil
 }
+
+// git log -z --format=CommitFormat で出力された1コミット分をパースする
+// 空や想定外の形式の場合は nil を返す
+func ParseCommit(record string) (*Commit, error) {
+	record = strings.Trim(record, "\n")
+	if record == "" {
+		return nil, nil
+	}
+
+	fields := strings.Split(record, commitFieldSeparator)
+	if len(fields) != 8 {
+		// 想定外の形式のコミットはスキップ
+		slog.Debug("skip invalid commit record", "record", record)
+		return nil, nil
+	}
+
+	authorDate, err := time.Parse(time.RFC3339, fields[4])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse author date of %s: %w", fields[1], err)
+	}
+	committerDate, err := time.Parse(time.RFC3339, fields[5])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse committer date of %s: %w", fields[1], err)
+	}
+
+	commit := NewCommit(fields[1], fields[7])
+	commit.FullId = fields[0]
+	// ルートコミットの場合は親がないため nil のままにする
+	if parents := strings.Fields(fields[2]); len(parents) > 0 {
+		commit.Parents = parents
+	}
+	commit.Author = fields[3]
+	commit.AuthorDate = authorDate
+	commit.CommitterDate = committerDate
+	commit.Refs = parseCommitRefs(fields[6])
+	return commit, nil
+}
+
+// %D の出力 (例: "HEAD -> main, origin/main, tag: v1.0.0") を参照ごとに分割する
+func parseCommitRefs(refs string) []string {
+	if strings.TrimSpace(refs) == "" {
+		return nil
+	}
+	var ret []string
+	for _, ref := range strings.Split(refs, ", ") {
+		ret = append(ret, strings.TrimSpace(ref))
+	}
+	return ret
+}
+
+// 複数選択されたコミットをまとめて扱うための型
+// git log の表示順 (新しいものが先頭) で保持する
+type Commits []*Commit
+
+// fzfで選択されたコミットIDを git log の表示順に並べ替えて返す
+func FindCommitsByIds(commits []*Commit, ids []string) (Commits, error) {
+	selected := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if _, err := FindCommitById(commits, id); err != nil {
+			return nil, err
+		}
+		selected[id] = true
+	}
+
+	var ret Commits
+	for _, commit := range commits {
+		if selected[commit.Id] {
+			ret = append(ret, commit)
+		}
+	}
+	return ret, nil
+}
+
+func (cs Commits) GetFullCommand(actionType ActionType) string {
+	options := cs.GetOptionsWithCommitIds(actionType)
+	onelineOptions := strings.Join(options, " ")
+
+	fullCommand := fmt.Sprintf("%s %s", actionType.Command, onelineOptions)
+	slog.Debug("Command:", "Command", actionType.Name, "fullCommand", fullCommand)
+
+	return fullCommand
+}
+
+func (cs Commits) GetOptionsWithCommitIds(actionType ActionType) []string {
+	ret := actionType.Options
+
+	// cherry-pick は親から順に適用する必要があるため古いコミットから並べる
+	// diff は古いコミットから新しいコミットへの変更を表示するため古いコミットから並べる
+	// revert は新しいコミットから打ち消すため表示順のまま
+	oldestFirst := actionType.IsEqual(CommitActionTypes.CherryPick) || actionType.IsEqual(CommitActionTypes.CherryPickWithoutCommit) ||
+		actionType.IsEqual(CommitActionTypes.DiffCommits)
+	for i := range cs {
+		c := cs[i]
+		if oldestFirst {
+			c = cs[len(cs)-1-i]
+		}
+		ret = append(ret, actionType.FormatTarget(c.Id))
+	}
+	return ret
+}
+
+// 選択された全てのコミットに対して実行できるアクションを返す
+func (cs Commits) GetAvailableActionTypes(state RepoState) []ActionType {
+	targets := make([]actionTarget, 0, len(cs))
+	for _, c := range cs {
+		targets = append(targets, c)
+	}
+
+	var ret []ActionType
+	for _, actionType := range filterAvailableActionTypes(FilterMultipleActionTypes(CommitActionTypes.All()), state, targets...) {
+		// 2つのコミットの差分は、ちょうど2つ選択された場合だけ表示できる
+		if actionType.IsEqual(CommitActionTypes.DiffCommits) && len(cs) != 2 {
+			continue
+		}
+		ret = append(ret, actionType)
+	}
+	return ret
+}
+
+// 破壊的なアクションの確認で表示する、失われる可能性がある内容の対象を返す
+func (cs Commits) GetDestructiveScope(actionType ActionType) DestructiveScope {
+	return defaultDestructiveScope()
+}
+
+func (cs Commits) GetFzfInputForSelectActionType(actionType ActionType) string {
+	// fzfに渡す形式: "表示名\tフルコマンド\t説明文"
+	return fmt.Sprintf("%s\tDescription : %s\tCommand     : %s\n", actionType.Name, actionType.Help, cs.GetFullCommand(actionType))
+}
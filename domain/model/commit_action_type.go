@@ -8,64 +8,153 @@ import (
 
 type CommitActionTypeMap struct {
 	GetCommitId             ActionType
+	Show                    ActionType
 	Diff                    ActionType
+	DiffHead                ActionType
+	DiffCommits             ActionType
 	RebaseInteractive       ActionType
+	RebaseFrom              ActionType
 	Revert                  ActionType
 	RevertWithoutCommit     ActionType
 	CherryPick              ActionType
 	CherryPickWithoutCommit ActionType
 	Checkout                ActionType
+	Fixup                   ActionType
+	FixupAmend              ActionType
+	Squash                  ActionType
+	Autosquash              ActionType
 	Unknown                 ActionType
+	// 設定ファイルで定義されたアクション
+	Custom []ActionType
 }
 
+// 選択したコミットの親を対象にする形式 (root コミットの場合は --root に置き換える)
+const parentTargetFormat = "%s^"
+
 var CommitActionTypes = CommitActionTypeMap{
 	GetCommitId: ActionType{
-		Name:    "get commit id",
-		Command: "echo",
-		Options: nil,
-		Help:    "print commit id",
+		Name:     "get commit id",
+		Command:  "echo",
+		Options:  nil,
+		Help:     "print commit id",
+		Multiple: true,
+	},
+	Show: ActionType{
+		Name:    "show",
+		Command: "git",
+		Options: []string{"show"},
+		Help:    "Show the changes of the commit",
 	},
 	Diff: ActionType{
 		Name:    "diff",
 		Command: "git",
 		Options: []string{"diff"},
-		Help:    "Show changes between commits",
+		Help:    "Show changes between the commit and the working tree",
+	},
+	DiffHead: ActionType{
+		Name:         "diff head",
+		Command:      "git",
+		Options:      []string{"diff"},
+		Help:         "Show changes between the commit and HEAD",
+		TargetFormat: "%s..HEAD",
+	},
+	DiffCommits: ActionType{
+		Name:     "diff commits",
+		Command:  "git",
+		Options:  []string{"diff"},
+		Help:     "Show changes between the two selected commits",
+		Multiple: true,
 	},
 	RebaseInteractive: ActionType{
-		Name:    "rebase interactive",
-		Command: "git",
-		Options: []string{"rebase", "-i"},
-		Help:    "Interactive rebase",
+		Name:       "rebase interactive",
+		Command:    "git",
+		Options:    []string{"rebase", "-i"},
+		Help:       "Interactive rebase of the commits after the commit",
+		Snapshot:   true,
+		Conditions: []ActionCondition{ConditionCleanWorkingTree, ConditionNoOperationInProgress},
+	},
+	RebaseFrom: ActionType{
+		Name:         "rebase from commit",
+		Command:      "git",
+		Options:      []string{"rebase", "-i"},
+		Help:         "Interactive rebase starting at the commit",
+		Snapshot:     true,
+		TargetFormat: parentTargetFormat,
+		Conditions:   []ActionCondition{ConditionCleanWorkingTree, ConditionNoOperationInProgress},
 	},
 	Revert: ActionType{
-		Name:    "revert",
-		Command: "git",
-		Options: []string{"revert", "--edit"},
-		Help:    "Revert commit",
+		Name:       "revert",
+		Command:    "git",
+		Options:    []string{"revert", "--edit"},
+		Help:       "Revert commit",
+		Multiple:   true,
+		Snapshot:   true,
+		Conditions: []ActionCondition{ConditionNotMergeCommit, ConditionNoOperationInProgress},
 	},
 	RevertWithoutCommit: ActionType{
-		Name:    "revert no commit",
-		Command: "git",
-		Options: []string{"revert", "--no-commit"},
-		Help:    "Revert without committing",
+		Name:       "revert no commit",
+		Command:    "git",
+		Options:    []string{"revert", "--no-commit"},
+		Help:       "Revert without committing",
+		Multiple:   true,
+		Snapshot:   true,
+		Conditions: []ActionCondition{ConditionNotMergeCommit, ConditionNoOperationInProgress},
 	},
 	CherryPick: ActionType{
-		Name:    "cherry-pick",
-		Command: "git",
-		Options: []string{"cherry-pick"},
-		Help:    "Cherry-pick commit",
+		Name:       "cherry-pick",
+		Command:    "git",
+		Options:    []string{"cherry-pick"},
+		Help:       "Cherry-pick commit",
+		Multiple:   true,
+		Conditions: []ActionCondition{ConditionNotMergeCommit, ConditionNoOperationInProgress},
 	},
 	CherryPickWithoutCommit: ActionType{
-		Name:    "cherry-pick without commit",
-		Command: "git",
-		Options: []string{"cherry-pick", "--no-commit"},
-		Help:    "Cherry-pick without committing",
+		Name:       "cherry-pick without commit",
+		Command:    "git",
+		Options:    []string{"cherry-pick", "--no-commit"},
+		Help:       "Cherry-pick without committing",
+		Multiple:   true,
+		Conditions: []ActionCondition{ConditionNotMergeCommit, ConditionNoOperationInProgress},
 	},
 	Checkout: ActionType{
-		Name:    "checkout",
-		Command: "git",
-		Options: []string{"checkout"},
-		Help:    "Checkout the commit",
+		Name:       "checkout",
+		Command:    "git",
+		Options:    []string{"checkout"},
+		Help:       "Checkout the commit",
+		Conditions: []ActionCondition{ConditionNoOperationInProgress},
+	},
+	Fixup: ActionType{
+		Name:         "fixup",
+		Command:      "git",
+		Options:      []string{"commit"},
+		Help:         "Commit the staged changes as a fixup of the commit",
+		TargetFormat: "--fixup=%s",
+		Conditions:   []ActionCondition{ConditionStagedChanges},
+	},
+	FixupAmend: ActionType{
+		Name:         "fixup amend",
+		Command:      "git",
+		Options:      []string{"commit"},
+		Help:         "Commit the staged changes as a fixup that also rewords the commit",
+		TargetFormat: "--fixup=amend:%s",
+		Conditions:   []ActionCondition{ConditionStagedChanges},
+	},
+	Squash: ActionType{
+		Name:         "squash",
+		Command:      "git",
+		Options:      []string{"commit"},
+		Help:         "Commit the staged changes as a squash of the commit",
+		TargetFormat: "--squash=%s",
+		Conditions:   []ActionCondition{ConditionStagedChanges},
+	},
+	Autosquash: ActionType{
+		Name:         "autosquash",
+		Command:      "git",
+		Options:      []string{"rebase", "-i", "--autosquash"},
+		Help:         "Squash the fixup commits onto the parent of the commit",
+		Snapshot:     true,
+		TargetFormat: parentTargetFormat,
+		Conditions:   []ActionCondition{ConditionCleanWorkingTree, ConditionNoOperationInProgress},
 	},
 	Unknown: ActionType{
 		Name:    "unknown",
@@ -76,26 +165,48 @@ var CommitActionTypes = CommitActionTypeMap{
 }
 
 func (c CommitActionTypeMap) All() []ActionType {
-	return []ActionType{
+	builtins := []ActionType{
 		c.GetCommitId,
+		c.Show,
 		c.Diff,
+		c.DiffHead,
+		c.DiffCommits,
 		c.RebaseInteractive,
+		c.RebaseFrom,
 		c.Revert,
 		c.RevertWithoutCommit,
 		c.CherryPick,
 		c.CherryPickWithoutCommit,
 		c.Checkout,
+		c.Fixup,
+		c.FixupAmend,
+		c.Squash,
+		c.Autosquash,
 	}
+	return mergeCustomActionTypes(builtins, c.Custom)
 }
 
 func (c CommitActionTypeMap) GetCommitActionTypes(action string) (ActionType, error) {
+	// 組み込みのアクションと同じ名前の独自のアクションは、組み込みのアクションを置き換える
+	if custom, ok := findCustomActionType(c.Custom, action); ok {
+		return custom, nil
+	}
+
 	switch action {
 	case "get commit id":
 		return c.GetCommitId, nil
+	case "show":
+		return c.Show, nil
 	case "diff":
 		return c.Diff, nil
+	case "diff head":
+		return c.DiffHead, nil
+	case "diff commits":
+		return c.DiffCommits, nil
 	case "rebase interactive":
 		return c.RebaseInteractive, nil
+	case "rebase from commit":
+		return c.RebaseFrom, nil
 	case "revert":
 		return c.Revert, nil
 	case "revert no commit":
@@ -106,6 +217,14 @@ func (c CommitActionTypeMap) GetCommitActionTypes(action string) (ActionType, er
 		return c.CherryPick, nil
 	case "cherry-pick without commit":
 		return c.CherryPickWithoutCommit, nil
+	case "fixup":
+		return c.Fixup, nil
+	case "fixup amend":
+		return c.FixupAmend, nil
+	case "squash":
+		return c.Squash, nil
+	case "autosquash":
+		return c.Autosquash, nil
 	default:
 		return c.Unknown, fmt.Errorf("unknown action: %s", action)
 	}
@@ -81,6 +81,78 @@ func TestCommitActionTypeMap_GetCommitActionTypes(t *testing.T) {
 			wantErr:        false,
 			wantErrMessage: nil,
 		},
+		{
+			name: "対応するコミットアクション(show)を取得すること",
+			args: args{
+				action: "show",
+			},
+			want:           CommitActionTypes.Show,
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "対応するコミットアクション(diff head)を取得すること",
+			args: args{
+				action: "diff head",
+			},
+			want:           CommitActionTypes.DiffHead,
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "対応するコミットアクション(diff commits)を取得すること",
+			args: args{
+				action: "diff commits",
+			},
+			want:           CommitActionTypes.DiffCommits,
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "対応するコミットアクション(rebase from commit)を取得すること",
+			args: args{
+				action: "rebase from commit",
+			},
+			want:           CommitActionTypes.RebaseFrom,
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "対応するコミットアクション(fixup)を取得すること",
+			args: args{
+				action: "fixup",
+			},
+			want:           CommitActionTypes.Fixup,
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "対応するコミットアクション(fixup amend)を取得すること",
+			args: args{
+				action: "fixup amend",
+			},
+			want:           CommitActionTypes.FixupAmend,
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "対応するコミットアクション(squash)を取得すること",
+			args: args{
+				action: "squash",
+			},
+			want:           CommitActionTypes.Squash,
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "対応するコミットアクション(autosquash)を取得すること",
+			args: args{
+				action: "autosquash",
+			},
+			want:           CommitActionTypes.Autosquash,
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
 		{
 			name: "不明な文字列が来た場合にはUNKNOWNとエラーを返すこと",
 			args: args{
@@ -119,13 +191,21 @@ func TestCommitActionTypeMap_All(t *testing.T) {
 			name: "全てのコミットアクションを取得すること",
 			want: []ActionType{
 				CommitActionTypes.GetCommitId,
+				CommitActionTypes.Show,
 				CommitActionTypes.Diff,
+				CommitActionTypes.DiffHead,
+				CommitActionTypes.DiffCommits,
 				CommitActionTypes.RebaseInteractive,
+				CommitActionTypes.RebaseFrom,
 				CommitActionTypes.Revert,
 				CommitActionTypes.RevertWithoutCommit,
 				CommitActionTypes.CherryPick,
 				CommitActionTypes.CherryPickWithoutCommit,
 				CommitActionTypes.Checkout,
+				CommitActionTypes.Fixup,
+				CommitActionTypes.FixupAmend,
+				CommitActionTypes.Squash,
+				CommitActionTypes.Autosquash,
 			},
 		},
 	}
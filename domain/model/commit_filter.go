@@ -0,0 +1,50 @@
+package model
+
+// git log で表示するコミットの絞り込み条件
+type CommitFilter struct {
+	Author string
+	// 日付は git log がそのまま解釈する (例: "2 weeks ago", "2024-01-01")
+	Since string
+	Until string
+	Grep  string
+	// すべての参照のコミットを表示するか
+	All bool
+	// マージコミットの1つ目の親だけをたどるか
+	FirstParent bool
+	// リビジョンの範囲 (例: main..HEAD)
+	Revision string
+	// 変更されたファイルのパス
+	Paths []string
+}
+
+// git log に渡すオプションを返す
+func (cf CommitFilter) GetLogOptions() []string {
+	var ret []string
+	if cf.Author != "" {
+		ret = append(ret, "--author="+cf.Author)
+	}
+	if cf.Since != "" {
+		ret = append(ret, "--since="+cf.Since)
+	}
+	if cf.Until != "" {
+		ret = append(ret, "--until="+cf.Until)
+	}
+	if cf.Grep != "" {
+		ret = append(ret, "--grep="+cf.Grep)
+	}
+	if cf.All {
+		ret = append(ret, "--all")
+	}
+	if cf.FirstParent {
+		ret = append(ret, "--first-parent")
+	}
+	if cf.Revision != "" {
+		ret = append(ret, cf.Revision)
+	}
+	// パスはリビジョンと区別するため "--" の後に渡す
+	if len(cf.Paths) > 0 {
+		ret = append(ret, "--")
+		ret = append(ret, cf.Paths...)
+	}
+	return ret
+}
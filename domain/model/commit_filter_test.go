@@ -0,0 +1,44 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCommitFilter_GetLogOptions(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		filter CommitFilter
+		want   []string
+	}{
+		{
+			name: "すべての条件をgit logのオプションに変換できること",
+			filter: CommitFilter{
+				Author:      "alice",
+				Since:       "2 weeks ago",
+				Until:       "2024-01-01",
+				Grep:        "fix",
+				All:         true,
+				FirstParent: true,
+				Revision:    "main..HEAD",
+				Paths:       []string{"pkg/auth", "README.md"},
+			},
+			want: []string{"--author=alice", "--since=2 weeks ago", "--until=2024-01-01", "--grep=fix", "--all", "--first-parent", "main..HEAD", "--", "pkg/auth", "README.md"},
+		},
+		{
+			name:   "条件がない場合はnilを返すこと",
+			filter: CommitFilter{},
+			want:   nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.filter.GetLogOptions(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CommitFilter.GetLogOptions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,9 +1,11 @@
 package model
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestFindCommitById(t *testing.T) {
@@ -23,13 +25,13 @@ func TestFindCommitById(t *testing.T) {
 			name: "コミットIDを指定してコミットを取得できること",
 			args: args{
 				commits: []*Commit{
-					NewCommit("commit1", "commit1", "commit1"),
-					NewCommit("commit2", "commit2", "commit2"),
-					NewCommit("commit3", "commit3", "commit3"),
+					NewCommit("commit1", "commit1"),
+					NewCommit("commit2", "commit2"),
+					NewCommit("commit3", "commit3"),
 				},
 				id: "commit2",
 			},
-			want:           NewCommit("commit2", "commit2", "commit2"),
+			want:           NewCommit("commit2", "commit2"),
 			wantErr:        false,
 			wantErrMessage: nil,
 		},
@@ -37,9 +39,9 @@ func TestFindCommitById(t *testing.T) {
 			name: "存在しないコミットIDを指定した場合にエラーが返ること(検索対象に含まれない)",
 			args: args{
 				commits: []*Commit{
-					NewCommit("commit1", "commit1", "commit1"),
-					NewCommit("commit2", "commit2", "commit2"),
-					NewCommit("commit3", "commit3", "commit3"),
+					NewCommit("commit1", "commit1"),
+					NewCommit("commit2", "commit2"),
+					NewCommit("commit3", "commit3"),
 				},
 				id: "dummy",
 			},
@@ -77,10 +79,9 @@ func TestFindCommitById(t *testing.T) {
 
 func TestCommit_GetFzfInputForSelectActionType(t *testing.T) {
 	type fields struct {
-		Id           string
-		Message      string
-		RawCommitLog string
-		ActionTypes  []ActionType
+		Id          string
+		Message     string
+		ActionTypes []ActionType
 	}
 	type args struct {
 		actionType ActionType
@@ -94,25 +95,47 @@ func TestCommit_GetFzfInputForSelectActionType(t *testing.T) {
 		{
 			name: "アクションを選択するためにfzfに渡す文字列を生成できること",
 			fields: fields{
-				Id:           "dummy",
-				Message:      "commit message",       // 使わない
-				RawCommitLog: "dummy commit message", // 使わない
-				ActionTypes:  []ActionType{CommitActionTypes.Diff},
+				Id:          "dummy",
+				Message:     "commit message", // 使わない
+				ActionTypes: []ActionType{CommitActionTypes.Diff},
 			},
 			args: args{
 				actionType: CommitActionTypes.Diff,
 			},
-			want: "diff\tDescription : Show changes between commits\tCommand     : git diff dummy\n",
+			want: "diff\tDescription : Show changes between the commit and the working tree\tCommand     : git diff dummy\n",
+		},
+		{
+			name: "fixupコミットを作成する場合はコミットIDを--fixup=amend:の形式で指定すること",
+			fields: fields{
+				Id:          "dummy",
+				Message:     "commit message", // 使わない
+				ActionTypes: []ActionType{CommitActionTypes.FixupAmend},
+			},
+			args: args{
+				actionType: CommitActionTypes.FixupAmend,
+			},
+			want: "fixup amend\tDescription : Commit the staged changes as a fixup that also rewords the commit\tCommand     : git commit --fixup=amend:dummy\n",
+		},
+		{
+			name: "autosquashする場合は選択したコミットの親からrebaseすること",
+			fields: fields{
+				Id:          "dummy",
+				Message:     "commit message", // 使わない
+				ActionTypes: []ActionType{CommitActionTypes.Autosquash},
+			},
+			args: args{
+				actionType: CommitActionTypes.Autosquash,
+			},
+			want: "autosquash\tDescription : Squash the fixup commits onto the parent of the commit\tCommand     : git rebase -i --autosquash dummy^\n",
 		},
 	}
 	for _, tt := range tests {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			c := Commit{
-				Id:           tt.fields.Id,
-				Message:      tt.fields.Message,
-				RawCommitLog: tt.fields.RawCommitLog,
-				ActionTypes:  tt.fields.ActionTypes,
+				Id:          tt.fields.Id,
+				Message:     tt.fields.Message,
+				ActionTypes: tt.fields.ActionTypes,
 			}
 			if got := c.GetFzfInputForSelectActionType(tt.args.actionType); got != tt.want {
 				t.Errorf("Commit.GetFzfInputForSelectActionType() = %v, want %v", got, tt.want)
@@ -120,3 +143,544 @@ func TestCommit_GetFzfInputForSelectActionType(t *testing.T) {
 		})
 	}
 }
+
+func TestFindCommitsByIds(t *testing.T) {
+	t.Parallel()
+	// git log の表示順 (新しいものが先頭)
+	commits := []*Commit{
+		NewCommit("ccc", "third"),
+		NewCommit("bbb", "second"),
+		NewCommit("aaa", "first"),
+	}
+	tests := []struct {
+		name    string
+		ids     []string
+		want    Commits
+		wantErr bool
+	}{
+		{
+			name:    "選択順に関わらずgit logの表示順で返すこと",
+			ids:     []string{"aaa", "ccc"},
+			want:    Commits{commits[0], commits[2]},
+			wantErr: false,
+		},
+		{
+			name:    "存在しないコミットIDが含まれる場合はエラーを返すこと",
+			ids:     []string{"aaa", "dummy"},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := FindCommitsByIds(commits, tt.ids)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FindCommitsByIds() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindCommitsByIds() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommits_GetFullCommand(t *testing.T) {
+	t.Parallel()
+	commits := Commits{
+		NewCommit("ccc", "third"),
+		NewCommit("bbb", "second"),
+		NewCommit("aaa", "first"),
+	}
+	tests := []struct {
+		name       string
+		actionType ActionType
+		want       string
+	}{
+		{
+			name:       "cherry-pickは古いコミットから順に指定すること",
+			actionType: CommitActionTypes.CherryPick,
+			want:       "git cherry-pick aaa bbb ccc",
+		},
+		{
+			name:       "cherry-pick without commitも古いコミットから順に指定すること",
+			actionType: CommitActionTypes.CherryPickWithoutCommit,
+			want:       "git cherry-pick --no-commit aaa bbb ccc",
+		},
+		{
+			name:       "revertは新しいコミットから順に指定すること",
+			actionType: CommitActionTypes.Revert,
+			want:       "git revert --edit ccc bbb aaa",
+		},
+		{
+			name:       "diff commitsは古いコミットから新しいコミットへの差分を表示すること",
+			actionType: CommitActionTypes.DiffCommits,
+			want:       "git diff aaa bbb ccc",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := commits.GetFullCommand(tt.actionType); got != tt.want {
+				t.Errorf("Commits.GetFullCommand() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommit_GetOptionsWithCommitId(t *testing.T) {
+	t.Parallel()
+
+	commit := NewCommit("bbb", "second")
+	commit.FullId = "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+	commit.Parents = []string{"aaa"}
+
+	root := NewCommit("aaa", "first")
+	root.FullId = "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+
+	tests := []struct {
+		name       string
+		commit     *Commit
+		actionType ActionType
+		want       []string
+	}{
+		{
+			name:       "rebase from commitは選択したコミットの親からrebaseすること",
+			commit:     commit,
+			actionType: CommitActionTypes.RebaseFrom,
+			want:       []string{"rebase", "-i", "bbb^"},
+		},
+		{
+			name:       "rootコミットからrebaseする場合は--rootを指定すること",
+			commit:     root,
+			actionType: CommitActionTypes.RebaseFrom,
+			want:       []string{"rebase", "-i", "--root"},
+		},
+		{
+			name:       "rootコミットにautosquashする場合も--rootを指定すること",
+			commit:     root,
+			actionType: CommitActionTypes.Autosquash,
+			want:       []string{"rebase", "-i", "--autosquash", "--root"},
+		},
+		{
+			name:       "diff headは選択したコミットとHEADの差分を表示すること",
+			commit:     root,
+			actionType: CommitActionTypes.DiffHead,
+			want:       []string{"diff", "aaa..HEAD"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.commit.GetOptionsWithCommitId(tt.actionType); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Commit.GetOptionsWithCommitId() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommit_GetAvailableActionTypes(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		commit Commit
+		state  RepoState
+	}
+	tests := []struct {
+		name string
+		args args
+		want []ActionType
+	}{
+		{
+			name: "1つのコミットではdiff commitsとステージされた変更が必要なアクション以外を選択できること",
+			args: args{
+				commit: Commit{Id: "1a2b3c4", FullId: "1a2b3c4d5e6f", Parents: []string{"0f1e2d3c"}, Message: "add feature", ActionTypes: CommitActionTypes.All()},
+				state:  RepoState{},
+			},
+			want: []ActionType{
+				CommitActionTypes.GetCommitId,
+				CommitActionTypes.Show,
+				CommitActionTypes.Diff,
+				CommitActionTypes.DiffHead,
+				CommitActionTypes.RebaseInteractive,
+				CommitActionTypes.RebaseFrom,
+				CommitActionTypes.Revert,
+				CommitActionTypes.RevertWithoutCommit,
+				CommitActionTypes.CherryPick,
+				CommitActionTypes.CherryPickWithoutCommit,
+				CommitActionTypes.Checkout,
+				CommitActionTypes.Autosquash,
+			},
+		},
+		{
+			name: "マージコミットはrevertやcherry-pickができないこと",
+			args: args{
+				commit: Commit{Id: "5e6f7a8", FullId: "5e6f7a8b9c0d", Parents: []string{"0f1e2d3c", "1a2b3c4d"}, Message: "Merge branch 'feature'", ActionTypes: CommitActionTypes.All()},
+				state:  RepoState{},
+			},
+			want: []ActionType{
+				CommitActionTypes.GetCommitId,
+				CommitActionTypes.Show,
+				CommitActionTypes.Diff,
+				CommitActionTypes.DiffHead,
+				CommitActionTypes.RebaseInteractive,
+				CommitActionTypes.RebaseFrom,
+				CommitActionTypes.Checkout,
+				CommitActionTypes.Autosquash,
+			},
+		},
+		{
+			name: "ステージされた変更がある場合はfixupやsquashができるが、rebaseはできないこと",
+			args: args{
+				commit: Commit{Id: "1a2b3c4", FullId: "1a2b3c4d5e6f", Parents: []string{"0f1e2d3c"}, Message: "add feature", ActionTypes: CommitActionTypes.All()},
+				state:  RepoState{Dirty: true, StagedChanges: true},
+			},
+			want: []ActionType{
+				CommitActionTypes.GetCommitId,
+				CommitActionTypes.Show,
+				CommitActionTypes.Diff,
+				CommitActionTypes.DiffHead,
+				CommitActionTypes.Revert,
+				CommitActionTypes.RevertWithoutCommit,
+				CommitActionTypes.CherryPick,
+				CommitActionTypes.CherryPickWithoutCommit,
+				CommitActionTypes.Checkout,
+				CommitActionTypes.Fixup,
+				CommitActionTypes.FixupAmend,
+				CommitActionTypes.Squash,
+			},
+		},
+		{
+			name: "rebaseの途中はrebaseやcherry-pick、チェックアウトができないこと",
+			args: args{
+				commit: Commit{Id: "1a2b3c4", FullId: "1a2b3c4d5e6f", Parents: []string{"0f1e2d3c"}, Message: "add feature", ActionTypes: CommitActionTypes.All()},
+				state:  RepoState{OperationInProgress: "rebase"},
+			},
+			want: []ActionType{
+				CommitActionTypes.GetCommitId,
+				CommitActionTypes.Show,
+				CommitActionTypes.Diff,
+				CommitActionTypes.DiffHead,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.args.commit.GetAvailableActionTypes(tt.args.state); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Commit.GetAvailableActionTypes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommits_GetAvailableActionTypes(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		commits Commits
+		state   RepoState
+	}
+	tests := []struct {
+		name string
+		args args
+		want []ActionType
+	}{
+		{
+			name: "2つのコミットを選択した場合はdiff commitsを選択できること",
+			args: args{
+				commits: Commits{
+					&Commit{Id: "bbb", Message: "second", ActionTypes: CommitActionTypes.All()},
+					&Commit{Id: "aaa", Message: "first", ActionTypes: CommitActionTypes.All()},
+				},
+				state: RepoState{},
+			},
+			want: []ActionType{
+				CommitActionTypes.GetCommitId,
+				CommitActionTypes.DiffCommits,
+				CommitActionTypes.Revert,
+				CommitActionTypes.RevertWithoutCommit,
+				CommitActionTypes.CherryPick,
+				CommitActionTypes.CherryPickWithoutCommit,
+			},
+		},
+		{
+			name: "3つ以上のコミットを選択した場合はdiff commitsを選択できないこと",
+			args: args{
+				commits: Commits{
+					&Commit{Id: "ccc", Message: "third", ActionTypes: CommitActionTypes.All()},
+					&Commit{Id: "bbb", Message: "second", ActionTypes: CommitActionTypes.All()},
+					&Commit{Id: "aaa", Message: "first", ActionTypes: CommitActionTypes.All()},
+				},
+				state: RepoState{},
+			},
+			want: []ActionType{
+				CommitActionTypes.GetCommitId,
+				CommitActionTypes.Revert,
+				CommitActionTypes.RevertWithoutCommit,
+				CommitActionTypes.CherryPick,
+				CommitActionTypes.CherryPickWithoutCommit,
+			},
+		},
+		{
+			name: "マージコミットが含まれる場合はrevertやcherry-pickができないこと",
+			args: args{
+				commits: Commits{
+					&Commit{Id: "ccc", Parents: []string{"bbb", "aaa"}, Message: "Merge branch 'feature'", ActionTypes: CommitActionTypes.All()},
+					&Commit{Id: "aaa", Message: "first", ActionTypes: CommitActionTypes.All()},
+				},
+				state: RepoState{},
+			},
+			want: []ActionType{
+				CommitActionTypes.GetCommitId,
+				CommitActionTypes.DiffCommits,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.args.commits.GetAvailableActionTypes(tt.args.state); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Commits.GetAvailableActionTypes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCommits(t *testing.T) {
+	t.Parallel()
+
+	merge := NewCommit("4a77a8e", "Merge branch 'feature' (fix: a, b)")
+	merge.FullId = "4a77a8e180d845e6ce85aab611ae97d01874d425"
+	merge.Parents = []string{"75d39af70702", "5e2aa26fa1c2"}
+	merge.Author = "alice"
+	merge.AuthorDate = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	merge.CommitterDate = time.Date(2024, 1, 2, 4, 0, 0, 0, time.UTC)
+	merge.Refs = []string{"HEAD -> main", "origin/main", "tag: v1.0.0"}
+
+	root := NewCommit("75d39af", "initial commit")
+	root.FullId = "75d39af7070275ddad73962f15fe410541eb4ae7"
+	root.Author = "bob"
+	root.AuthorDate = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	root.CommitterDate = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		log     string
+		want    []*Commit
+		wantErr bool
+	}{
+		{
+			name: "git log -z --format=CommitFormatの出力をパースできること",
+			log: "4a77a8e180d845e6ce85aab611ae97d01874d425\x1f4a77a8e\x1f75d39af70702 5e2aa26fa1c2\x1falice\x1f2024-01-02T03:04:05Z\x1f2024-01-02T04:00:00Z\x1fHEAD -> main, origin/main, tag: v1.0.0\x1fMerge branch 'feature' (fix: a, b)\x00" +
+				"75d39af7070275ddad73962f15fe410541eb4ae7\x1f75d39af\x1f\x1fbob\x1f2024-01-01T00:00:00Z\x1f2024-01-01T00:00:00Z\x1f\x1finitial commit\x00",
+			want: []*Commit{merge, root},
+		},
+		{
+			name: "フィールドの数が合わないコミットはスキップすること",
+			log:  "invalid\x00",
+			want: nil,
+		},
+		{
+			name:    "日時の形式が不正な場合はエラーを返すこと",
+			log:     "75d39af7070275ddad73962f15fe410541eb4ae7\x1f75d39af\x1f\x1fbob\x1fyesterday\x1f2024-01-01T00:00:00Z\x1f\x1finitial commit\x00",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseCommits(tt.log)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseCommits() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseCommits() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCommit(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		record string
+	}
+	tests := []struct {
+		name           string
+		args           args
+		want           *Commit
+		wantErr        bool
+		wantErrMessage error
+	}{
+		{
+			name: "マージコミットの親と参照をパースできること",
+			args: args{
+				record: "4a77a8e180d845e6ce85aab611ae97d01874d425\x1f4a77a8e\x1f75d39af70702 5e2aa26fa1c2\x1falice\x1f2024-01-02T03:04:05Z\x1f2024-01-02T04:00:00Z\x1fHEAD -> main, origin/main, tag: v1.0.0\x1fMerge branch 'feature'",
+			},
+			want: &Commit{
+				Id:            "4a77a8e",
+				FullId:        "4a77a8e180d845e6ce85aab611ae97d01874d425",
+				Parents:       []string{"75d39af70702", "5e2aa26fa1c2"},
+				Author:        "alice",
+				AuthorDate:    time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+				CommitterDate: time.Date(2024, 1, 2, 4, 0, 0, 0, time.UTC),
+				Refs:          []string{"HEAD -> main", "origin/main", "tag: v1.0.0"},
+				Message:       "Merge branch 'feature'",
+				ActionTypes:   CommitActionTypes.All(),
+			},
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "前のコミットの後の改行を取り除いてルートコミットをパースできること",
+			args: args{
+				record: "\n75d39af7070275ddad73962f15fe410541eb4ae7\x1f75d39af\x1f\x1fbob\x1f2024-01-01T00:00:00Z\x1f2024-01-01T00:00:00Z\x1f\x1finitial commit",
+			},
+			want: &Commit{
+				Id:            "75d39af",
+				FullId:        "75d39af7070275ddad73962f15fe410541eb4ae7",
+				Author:        "bob",
+				AuthorDate:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				CommitterDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				Message:       "initial commit",
+				ActionTypes:   CommitActionTypes.All(),
+			},
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "空の場合はnilを返すこと",
+			args: args{
+				record: "\n",
+			},
+			want:           nil,
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "フィールドの数が合わない場合はnilを返すこと",
+			args: args{
+				record: "75d39af\x1finitial commit",
+			},
+			want:           nil,
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "コミッターの日時の形式が不正な場合はエラーを返すこと",
+			args: args{
+				record: "75d39af7070275ddad73962f15fe410541eb4ae7\x1f75d39af\x1f\x1fbob\x1f2024-01-01T00:00:00Z\x1fyesterday\x1f\x1finitial commit",
+			},
+			want:           nil,
+			wantErr:        true,
+			wantErrMessage: errors.New(`failed to parse committer date of 75d39af: parsing time "yesterday" as "2006-01-02T15:04:05Z07:00": cannot parse "yesterday" as "2006"`),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseCommit(tt.args.record)
+			if (err != nil) != tt.wantErr || err != nil && err.Error() != tt.wantErrMessage.Error() {
+				t.Errorf("ParseCommit() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("ParseCommit() error = %v, wantErrMessage %v", err, tt.wantErrMessage)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseCommit() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommit_GetFzfLine(t *testing.T) {
+	t.Parallel()
+
+	decorated := NewCommit("4a77a8e", "add feature")
+	decorated.Author = "alice"
+	decorated.AuthorDate = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	decorated.Refs = []string{"HEAD -> main", "origin/main"}
+
+	tests := []struct {
+		name   string
+		commit *Commit
+		want   string
+	}{
+		{
+			name:   "短縮ハッシュ、日付、作者、参照、件名の順に表示すること",
+			commit: decorated,
+			want:   "4a77a8e 2024-01-02 alice (HEAD -> main, origin/main) add feature",
+		},
+		{
+			name:   "空のフィールドは表示しないこと",
+			commit: NewCommit("75d39af", "initial commit"),
+			want:   "75d39af initial commit",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.commit.GetFzfLine(); got != tt.want {
+				t.Errorf("Commit.GetFzfLine() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsCommitIdLike(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		query string
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "短縮ハッシュの場合はtrueを返すこと",
+			args: args{
+				query: "4a77a8e",
+			},
+			want: true,
+		},
+		{
+			name: "16進数以外の文字を含む場合はfalseを返すこと",
+			args: args{
+				query: "fix typo",
+			},
+			want: false,
+		},
+		{
+			name: "4文字未満の場合はfalseを返すこと",
+			args: args{
+				query: "abc",
+			},
+			want: false,
+		},
+		{
+			name: "空の場合はfalseを返すこと",
+			args: args{
+				query: "",
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := IsCommitIdLike(tt.args.query); got != tt.want {
+				t.Errorf("IsCommitIdLike() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package model
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// 競合を解消するアクションの対象 (途中で止まっている操作と、選択された競合しているファイル)
+type Conflicts struct {
+	// 途中で止まっている操作 (例: rebase, merge, cherry-pick, revert) 何もない場合は空文字
+	Operation string
+	Files     FileStatuses
+}
+
+// 競合しているファイルだけを返す
+func FilterConflictedFileStatuses(fileStatuses []*FileStatus) []*FileStatus {
+	var ret []*FileStatus
+	for _, fileStatus := range fileStatuses {
+		if fileStatus.Conflicted {
+			ret = append(ret, fileStatus)
+		}
+	}
+	return ret
+}
+
+// 選択されたファイルとリポジトリの状態に応じて実行できるアクションだけを返す
+// (例: 競合が残っている間は continue を表示しない、merge の場合は skip を表示しない)
+func (c Conflicts) GetAvailableActionTypes(state RepoState) []ActionType {
+	return filterAvailableActionTypes(ConflictActionTypes.All(), state, c)
+}
+
+func (c Conflicts) meetsCondition(condition ActionCondition, state RepoState) bool {
+	if condition == ConditionFilesSelected {
+		return len(c.Files) > 0
+	}
+	return true
+}
+
+func (c Conflicts) GetFullCommand(actionType ActionType) string {
+	options := c.GetOptionsWithConflicts(actionType)
+	onelineOptions := strings.Join(options, " ")
+
+	fullCommand := fmt.Sprintf("%s %s", actionType.Command, onelineOptions)
+	slog.Debug("Command:", "Command", actionType.Name, "fullCommand", fullCommand)
+
+	return fullCommand
+}
+
+// 途中で止まっている操作に対するアクションは操作の名前を先頭に追加し (例: git rebase --continue)
+// ファイルに対するアクションは選択されたファイルのパスを末尾に追加する
+func (c Conflicts) GetOptionsWithConflicts(actionType ActionType) []string {
+	if ConflictActionTypes.IsOperationAction(actionType) {
+		return append([]string{c.Operation}, actionType.Options...)
+	}
+	return c.Files.GetOptionsWithPaths(actionType)
+}
+
+// 破壊的なアクションの確認で表示する、失われる可能性がある内容の対象を返す
+// 片方の変更を採用するアクションは選択したファイル、中止やスキップはワークツリー全体の変更を表示する
+func (c Conflicts) GetDestructiveScope(actionType ActionType) DestructiveScope {
+	switch {
+	case actionType.IsEqual(ConflictActionTypes.TakeOurs), actionType.IsEqual(ConflictActionTypes.TakeTheirs):
+		return DestructiveScope{Worktree: CurrentWorktree, Paths: c.Files.getPathsWithOrigPath()}
+	case actionType.IsEqual(ConflictActionTypes.Abort), actionType.IsEqual(ConflictActionTypes.Skip):
+		return DestructiveScope{Worktree: CurrentWorktree}
+	default:
+		return defaultDestructiveScope()
+	}
+}
+
+func (c Conflicts) GetFzfInputForSelectActionType(actionType ActionType) string {
+	// fzfに渡す形式: "表示名\tフルコマンド\t説明文"
+	return fmt.Sprintf("%s\tDescription : %s\tCommand     : %s\n", actionType.Name, actionType.Help, c.GetFullCommand(actionType))
+}
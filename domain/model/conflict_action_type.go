@@ -0,0 +1,157 @@
+package model
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+type ConflictActionTypeMap struct {
+	TakeOurs     ActionType
+	TakeTheirs   ActionType
+	Mergetool    ActionType
+	Edit         ActionType
+	MarkResolved ActionType
+	Continue     ActionType
+	Abort        ActionType
+	Skip         ActionType
+	Unknown      ActionType
+}
+
+var ConflictActionTypes = ConflictActionTypeMap{
+	TakeOurs: ActionType{
+		Name:    "take ours",
+		Command: "sh",
+		// 選択した側の内容で上書きした後、解消済みにする
+		Options:     []string{"-c", `git checkout --ours -- "$@" && git add -- "$@"`, "sh"},
+		Help:        "Resolve the files with our side (the branch being rebased onto during a rebase)",
+		Multiple:    true,
+		Destructive: true,
+		Conditions:  []ActionCondition{ConditionFilesSelected},
+	},
+	TakeTheirs: ActionType{
+		Name:        "take theirs",
+		Command:     "sh",
+		Options:     []string{"-c", `git checkout --theirs -- "$@" && git add -- "$@"`, "sh"},
+		Help:        "Resolve the files with their side (the commit being applied during a rebase or cherry-pick)",
+		Multiple:    true,
+		Destructive: true,
+		Conditions:  []ActionCondition{ConditionFilesSelected},
+	},
+	Mergetool: ActionType{
+		Name:       "mergetool",
+		Command:    "git",
+		Options:    []string{"mergetool", "--"},
+		Help:       "Resolve the files with the configured merge tool",
+		Multiple:   true,
+		Conditions: []ActionCondition{ConditionFilesSelected},
+	},
+	Edit: ActionType{
+		Name:    "edit",
+		Command: "sh",
+		// $EDITOR が設定されていない場合は vi で開く
+		Options:    []string{"-c", `${EDITOR:-vi} "$@"`, "sh"},
+		Help:       "Open the files in $EDITOR to resolve the conflict markers by hand",
+		Multiple:   true,
+		Conditions: []ActionCondition{ConditionFilesSelected},
+	},
+	MarkResolved: ActionType{
+		Name:       "mark resolved",
+		Command:    "git",
+		Options:    []string{"add", "--"},
+		Help:       "Mark the files as resolved",
+		Multiple:   true,
+		Conditions: []ActionCondition{ConditionFilesSelected},
+	},
+	// 途中で止まっている操作に対するアクション (実行時に操作の名前を先頭に追加する)
+	Continue: ActionType{
+		Name:       "continue",
+		Command:    "git",
+		Options:    []string{"--continue"},
+		Help:       "Continue the operation after all conflicts are resolved",
+		Conditions: []ActionCondition{ConditionOperationInProgress, ConditionNoConflicts},
+	},
+	Abort: ActionType{
+		Name:        "abort",
+		Command:     "git",
+		Options:     []string{"--abort"},
+		Help:        "Abort the operation and go back to the state before it started",
+		Destructive: true,
+		Conditions:  []ActionCondition{ConditionOperationInProgress},
+	},
+	Skip: ActionType{
+		Name:        "skip",
+		Command:     "git",
+		Options:     []string{"--skip"},
+		Help:        "Skip the current commit and continue with the rest",
+		Destructive: true,
+		Conditions:  []ActionCondition{ConditionSkippableOperation},
+	},
+	Unknown: ActionType{
+		Name:    "unknown",
+		Command: "unknown",
+		Options: nil,
+		Help:    "unknown",
+	},
+}
+
+func (c ConflictActionTypeMap) All() []ActionType {
+	return []ActionType{
+		c.Edit,
+		c.Mergetool,
+		c.MarkResolved,
+		c.TakeOurs,
+		c.TakeTheirs,
+		c.Continue,
+		c.Skip,
+		c.Abort,
+	}
+}
+
+// 途中で止まっている操作に対するアクションか (対象のファイルを引数に取らない)
+func (c ConflictActionTypeMap) IsOperationAction(actionType ActionType) bool {
+	return actionType.IsEqual(c.Continue) || actionType.IsEqual(c.Abort) || actionType.IsEqual(c.Skip)
+}
+
+func (c ConflictActionTypeMap) GetConflictActionTypes(action string) (ActionType, error) {
+	switch action {
+	case "take ours":
+		return c.TakeOurs, nil
+	case "take theirs":
+		return c.TakeTheirs, nil
+	case "mergetool":
+		return c.Mergetool, nil
+	case "edit":
+		return c.Edit, nil
+	case "mark resolved":
+		return c.MarkResolved, nil
+	case "continue":
+		return c.Continue, nil
+	case "abort":
+		return c.Abort, nil
+	case "skip":
+		return c.Skip, nil
+	default:
+		return c.Unknown, fmt.Errorf("unknown action: %s", action)
+	}
+}
+
+func ParseSelectedConflictActionType(selectedLine string) (ActionType, error) {
+	slog.Debug("Selected action from fzf", "selected", selectedLine)
+	if selectedLine == "" {
+		slog.Debug("No action selected")
+		return ConflictActionTypes.Unknown, nil
+	}
+
+	// タブで分割
+	fields := strings.Split(selectedLine, "\t")
+
+	// 最初のフィールドだけ取得
+	selectedActionType := fields[0]
+
+	result, err := ConflictActionTypes.GetConflictActionTypes(selectedActionType)
+	if err != nil {
+		return ConflictActionTypes.Unknown, err
+	}
+	return result, nil
+}
@@ -0,0 +1,175 @@
+package model
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestConflictActionTypeMap_GetConflictActionTypes(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		action string
+	}
+	tests := []struct {
+		name           string
+		args           args
+		want           ActionType
+		wantErr        bool
+		wantErrMessage error
+	}{
+		{
+			name: "対応する競合のアクション(take ours)を取得すること",
+			args: args{
+				action: "take ours",
+			},
+			want:           ConflictActionTypes.TakeOurs,
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "対応する競合のアクション(take theirs)を取得すること",
+			args: args{
+				action: "take theirs",
+			},
+			want:           ConflictActionTypes.TakeTheirs,
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "対応する競合のアクション(mergetool)を取得すること",
+			args: args{
+				action: "mergetool",
+			},
+			want:           ConflictActionTypes.Mergetool,
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "対応する競合のアクション(edit)を取得すること",
+			args: args{
+				action: "edit",
+			},
+			want:           ConflictActionTypes.Edit,
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "対応する競合のアクション(mark resolved)を取得すること",
+			args: args{
+				action: "mark resolved",
+			},
+			want:           ConflictActionTypes.MarkResolved,
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "対応する競合のアクション(continue)を取得すること",
+			args: args{
+				action: "continue",
+			},
+			want:           ConflictActionTypes.Continue,
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "対応する競合のアクション(abort)を取得すること",
+			args: args{
+				action: "abort",
+			},
+			want:           ConflictActionTypes.Abort,
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "対応する競合のアクション(skip)を取得すること",
+			args: args{
+				action: "skip",
+			},
+			want:           ConflictActionTypes.Skip,
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "不明なアクションが指定された場合、errorを返却すること",
+			args: args{
+				action: "dummy",
+			},
+			want:           ConflictActionTypes.Unknown,
+			wantErr:        true,
+			wantErrMessage: fmt.Errorf("unknown action: %s", "dummy"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ConflictActionTypes.GetConflictActionTypes(tt.args.action)
+			if (err != nil) != tt.wantErr || err != nil && err.Error() != tt.wantErrMessage.Error() {
+				t.Errorf("ConflictActionTypeMap.GetConflictActionTypes() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("ConflictActionTypeMap.GetConflictActionTypes() error = %v, wantErrMessage %v", err, tt.wantErrMessage)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ConflictActionTypeMap.GetConflictActionTypes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSelectedConflictActionType(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		selectedLine string
+	}
+	tests := []struct {
+		name           string
+		args           args
+		want           ActionType
+		wantErr        bool
+		wantErrMessage error
+	}{
+		{
+			name: "fzfの選択結果を元に、対応する競合のアクションを取得すること",
+			args: args{
+				selectedLine: "mark resolved\tDescription : hogehoge\tCommand     : fugafuga\n",
+			},
+			want:           ConflictActionTypes.MarkResolved,
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "何も選択されなかった場合、Unknownを返却すること",
+			args: args{
+				selectedLine: "",
+			},
+			want:           ConflictActionTypes.Unknown,
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "不明な文字列が指定された場合、Unknownを返却すること",
+			args: args{
+				selectedLine: "dummy\tDescription : hogehoge\tCommand     : fugafuga\n",
+			},
+			want:           ConflictActionTypes.Unknown,
+			wantErr:        true,
+			wantErrMessage: fmt.Errorf("unknown action: %s", "dummy"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseSelectedConflictActionType(tt.args.selectedLine)
+			if (err != nil) != tt.wantErr || err != nil && err.Error() != tt.wantErrMessage.Error() {
+				t.Errorf("ParseSelectedConflictActionType() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("ParseSelectedConflictActionType() error = %v, wantErrMessage %v", err, tt.wantErrMessage)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSelectedConflictActionType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFilterConflictedFileStatuses(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		fileStatuses []*FileStatus
+	}
+	tests := []struct {
+		name string
+		args args
+		want []*FileStatus
+	}{
+		{
+			name: "競合しているファイルだけを返却すること",
+			args: args{
+				fileStatuses: []*FileStatus{
+					{Path: "README.md", IndexStatus: ".", WorktreeStatus: "M", Unstaged: true},
+					{Path: "main.go", IndexStatus: "U", WorktreeStatus: "U", Conflicted: true},
+				},
+			},
+			want: []*FileStatus{
+				{Path: "main.go", IndexStatus: "U", WorktreeStatus: "U", Conflicted: true},
+			},
+		},
+		{
+			name: "競合しているファイルがない場合、nilを返却すること",
+			args: args{
+				fileStatuses: []*FileStatus{
+					{Path: "README.md", IndexStatus: ".", WorktreeStatus: "M", Unstaged: true},
+				},
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := FilterConflictedFileStatuses(tt.args.fileStatuses); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FilterConflictedFileStatuses() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConflicts_GetOptionsWithConflicts(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		conflicts  Conflicts
+		actionType ActionType
+	}
+	tests := []struct {
+		name string
+		args args
+		want []string
+	}{
+		{
+			name: "ファイルに対するアクションは選択されたファイルのパスを末尾に追加すること",
+			args: args{
+				conflicts: Conflicts{
+					Operation: "rebase",
+					Files: FileStatuses{
+						{Path: "main.go", IndexStatus: "U", WorktreeStatus: "U", Conflicted: true},
+						{Path: "docs/new file.md", IndexStatus: "A", WorktreeStatus: "A", Conflicted: true},
+					},
+				},
+				actionType: ConflictActionTypes.MarkResolved,
+			},
+			want: []string{"add", "--", "main.go", "docs/new file.md"},
+		},
+		{
+			name: "途中の操作に対するアクションは操作の名前を先頭に追加すること",
+			args: args{
+				conflicts: Conflicts{
+					Operation: "cherry-pick",
+				},
+				actionType: ConflictActionTypes.Continue,
+			},
+			want: []string{"cherry-pick", "--continue"},
+		},
+		{
+			name: "ファイルが選択されていても途中の操作に対するアクションはパスを追加しないこと",
+			args: args{
+				conflicts: Conflicts{
+					Operation: "merge",
+					Files: FileStatuses{
+						{Path: "main.go", IndexStatus: "U", WorktreeStatus: "U", Conflicted: true},
+					},
+				},
+				actionType: ConflictActionTypes.Abort,
+			},
+			want: []string{"merge", "--abort"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.args.conflicts.GetOptionsWithConflicts(tt.args.actionType); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Conflicts.GetOptionsWithConflicts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConflicts_GetAvailableActionTypes(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		conflicts Conflicts
+		state     RepoState
+	}
+	tests := []struct {
+		name string
+		args args
+		want []ActionType
+	}{
+		{
+			name: "競合が残っている場合はファイルの解消と中止ができて、続行はできないこと",
+			args: args{
+				conflicts: Conflicts{
+					Operation: "rebase",
+					Files: FileStatuses{
+						{Path: "main.go", IndexStatus: "U", WorktreeStatus: "U", Conflicted: true},
+					},
+				},
+				state: RepoState{Dirty: true, Conflicted: true, OperationInProgress: "rebase"},
+			},
+			want: []ActionType{
+				ConflictActionTypes.Edit,
+				ConflictActionTypes.Mergetool,
+				ConflictActionTypes.MarkResolved,
+				ConflictActionTypes.TakeOurs,
+				ConflictActionTypes.TakeTheirs,
+				ConflictActionTypes.Skip,
+				ConflictActionTypes.Abort,
+			},
+		},
+		{
+			name: "全ての競合を解消した場合は続行できること",
+			args: args{
+				conflicts: Conflicts{
+					Operation: "rebase",
+				},
+				state: RepoState{Dirty: true, OperationInProgress: "rebase"},
+			},
+			want: []ActionType{
+				ConflictActionTypes.Continue,
+				ConflictActionTypes.Skip,
+				ConflictActionTypes.Abort,
+			},
+		},
+		{
+			name: "mergeの途中は対象を飛ばせないこと",
+			args: args{
+				conflicts: Conflicts{
+					Operation: "merge",
+				},
+				state: RepoState{OperationInProgress: "merge"},
+			},
+			want: []ActionType{
+				ConflictActionTypes.Continue,
+				ConflictActionTypes.Abort,
+			},
+		},
+		{
+			name: "途中の操作がない場合は続行や中止ができないこと",
+			args: args{
+				conflicts: Conflicts{
+					Files: FileStatuses{
+						{Path: "main.go", IndexStatus: "U", WorktreeStatus: "U", Conflicted: true},
+					},
+				},
+				state: RepoState{Dirty: true, Conflicted: true},
+			},
+			want: []ActionType{
+				ConflictActionTypes.Edit,
+				ConflictActionTypes.Mergetool,
+				ConflictActionTypes.MarkResolved,
+				ConflictActionTypes.TakeOurs,
+				ConflictActionTypes.TakeTheirs,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.args.conflicts.GetAvailableActionTypes(tt.args.state); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Conflicts.GetAvailableActionTypes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package model
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"strings"
+	"text/template"
+)
+
+// 設定ファイルで定義されたユーザー独自のアクションを生成する
+// args には対象の構造体のフィールドを参照するテンプレート (例: {{.Name}}, {{.Id}}, {{.LastCommitId}}) を指定できる
+func NewCustomActionType(name string, command string, args []string, help string, confirm bool, destructive bool) ActionType {
+	return ActionType{
+		Name:        name,
+		Command:     command,
+		Options:     nil,
+		Help:        help,
+		Custom:      true,
+		Args:        args,
+		Confirm:     confirm,
+		Destructive: destructive,
+	}
+}
+
+// リポジトリ毎の設定ファイルのアクションの説明に付ける印
+// clone したリポジトリが用意したコマンドであることを選択時に見分けられるようにする
+const localCustomActionMarker = "[.gitman.toml]"
+
+// リポジトリ毎の設定ファイル (.gitman.toml) で定義されたアクションを生成する
+func NewLocalCustomActionType(name string, command string, args []string, help string, confirm bool, destructive bool) ActionType {
+	ret := NewCustomActionType(name, command, args, help, confirm, destructive)
+	ret.Local = true
+	ret.Help = strings.TrimSpace(localCustomActionMarker + " " + help)
+	return ret
+}
+
+// テンプレート形式の引数を対象の値で展開する
+func (a ActionType) renderArgs(data any) ([]string, error) {
+	ret := make([]string, 0, len(a.Args))
+	for _, arg := range a.Args {
+		tmpl, err := template.New(a.Name).Option("missingkey=error").Parse(arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid argument template %q in action %q: %w", arg, a.Name, err)
+		}
+
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("failed to render argument template %q in action %q: %w", arg, a.Name, err)
+		}
+		ret = append(ret, buf.String())
+	}
+	return ret, nil
+}
+
+// テンプレート形式の引数を対象の値で展開したオプションを返す
+// テンプレートは登録時に検証済みのため、失敗した場合はログを出力して展開前の引数を返す
+func (a ActionType) GetCustomOptions(data any) []string {
+	ret, err := a.renderArgs(data)
+	if err != nil {
+		slog.Error("failed to render custom action arguments", "action", a.Name, "error", err)
+		return a.Args
+	}
+	return ret
+}
+
+// 独自のアクションの名前が重複せず、テンプレートが対象の構造体で展開できるか検証する
+// 組み込みのアクションと同じ名前は、後から組み込みのアクションが追加されても設定が使えるように許可する (組み込みのアクションを置き換える)
+// ただしリポジトリ毎の設定ファイルのアクションは、見慣れた名前で別のコマンドを実行させないよう組み込みのアクションを置き換えられない
+func validateCustomActionTypes(customs []ActionType, builtins []ActionType, data any) error {
+	builtinNames := make(map[string]bool, len(builtins))
+	for _, builtin := range builtins {
+		builtinNames[builtin.Name] = true
+	}
+
+	names := map[string]bool{"unknown": true}
+	for _, custom := range customs {
+		if custom.Name == "" || custom.Command == "" {
+			return fmt.Errorf("custom action requires name and command: %+v", custom)
+		}
+		if custom.Local && builtinNames[custom.Name] {
+			return fmt.Errorf("custom action %q in .gitman.toml can not replace the built-in action (define it in the user config instead)", custom.Name)
+		}
+		if names[custom.Name] {
+			return fmt.Errorf("custom action %q conflicts with another action", custom.Name)
+		}
+		names[custom.Name] = true
+
+		if _, err := custom.renderArgs(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 組み込みのアクションの後に独自のアクションを追加する
+// 組み込みのアクションと同じ名前の独自のアクションは、組み込みのアクションと同じ位置で置き換える
+func mergeCustomActionTypes(builtins []ActionType, customs []ActionType) []ActionType {
+	ret := make([]ActionType, 0, len(builtins)+len(customs))
+	overridden := make(map[string]bool, len(customs))
+	for _, builtin := range builtins {
+		if custom, ok := findCustomActionType(customs, builtin.Name); ok {
+			ret = append(ret, custom)
+			overridden[custom.Name] = true
+			continue
+		}
+		ret = append(ret, builtin)
+	}
+	for _, custom := range customs {
+		if !overridden[custom.Name] {
+			ret = append(ret, custom)
+		}
+	}
+	return ret
+}
+
+func SetCustomBranchActionTypes(actionTypes []ActionType) error {
+	builtins := BranchActionTypes
+	builtins.Custom = nil
+	if err := validateCustomActionTypes(actionTypes, builtins.All(), Branch{}); err != nil {
+		return fmt.Errorf("invalid branch action: %w", err)
+	}
+	BranchActionTypes.Custom = actionTypes
+	return nil
+}
+
+func SetCustomCommitActionTypes(actionTypes []ActionType) error {
+	builtins := CommitActionTypes
+	builtins.Custom = nil
+	if err := validateCustomActionTypes(actionTypes, builtins.All(), Commit{}); err != nil {
+		return fmt.Errorf("invalid commit action: %w", err)
+	}
+	CommitActionTypes.Custom = actionTypes
+	return nil
+}
+
+func SetCustomReflogActionTypes(actionTypes []ActionType) error {
+	builtins := ReflogActionTypes
+	builtins.Custom = nil
+	if err := validateCustomActionTypes(actionTypes, builtins.All(), Reflog{}); err != nil {
+		return fmt.Errorf("invalid reflog action: %w", err)
+	}
+	ReflogActionTypes.Custom = actionTypes
+	return nil
+}
+
+func SetCustomStashActionTypes(actionTypes []ActionType) error {
+	builtins := StashActionTypes
+	builtins.Custom = nil
+	if err := validateCustomActionTypes(actionTypes, builtins.All(), Stash{}); err != nil {
+		return fmt.Errorf("invalid stash action: %w", err)
+	}
+	StashActionTypes.Custom = actionTypes
+	return nil
+}
+
+func SetCustomTagActionTypes(actionTypes []ActionType) error {
+	builtins := TagActionTypes
+	builtins.Custom = nil
+	if err := validateCustomActionTypes(actionTypes, builtins.All(), Tag{}); err != nil {
+		return fmt.Errorf("invalid tag action: %w", err)
+	}
+	TagActionTypes.Custom = actionTypes
+	return nil
+}
+
+func SetCustomWorktreeActionTypes(actionTypes []ActionType) error {
+	builtins := WorktreeActionTypes
+	builtins.Custom = nil
+	if err := validateCustomActionTypes(actionTypes, builtins.All(), Worktree{}); err != nil {
+		return fmt.Errorf("invalid worktree action: %w", err)
+	}
+	WorktreeActionTypes.Custom = actionTypes
+	return nil
+}
+
+func SetCustomRemoteActionTypes(actionTypes []ActionType) error {
+	builtins := RemoteActionTypes
+	builtins.Custom = nil
+	if err := validateCustomActionTypes(actionTypes, builtins.All(), Remote{}); err != nil {
+		return fmt.Errorf("invalid remote action: %w", err)
+	}
+	RemoteActionTypes.Custom = actionTypes
+	return nil
+}
+
+func SetCustomFileStatusActionTypes(actionTypes []ActionType) error {
+	builtins := FileStatusActionTypes
+	builtins.Custom = nil
+	if err := validateCustomActionTypes(actionTypes, builtins.All(), FileStatuses{}); err != nil {
+		return fmt.Errorf("invalid status action: %w", err)
+	}
+	FileStatusActionTypes.Custom = actionTypes
+	return nil
+}
+
+// 独自のアクションから名前が一致するものを探す
+func findCustomActionType(customs []ActionType, action string) (ActionType, bool) {
+	for _, custom := range customs {
+		if custom.Name == action {
+			return custom, true
+		}
+	}
+	return ActionType{}, false
+}
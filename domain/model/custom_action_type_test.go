@@ -0,0 +1,235 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCustomActionType_GetFullCommand(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		fullCmd    func(ActionType) string
+		actionType ActionType
+		want       string
+	}{
+		{
+			name:       "ブランチ名をテンプレートで参照できること",
+			fullCmd:    NewBranch(false, "feature/a", "aaa", "msg").GetFullCommand,
+			actionType: NewCustomActionType("push force", "git", []string{"push", "--force-with-lease", "origin", "{{.Name}}"}, "force push", true, false),
+			want:       "git push --force-with-lease origin feature/a",
+		},
+		{
+			name:       "ブランチの最終コミットIDをテンプレートで参照できること",
+			fullCmd:    NewBranch(false, "feature/a", "aaa", "msg").GetFullCommand,
+			actionType: NewCustomActionType("show last", "git", []string{"show", "{{.LastCommitId}}"}, "show", false, false),
+			want:       "git show aaa",
+		},
+		{
+			name:       "コミットIDをテンプレートで参照できること",
+			fullCmd:    NewCommit("1a2b3c", "msg").GetFullCommand,
+			actionType: NewCustomActionType("open", "sh", []string{"-c", "open https://example.com/commit/{{.Id}}"}, "open", false, false),
+			want:       "sh -c open https://example.com/commit/1a2b3c",
+		},
+		{
+			name:       "ワークツリーのパスをテンプレートで参照できること",
+			fullCmd:    (&Worktree{Path: "/home/user/gitman-fix", Branch: "fix"}).GetFullCommand,
+			actionType: NewCustomActionType("open", "code", []string{"{{.Path}}"}, "open", false, false),
+			want:       "code /home/user/gitman-fix",
+		},
+		{
+			name:       "リモートの名前をテンプレートで参照できること",
+			fullCmd:    NewRemote("origin", "git@example.com:a/b.git", "git@example.com:a/b.git").GetFullCommand,
+			actionType: NewCustomActionType("browse", "gh", []string{"browse", "--repo", "{{.FetchURL}}"}, "browse", false, false),
+			want:       "gh browse --repo git@example.com:a/b.git",
+		},
+		{
+			name:       "ステータスの場合は展開した引数の後に選択したファイルを追加すること",
+			fullCmd:    FileStatuses{NewFileStatus(".", "M", "a.go", ""), NewFileStatus(".", "M", "b.go", "")}.GetFullCommand,
+			actionType: NewCustomActionType("format", "gofmt", []string{"-w"}, "format", false, false),
+			want:       "gofmt -w a.go b.go",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.fullCmd(tt.actionType); got != tt.want {
+				t.Errorf("GetFullCommand() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateCustomActionTypes(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		customs []ActionType
+		wantErr bool
+	}{
+		{
+			name:    "正しい独自のアクションはエラーにならないこと",
+			customs: []ActionType{NewCustomActionType("push force", "git", []string{"push", "{{.Name}}"}, "", false, false)},
+			wantErr: false,
+		},
+		{
+			name:    "組み込みのアクションと同名の場合もエラーにならないこと",
+			customs: []ActionType{NewCustomActionType("switch", "git", []string{"switch", "{{.Name}}"}, "", false, false)},
+			wantErr: false,
+		},
+		{
+			name:    "リポジトリ毎の設定ファイルのアクションは組み込みのアクションと別名であればエラーにならないこと",
+			customs: []ActionType{NewLocalCustomActionType("push force", "git", []string{"push", "{{.Name}}"}, "", false, false)},
+			wantErr: false,
+		},
+		{
+			name:    "リポジトリ毎の設定ファイルのアクションが組み込みのアクションと同名の場合はエラーを返すこと",
+			customs: []ActionType{NewLocalCustomActionType("switch", "sh", []string{"-c", "echo {{.Name}}"}, "", false, false)},
+			wantErr: true,
+		},
+		{
+			name: "独自のアクション同士で同名の場合はエラーを返すこと",
+			customs: []ActionType{
+				NewCustomActionType("push", "git", []string{"push"}, "", false, false),
+				NewCustomActionType("push", "git", []string{"push"}, "", false, false),
+			},
+			wantErr: true,
+		},
+		{
+			name:    "存在しないフィールドを参照した場合はエラーを返すこと",
+			customs: []ActionType{NewCustomActionType("push", "git", []string{"push", "{{.Id}}"}, "", false, false)},
+			wantErr: true,
+		},
+		{
+			name:    "テンプレートの構文が誤っている場合はエラーを返すこと",
+			customs: []ActionType{NewCustomActionType("push", "git", []string{"push", "{{.Name"}, "", false, false)},
+			wantErr: true,
+		},
+		{
+			name:    "コマンドが空の場合はエラーを返すこと",
+			customs: []ActionType{NewCustomActionType("push", "", nil, "", false, false)},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := validateCustomActionTypes(tt.customs, BranchActionTypes.All(), Branch{})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateCustomActionTypes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMergeCustomActionTypes(t *testing.T) {
+	t.Parallel()
+	pushForce := NewCustomActionType("push force", "git", []string{"push", "{{.Name}}"}, "force push", true, false)
+	pushOverride := NewCustomActionType("push", "git", []string{"push", "fork", "{{.Name}}"}, "push to fork", false, false)
+	type args struct {
+		builtins []ActionType
+		customs  []ActionType
+	}
+	tests := []struct {
+		name string
+		args args
+		want []ActionType
+	}{
+		{
+			name: "組み込みのアクションの後に独自のアクションを追加すること",
+			args: args{
+				builtins: []ActionType{BranchActionTypes.Switch, BranchActionTypes.Push},
+				customs:  []ActionType{pushForce},
+			},
+			want: []ActionType{BranchActionTypes.Switch, BranchActionTypes.Push, pushForce},
+		},
+		{
+			name: "組み込みのアクションと同名の独自のアクションは同じ位置で置き換えること",
+			args: args{
+				builtins: []ActionType{BranchActionTypes.Switch, BranchActionTypes.Push, BranchActionTypes.Delete},
+				customs:  []ActionType{pushForce, pushOverride},
+			},
+			want: []ActionType{BranchActionTypes.Switch, pushOverride, BranchActionTypes.Delete, pushForce},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := mergeCustomActionTypes(tt.args.builtins, tt.args.customs); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mergeCustomActionTypes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBranchActionTypeMap_Custom(t *testing.T) {
+	t.Parallel()
+	custom := NewCustomActionType("push force", "git", []string{"push", "{{.Name}}"}, "force push", true, false)
+	m := BranchActionTypes
+	m.Custom = []ActionType{custom}
+
+	if got := m.All(); !reflect.DeepEqual(got[len(got)-1], custom) {
+		t.Errorf("BranchActionTypeMap.All() should end with the custom action, got %v", got)
+	}
+
+	got, err := m.GetBranchActionTypes("push force")
+	if err != nil || !reflect.DeepEqual(got, custom) {
+		t.Errorf("BranchActionTypeMap.GetBranchActionTypes() = %v, %v, want %v", got, err, custom)
+	}
+}
+
+func TestBranchActionTypeMap_CustomOverride(t *testing.T) {
+	t.Parallel()
+	custom := NewCustomActionType("push", "git", []string{"push", "fork", "{{.Name}}"}, "push to fork", false, false)
+	m := BranchActionTypes
+	m.Custom = []ActionType{custom}
+
+	got, err := m.GetBranchActionTypes("push")
+	if err != nil || !reflect.DeepEqual(got, custom) {
+		t.Errorf("BranchActionTypeMap.GetBranchActionTypes() = %v, %v, want %v", got, err, custom)
+	}
+	// 組み込みのアクションと同じ名前でも、組み込みのアクションとしては扱わないこと
+	if got.IsEqual(BranchActionTypes.Push) {
+		t.Errorf("ActionType.IsEqual() = true, want false for a custom action overriding a builtin")
+	}
+}
+
+func TestNewLocalCustomActionType(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		help string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "説明の先頭にリポジトリ毎の設定ファイルのアクションであることを示す印を付けること",
+			args: args{
+				help: "Deploy the branch",
+			},
+			want: "[.gitman.toml] Deploy the branch",
+		},
+		{
+			name: "説明が空の場合は印だけを表示すること",
+			args: args{
+				help: "",
+			},
+			want: "[.gitman.toml]",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := NewLocalCustomActionType("deploy", "make", []string{"deploy"}, tt.args.help, false, false)
+			if got.Help != tt.want || !got.Local || !got.Custom {
+				t.Errorf("NewLocalCustomActionType() = %+v, want Help %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// カレントディレクトリのワークツリーを表す DestructiveScope.Worktree の値
+const CurrentWorktree = "."
+
+// 破壊的なアクションで失われる可能性がある内容として、確認時に表示する対象
+// アクションの対象 (ブランチ、スタッシュ、ワークツリーなど) ごとに GetDestructiveScope で決める
+type DestructiveScope struct {
+	// 未コミットの変更を表示するワークツリーのパス (空文字の場合は表示しない)
+	Worktree string
+	// 未コミットの変更を表示するファイル (リポジトリのルートからの相対パス、空の場合はワークツリー全体)
+	Paths []string
+	// リモートにプッシュされていないコミット数を表示する参照
+	UnpushedRefs []string
+	// 強制的な push で上書きされる、上流ブランチだけにあるコミット数を表示するブランチ
+	OverwrittenRefs []OverwrittenRef
+	// 変更されたファイルを表示するスタッシュ
+	Stash string
+}
+
+// 強制的な push で上書きされるブランチと上流ブランチ
+type OverwrittenRef struct {
+	Ref      string
+	Upstream string
+}
+
+// 表示する内容がないか (例: タグの削除はローカルの変更に影響しない)
+func (s DestructiveScope) IsEmpty() bool {
+	return s.Worktree == "" && len(s.UnpushedRefs) == 0 && len(s.OverwrittenRefs) == 0 && s.Stash == ""
+}
+
+// 独自のアクションなど、失われる内容がわからない場合に表示する対象
+// カレントディレクトリのワークツリーの変更と refs の未プッシュのコミット数を表示する
+func defaultDestructiveScope(refs ...string) DestructiveScope {
+	return DestructiveScope{Worktree: CurrentWorktree, UnpushedRefs: refs}
+}
+
+// リモートにプッシュされていないコミット数
+type UnpushedCommitCount struct {
+	Ref   string
+	Count int
+}
+
+// 強制的な push で上書きされる、上流ブランチだけにあるコミット数
+type OverwrittenCommitCount struct {
+	Ref      string
+	Upstream string
+	Count    int
+}
+
+// 破壊的なアクションを実行する前に表示する、失われる可能性がある内容の要約
+type DestructiveSummary struct {
+	// 未コミットの変更を取得したワークツリーのパス
+	Worktree string
+	// git status --short の各行
+	ChangedFiles []string
+	Stash        string
+	// git stash show --name-status の各行
+	StashFiles         []string
+	UnpushedCommits    []UnpushedCommitCount
+	OverwrittenCommits []OverwrittenCommitCount
+}
+
+func NewDestructiveSummary(scope DestructiveScope, statusShort string, stashFiles string, unpushedCommits []UnpushedCommitCount, overwrittenCommits []OverwrittenCommitCount) *DestructiveSummary {
+	return &DestructiveSummary{
+		Worktree:           scope.Worktree,
+		ChangedFiles:       splitNonEmptyLines(statusShort),
+		Stash:              scope.Stash,
+		StashFiles:         splitNonEmptyLines(stashFiles),
+		UnpushedCommits:    unpushedCommits,
+		OverwrittenCommits: overwrittenCommits,
+	}
+}
+
+func splitNonEmptyLines(s string) []string {
+	var ret []string
+	for _, line := range strings.Split(s, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		ret = append(ret, line)
+	}
+	return ret
+}
+
+// 確認画面に表示する文字列を返す
+func (d DestructiveSummary) String() string {
+	var lines []string
+
+	if len(d.ChangedFiles) > 0 {
+		// カレントディレクトリ以外のワークツリーの場合はパスを表示する
+		if d.Worktree != "" && d.Worktree != CurrentWorktree {
+			lines = append(lines, fmt.Sprintf("Uncommitted changes in %s (%d files):", d.Worktree, len(d.ChangedFiles)))
+		} else {
+			lines = append(lines, fmt.Sprintf("Uncommitted changes (%d files):", len(d.ChangedFiles)))
+		}
+		for _, file := range d.ChangedFiles {
+			lines = append(lines, "  "+file)
+		}
+	}
+
+	if len(d.StashFiles) > 0 {
+		lines = append(lines, fmt.Sprintf("Changes in %s (%d files):", d.Stash, len(d.StashFiles)))
+		for _, file := range d.StashFiles {
+			lines = append(lines, "  "+file)
+		}
+	}
+
+	for _, unpushed := range d.UnpushedCommits {
+		if unpushed.Count == 0 {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s has %d commit(s) not pushed to any remote", unpushed.Ref, unpushed.Count))
+	}
+
+	for _, overwritten := range d.OverwrittenCommits {
+		if overwritten.Count == 0 {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s has %d commit(s) not in %s that will be overwritten", overwritten.Upstream, overwritten.Count, overwritten.Ref))
+	}
+
+	if len(lines) == 0 {
+		return "No uncommitted changes or unpushed commits."
+	}
+	return strings.Join(lines, "\n")
+}
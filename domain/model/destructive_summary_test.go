@@ -0,0 +1,162 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDestructiveSummary_String(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		scope              DestructiveScope
+		statusShort        string
+		stashFiles         string
+		unpushedCommits    []UnpushedCommitCount
+		overwrittenCommits []OverwrittenCommitCount
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "未コミットの変更と未プッシュのコミット数を表示すること",
+			args: args{
+				scope:           DestructiveScope{Worktree: CurrentWorktree, UnpushedRefs: []string{"HEAD"}},
+				statusShort:     " M main.go\n?? new.go\n",
+				unpushedCommits: []UnpushedCommitCount{{Ref: "HEAD", Count: 2}},
+			},
+			want: "Uncommitted changes (2 files):\n   M main.go\n  ?? new.go\nHEAD has 2 commit(s) not pushed to any remote",
+		},
+		{
+			name: "カレントディレクトリ以外のワークツリーの場合はパスを表示すること",
+			args: args{
+				scope:       DestructiveScope{Worktree: "/repo/feature"},
+				statusShort: " M main.go\n",
+			},
+			want: "Uncommitted changes in /repo/feature (1 files):\n   M main.go",
+		},
+		{
+			name: "スタッシュの場合は変更されたファイルを表示すること",
+			args: args{
+				scope:      DestructiveScope{Stash: "stash@{0}"},
+				stashFiles: "M\tmain.go\nA\tnew.go\n",
+			},
+			want: "Changes in stash@{0} (2 files):\n  M\tmain.go\n  A\tnew.go",
+		},
+		{
+			name: "強制的なpushで上書きされるコミット数を表示すること",
+			args: args{
+				scope:              DestructiveScope{OverwrittenRefs: []OverwrittenRef{{Ref: "feature", Upstream: "origin/feature"}}},
+				overwrittenCommits: []OverwrittenCommitCount{{Ref: "feature", Upstream: "origin/feature", Count: 3}},
+			},
+			want: "origin/feature has 3 commit(s) not in feature that will be overwritten",
+		},
+		{
+			name: "未プッシュのコミットがない参照は表示しないこと",
+			args: args{
+				scope:           DestructiveScope{UnpushedRefs: []string{"feature/a", "feature/b"}},
+				unpushedCommits: []UnpushedCommitCount{{Ref: "feature/a", Count: 0}, {Ref: "feature/b", Count: 1}},
+			},
+			want: "feature/b has 1 commit(s) not pushed to any remote",
+		},
+		{
+			name: "失われる内容がない場合はその旨を表示すること",
+			args: args{
+				scope: DestructiveScope{Worktree: CurrentWorktree},
+			},
+			want: "No uncommitted changes or unpushed commits.",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := NewDestructiveSummary(tt.args.scope, tt.args.statusShort, tt.args.stashFiles, tt.args.unpushedCommits, tt.args.overwrittenCommits).String()
+			if got != tt.want {
+				t.Errorf("DestructiveSummary.String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetDestructiveScope(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		target interface {
+			GetDestructiveScope(ActionType) DestructiveScope
+		}
+		actionType ActionType
+	}
+	tests := []struct {
+		name string
+		args args
+		want DestructiveScope
+	}{
+		{
+			name: "ブランチの削除は対象のブランチの未プッシュのコミットだけを対象とすること",
+			args: args{
+				target:     Branch{Name: "feature"},
+				actionType: BranchActionTypes.Delete,
+			},
+			want: DestructiveScope{UnpushedRefs: []string{"feature"}},
+		},
+		{
+			name: "ブランチの強制的なpushは上流ブランチだけにあるコミットを対象とすること",
+			args: args{
+				target:     Branch{Name: "feature", Upstream: "origin/feature"},
+				actionType: BranchActionTypes.PushForce,
+			},
+			want: DestructiveScope{OverwrittenRefs: []OverwrittenRef{{Ref: "feature", Upstream: "origin/feature"}}},
+		},
+		{
+			name: "ワークツリーの削除は対象のワークツリーの変更を対象とすること",
+			args: args{
+				target:     Worktree{Path: "/repo/feature"},
+				actionType: WorktreeActionTypes.Remove,
+			},
+			want: DestructiveScope{Worktree: "/repo/feature"},
+		},
+		{
+			name: "スタッシュの削除はスタッシュの内容を対象とすること",
+			args: args{
+				target:     Stash{Id: "stash@{1}"},
+				actionType: StashActionTypes.Drop,
+			},
+			want: DestructiveScope{Stash: "stash@{1}"},
+		},
+		{
+			name: "タグの削除は何も対象としないこと",
+			args: args{
+				target:     Tag{Name: "v1.0.0"},
+				actionType: TagActionTypes.DeleteLocal,
+			},
+			want: DestructiveScope{},
+		},
+		{
+			name: "ファイルを元に戻すアクションは変更前のパスを含む選択したファイルを対象とすること",
+			args: args{
+				target:     FileStatuses{{Path: "new.go", OrigPath: "old.go"}, {Path: "main.go"}},
+				actionType: FileStatusActionTypes.Discard,
+			},
+			want: DestructiveScope{Worktree: CurrentWorktree, Paths: []string{"new.go", "old.go", "main.go"}},
+		},
+		{
+			name: "reflogのreset --hardはワークツリーの変更とHEADの未プッシュのコミットを対象とすること",
+			args: args{
+				target:     Reflog{},
+				actionType: ReflogActionTypes.ResetHard,
+			},
+			want: DestructiveScope{Worktree: CurrentWorktree, UnpushedRefs: []string{"HEAD"}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.args.target.GetDestructiveScope(tt.args.actionType); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetDestructiveScope() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
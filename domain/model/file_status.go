@@ -0,0 +1,272 @@
+package model
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// git status --porcelain=v2 で対象となったファイルを表す構造体
+// パスはリポジトリのルートからの相対パス
+type FileStatus struct {
+	Path           string
+	OrigPath       string
+	IndexStatus    string
+	WorktreeStatus string
+	Staged         bool
+	Unstaged       bool
+	Untracked      bool
+	Conflicted     bool
+}
+
+func NewFileStatus(indexStatus string, worktreeStatus string, path string, origPath string) *FileStatus {
+	return &FileStatus{
+		Path:           path,
+		OrigPath:       origPath,
+		IndexStatus:    indexStatus,
+		WorktreeStatus: worktreeStatus,
+		Staged:         indexStatus != "." && indexStatus != "?",
+		Unstaged:       worktreeStatus != "." && worktreeStatus != "?",
+		Untracked:      indexStatus == "?",
+	}
+}
+
+func newConflictedFileStatus(indexStatus string, worktreeStatus string, path string) *FileStatus {
+	fileStatus := NewFileStatus(indexStatus, worktreeStatus, path, "")
+	fileStatus.Staged = false
+	fileStatus.Unstaged = false
+	fileStatus.Conflicted = true
+	return fileStatus
+}
+
+func (f FileStatus) meetsCondition(condition ActionCondition, state RepoState) bool {
+	switch condition {
+	case ConditionTrackedFile:
+		return !f.Untracked
+	case ConditionUntrackedFile:
+		return f.Untracked
+	case ConditionStagedFile:
+		return f.Staged
+	case ConditionUnstagedFile:
+		return f.Unstaged
+	}
+	return true
+}
+
+func (f FileStatus) String() string {
+	return f.Path
+}
+
+// --list で出力する項目を返す
+func (f FileStatus) GetListItem() ListItem {
+	return ListItem{
+		{Key: "path", Value: f.Path},
+		{Key: "orig_path", Value: f.OrigPath},
+		{Key: "state", Value: f.GetStateLabel()},
+		{Key: "index_status", Value: f.IndexStatus},
+		{Key: "worktree_status", Value: f.WorktreeStatus},
+		{Key: "staged", Value: f.Staged},
+		{Key: "unstaged", Value: f.Unstaged},
+		{Key: "untracked", Value: f.Untracked},
+		{Key: "conflicted", Value: f.Conflicted},
+	}
+}
+
+// ファイルの状態を表すラベルを返す
+func (f FileStatus) GetStateLabel() string {
+	switch {
+	case f.Conflicted:
+		return "conflicted"
+	case f.Untracked:
+		return "untracked"
+	case f.Staged && f.Unstaged:
+		return "partial"
+	case f.Staged:
+		return "staged"
+	default:
+		return "unstaged"
+	}
+}
+
+// プレビューに表示する差分のコマンドを返す
+// 作業ツリーに変更がある場合はインデックスとの差分、ステージ済みのみの場合はHEADとの差分を表示する
+func (f FileStatus) GetPreviewCommand() string {
+	path := shellQuote(f.Path)
+	switch {
+	case f.Untracked:
+		return fmt.Sprintf("git diff --color=always --no-index -- /dev/null %s", path)
+	case f.Conflicted, f.Unstaged:
+		return fmt.Sprintf("git diff --color=always -- %s", path)
+	default:
+		if f.OrigPath != "" {
+			return fmt.Sprintf("git diff --color=always --cached -M -- %s %s", shellQuote(f.OrigPath), path)
+		}
+		return fmt.Sprintf("git diff --color=always --cached -- %s", path)
+	}
+}
+
+// fzfの候補として表示する1行を返す
+// 形式: "表示用の文字列\tパス\tプレビュー用のコマンド"
+func (f FileStatus) GetFzfLine() string {
+	display := fmt.Sprintf("%s%s %-10s %s", f.IndexStatus, f.WorktreeStatus, f.GetStateLabel(), f.Path)
+	if f.OrigPath != "" {
+		display = fmt.Sprintf("%s <- %s", display, f.OrigPath)
+	}
+	return fmt.Sprintf("%s\t%s\t%s", display, f.Path, f.GetPreviewCommand())
+}
+
+// fzfで選択された行からパスを取り出す
+func ParseSelectedFileStatusPath(selectedLine string) string {
+	fields := strings.Split(selectedLine, "\t")
+	if len(fields) < 2 {
+		return ""
+	}
+	return fields[1]
+}
+
+func FindFileStatusByPath(fileStatuses []*FileStatus, path string) (*FileStatus, error) {
+	for _, fileStatus := range fileStatuses {
+		if fileStatus.Path == path {
+			return fileStatus, nil
+		}
+	}
+	return nil, fmt.Errorf("file %s not found", path)
+}
+
+// 複数選択されたファイルをまとめて扱うための型
+type FileStatuses []*FileStatus
+
+func (fs FileStatuses) GetFullCommand(actionType ActionType) string {
+	options := fs.GetOptionsWithPaths(actionType)
+	onelineOptions := strings.Join(options, " ")
+
+	fullCommand := fmt.Sprintf("%s %s", actionType.Command, onelineOptions)
+	slog.Debug("Command:", "Command", actionType.Name, "fullCommand", fullCommand)
+
+	return fullCommand
+}
+
+func (fs FileStatuses) GetOptionsWithPaths(actionType ActionType) []string {
+	ret := actionType.Options
+	// 独自のアクションは選択したファイルをまとめて扱うため、展開した引数の後に全てのパスを追加する
+	if actionType.Custom {
+		ret = actionType.GetCustomOptions(fs)
+	}
+	for _, f := range fs {
+		switch {
+		// .gitignore にはルートからのパターンとして、特殊文字をエスケープして追加する
+		case actionType.IsEqual(FileStatusActionTypes.Ignore):
+			ret = append(ret, "/"+escapeGitignorePattern(f.Path))
+		// 名前を変更したファイルは変更前のパスも元に戻さないと、変更前のファイルが削除されたまま残る
+		case f.OrigPath != "" && (actionType.IsEqual(FileStatusActionTypes.Discard) || actionType.IsEqual(FileStatusActionTypes.Unstage)):
+			ret = append(ret, f.Path, f.OrigPath)
+		default:
+			ret = append(ret, f.Path)
+		}
+	}
+	return ret
+}
+
+// パスが文字どおりに一致するように .gitignore の特殊文字をエスケープする
+// ワイルドカード (*, ?, [)、コメント (#)、否定 (!)、バックスラッシュと末尾の空白が対象
+func escapeGitignorePattern(path string) string {
+	var b strings.Builder
+	for _, r := range path {
+		if strings.ContainsRune(`\*?[#!`, r) {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	escaped := b.String()
+	// 末尾の空白はエスケープしないと無視される
+	trimmed := strings.TrimRight(escaped, " ")
+	return trimmed + strings.Repeat(`\ `, len(escaped)-len(trimmed))
+}
+
+// リポジトリの状態と選択された全てのファイルが条件を満たすアクションだけを返す
+func (fs FileStatuses) GetAvailableActionTypes(state RepoState) []ActionType {
+	targets := make([]actionTarget, 0, len(fs))
+	for _, f := range fs {
+		targets = append(targets, f)
+	}
+	return filterAvailableActionTypes(FileStatusActionTypes.All(), state, targets...)
+}
+
+// 破壊的なアクションの確認で表示する、失われる可能性がある内容の対象を返す
+// 元に戻すアクションは選択したファイルの未コミットの変更だけを表示する
+func (fs FileStatuses) GetDestructiveScope(actionType ActionType) DestructiveScope {
+	if actionType.IsEqual(FileStatusActionTypes.Restore) || actionType.IsEqual(FileStatusActionTypes.Discard) {
+		return DestructiveScope{Worktree: CurrentWorktree, Paths: fs.getPathsWithOrigPath()}
+	}
+	return defaultDestructiveScope()
+}
+
+// 名前を変更したファイルは変更前のパスも含めて返す
+func (fs FileStatuses) getPathsWithOrigPath() []string {
+	var ret []string
+	for _, f := range fs {
+		ret = append(ret, f.Path)
+		if f.OrigPath != "" {
+			ret = append(ret, f.OrigPath)
+		}
+	}
+	return ret
+}
+
+func (fs FileStatuses) GetFzfInputForSelectActionType(actionType ActionType) string {
+	// fzfに渡す形式: "表示名\tフルコマンド\t説明文"
+	return fmt.Sprintf("%s\tDescription : %s\tCommand     : %s\n", actionType.Name, actionType.Help, fs.GetFullCommand(actionType))
+}
+
+// git status --porcelain=v2 -z の形式をパースして、FileStatus構造体のスライスを返す
+func ParseFileStatuses(status string) ([]*FileStatus, error) {
+	var result []*FileStatus
+
+	// -z を指定しているため各エントリはNUL区切り
+	entries := strings.Split(status, "\x00")
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		if entry == "" {
+			continue
+		}
+
+		switch entry[0] {
+		case '1':
+			// 1 <XY> <sub> <mH> <mI> <mW> <hH> <hI> <path>
+			fields := strings.SplitN(entry, " ", 9)
+			if len(fields) != 9 {
+				return nil, fmt.Errorf("invalid status entry: %s", entry)
+			}
+			result = append(result, NewFileStatus(fields[1][:1], fields[1][1:], fields[8], ""))
+		case '2':
+			// 2 <XY> <sub> <mH> <mI> <mW> <hH> <hI> <X><score> <path>\0<origPath>
+			fields := strings.SplitN(entry, " ", 10)
+			if len(fields) != 10 || i+1 >= len(entries) {
+				return nil, fmt.Errorf("invalid status entry: %s", entry)
+			}
+			i++
+			result = append(result, NewFileStatus(fields[1][:1], fields[1][1:], fields[9], entries[i]))
+		case 'u':
+			// u <XY> <sub> <m1> <m2> <m3> <mW> <h1> <h2> <h3> <path>
+			fields := strings.SplitN(entry, " ", 11)
+			if len(fields) != 11 {
+				return nil, fmt.Errorf("invalid status entry: %s", entry)
+			}
+			result = append(result, newConflictedFileStatus(fields[1][:1], fields[1][1:], fields[10]))
+		case '?':
+			// ? <path>
+			result = append(result, NewFileStatus("?", "?", strings.TrimPrefix(entry, "? "), ""))
+		default:
+			// ignored(!) やヘッダ(#)は対象外
+			continue
+		}
+	}
+
+	slog.Debug("get file statuses from git", "fileStatuses", result)
+	return result, nil
+}
+
+// シェルで安全に扱えるようにシングルクォートで囲む
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
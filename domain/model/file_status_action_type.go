@@ -0,0 +1,137 @@
+package model
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+type FileStatusActionTypeMap struct {
+	Stage   ActionType
+	Unstage ActionType
+	Restore ActionType
+	Discard ActionType
+	Diff    ActionType
+	Ignore  ActionType
+	Unknown ActionType
+	// 設定ファイルで定義されたアクション
+	Custom []ActionType
+}
+
+var FileStatusActionTypes = FileStatusActionTypeMap{
+	Stage: ActionType{
+		Name:     "stage",
+		Command:  "git",
+		Options:  []string{"add", "--"},
+		Help:     "Add the selected files to the index",
+		Multiple: true,
+	},
+	Unstage: ActionType{
+		Name:     "unstage",
+		Command:  "git",
+		Options:  []string{"restore", "--staged", "--"},
+		Help:     "Remove the selected files from the index (keep changes in the working tree)",
+		Multiple: true,
+		// 追跡していないファイルやステージされていないファイルを含むと pathspec が一致せずに失敗する
+		Conditions: []ActionCondition{ConditionStagedFile},
+	},
+	Restore: ActionType{
+		Name:        "restore",
+		Command:     "git",
+		Options:     []string{"restore", "--"},
+		Help:        "Restore the working tree files from the index (discard unstaged changes)",
+		Multiple:    true,
+		Destructive: true,
+		Snapshot:    true,
+		Conditions:  []ActionCondition{ConditionUnstagedFile},
+	},
+	Discard: ActionType{
+		Name:        "discard",
+		Command:     "git",
+		Options:     []string{"restore", "--source=HEAD", "--staged", "--worktree", "--"},
+		Help:        "Discard both staged and unstaged changes of tracked files",
+		Multiple:    true,
+		Destructive: true,
+		Snapshot:    true,
+		Conditions:  []ActionCondition{ConditionTrackedFile},
+	},
+	Diff: ActionType{
+		Name:     "diff",
+		Command:  "git",
+		Options:  []string{"diff", "HEAD", "--"},
+		Help:     "Show changes of the selected files against HEAD",
+		Multiple: true,
+	},
+	Ignore: ActionType{
+		Name:    "add to .gitignore",
+		Command: "sh",
+		// 既存の .gitignore が改行で終わっていない場合は、最後の行とつながらないように改行を追加してから追記する
+		Options:  []string{"-c", `if [ -s .gitignore ] && [ -n "$(tail -c 1 .gitignore)" ]; then echo >> .gitignore; fi; printf '%s\n' "$@" >> .gitignore`, "sh"},
+		Help:     "Append the selected files to .gitignore at the repository root",
+		Multiple: true,
+		// 追跡しているファイルは .gitignore に追加しても無視されない
+		Conditions: []ActionCondition{ConditionUntrackedFile},
+	},
+	Unknown: ActionType{
+		Name:    "unknown",
+		Command: "unknown",
+		Options: nil,
+		Help:    "unknown",
+	},
+}
+
+func (f FileStatusActionTypeMap) All() []ActionType {
+	builtins := []ActionType{
+		f.Stage,
+		f.Unstage,
+		f.Diff,
+		f.Restore,
+		f.Discard,
+		f.Ignore,
+	}
+	return mergeCustomActionTypes(builtins, f.Custom)
+}
+
+func (f FileStatusActionTypeMap) GetFileStatusActionTypes(action string) (ActionType, error) {
+	// 組み込みのアクションと同じ名前の独自のアクションは、組み込みのアクションを置き換える
+	if custom, ok := findCustomActionType(f.Custom, action); ok {
+		return custom, nil
+	}
+
+	switch action {
+	case "stage":
+		return f.Stage, nil
+	case "unstage":
+		return f.Unstage, nil
+	case "restore":
+		return f.Restore, nil
+	case "discard":
+		return f.Discard, nil
+	case "diff":
+		return f.Diff, nil
+	case "add to .gitignore":
+		return f.Ignore, nil
+	default:
+		return f.Unknown, fmt.Errorf("unknown action: %s", action)
+	}
+}
+
+func ParseSelectedFileStatusActionType(selectedLine string) (ActionType, error) {
+	slog.Debug("Selected action from fzf", "selected", selectedLine)
+	if selectedLine == "" {
+		slog.Debug("No action selected")
+		return FileStatusActionTypes.Unknown, nil
+	}
+
+	// タブで分割
+	fields := strings.Split(selectedLine, "\t")
+
+	// 最初のフィールドだけ取得
+	selectedActionType := fields[0]
+
+	result, err := FileStatusActionTypes.GetFileStatusActionTypes(selectedActionType)
+	if err != nil {
+		return FileStatusActionTypes.Unknown, err
+	}
+	return result, nil
+}
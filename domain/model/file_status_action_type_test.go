@@ -0,0 +1,115 @@
+package model
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestFileStatusActionTypeMap_GetFileStatusActionTypes(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		action  string
+		want    ActionType
+		wantErr bool
+	}{
+		{name: "対応するファイルアクション(stage)を取得すること", action: "stage", want: FileStatusActionTypes.Stage},
+		{name: "対応するファイルアクション(unstage)を取得すること", action: "unstage", want: FileStatusActionTypes.Unstage},
+		{name: "対応するファイルアクション(restore)を取得すること", action: "restore", want: FileStatusActionTypes.Restore},
+		{name: "対応するファイルアクション(discard)を取得すること", action: "discard", want: FileStatusActionTypes.Discard},
+		{name: "対応するファイルアクション(diff)を取得すること", action: "diff", want: FileStatusActionTypes.Diff},
+		{name: "対応するファイルアクション(add to .gitignore)を取得すること", action: "add to .gitignore", want: FileStatusActionTypes.Ignore},
+		{name: "不明なアクションが指定された場合、errorを返却すること", action: "dummy", want: FileStatusActionTypes.Unknown, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := FileStatusActionTypes.GetFileStatusActionTypes(tt.action)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FileStatusActionTypeMap.GetFileStatusActionTypes() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FileStatusActionTypeMap.GetFileStatusActionTypes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSelectedFileStatusActionType(t *testing.T) {
+	t.Parallel()
+	got, err := ParseSelectedFileStatusActionType("stage\tDescription : hogehoge\tCommand     : fugafuga\n")
+	if err != nil || !reflect.DeepEqual(got, FileStatusActionTypes.Stage) {
+		t.Errorf("ParseSelectedFileStatusActionType() = %v, %v, want %v", got, err, FileStatusActionTypes.Stage)
+	}
+}
+
+func TestFileStatusActionTypes_Ignore(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		gitignore string
+		patterns  []string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: ".gitignoreがない場合は作成して追記すること",
+			args: args{
+				gitignore: "",
+				patterns:  []string{"/new.go", "/tmp/"},
+			},
+			want: "/new.go\n/tmp/\n",
+		},
+		{
+			name: ".gitignoreが改行で終わっている場合はそのまま追記すること",
+			args: args{
+				gitignore: "/bin\n",
+				patterns:  []string{"/new.go"},
+			},
+			want: "/bin\n/new.go\n",
+		},
+		{
+			name: ".gitignoreが改行で終わっていない場合は改行を追加してから追記すること",
+			args: args{
+				gitignore: "/bin",
+				patterns:  []string{"/new.go"},
+			},
+			want: "/bin\n/new.go\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			path := filepath.Join(dir, ".gitignore")
+			if tt.args.gitignore != "" {
+				if err := os.WriteFile(path, []byte(tt.args.gitignore), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			actionType := FileStatusActionTypes.Ignore
+			cmd := exec.Command(actionType.Command, append(actionType.Options, tt.args.patterns...)...)
+			cmd.Dir = dir
+			if out, err := cmd.CombinedOutput(); err != nil {
+				t.Fatalf("failed to run %s: %v: %s", actionType.Name, err, out)
+			}
+
+			got, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf(".gitignore = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
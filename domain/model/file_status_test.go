@@ -0,0 +1,326 @@
+package model
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestParseFileStatuses(t *testing.T) {
+	t.Parallel()
+	conflicted := NewFileStatus("U", "U", "conflict.go", "")
+	conflicted.Staged = false
+	conflicted.Unstaged = false
+	conflicted.Conflicted = true
+
+	tests := []struct {
+		name    string
+		status  string
+		want    []*FileStatus
+		wantErr bool
+	}{
+		{
+			name: "git status --porcelain=v2 -z の出力をパースできること",
+			status: "1 M. N... 100644 100644 100644 aaaaaaa bbbbbbb staged.go\x00" +
+				"1 .M N... 100644 100644 100644 aaaaaaa aaaaaaa with space.go\x00" +
+				"1 MM N... 100644 100644 100644 aaaaaaa bbbbbbb partial.go\x00" +
+				"2 RM N... 100644 100644 100644 aaaaaaa aaaaaaa R100 new.go\x00old.go\x00" +
+				"u UU N... 100644 100644 100644 100644 aaaaaaa bbbbbbb ccccccc conflict.go\x00" +
+				"? sub/untracked.go\x00" +
+				"! ignored.log\x00",
+			want: []*FileStatus{
+				{Path: "staged.go", IndexStatus: "M", WorktreeStatus: ".", Staged: true},
+				{Path: "with space.go", IndexStatus: ".", WorktreeStatus: "M", Unstaged: true},
+				{Path: "partial.go", IndexStatus: "M", WorktreeStatus: "M", Staged: true, Unstaged: true},
+				{Path: "new.go", OrigPath: "old.go", IndexStatus: "R", WorktreeStatus: "M", Staged: true, Unstaged: true},
+				conflicted,
+				{Path: "sub/untracked.go", IndexStatus: "?", WorktreeStatus: "?", Untracked: true},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "変更がない場合はnilを返すこと",
+			status:  "",
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name:    "不正なエントリの場合はエラーを返すこと",
+			status:  "1 M. N...\x00",
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseFileStatuses(tt.status)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseFileStatuses() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseFileStatuses() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileStatus_GetPreviewCommand(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		fileStatus *FileStatus
+		want       string
+	}{
+		{
+			name:       "作業ツリーに変更があるファイルはインデックスとの差分を表示すること",
+			fileStatus: NewFileStatus("M", "M", "main.go", ""),
+			want:       "git diff --color=always -- 'main.go'",
+		},
+		{
+			name:       "ステージ済みのみのファイルはHEADとの差分を表示すること",
+			fileStatus: NewFileStatus("M", ".", "it's.go", ""),
+			want:       `git diff --color=always --cached -- 'it'\''s.go'`,
+		},
+		{
+			name:       "リネームされたファイルは元のパスを含めて差分を表示すること",
+			fileStatus: NewFileStatus("R", ".", "new.go", "old.go"),
+			want:       "git diff --color=always --cached -M -- 'old.go' 'new.go'",
+		},
+		{
+			name:       "未追跡のファイルはファイル全体を差分として表示すること",
+			fileStatus: NewFileStatus("?", "?", "new.go", ""),
+			want:       "git diff --color=always --no-index -- /dev/null 'new.go'",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.fileStatus.GetPreviewCommand(); got != tt.want {
+				t.Errorf("FileStatus.GetPreviewCommand() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileStatus_GetFzfLine(t *testing.T) {
+	t.Parallel()
+	fileStatus := NewFileStatus("R", ".", "new.go", "old.go")
+	want := "R. staged     new.go <- old.go\tnew.go\tgit diff --color=always --cached -M -- 'old.go' 'new.go'"
+	if got := fileStatus.GetFzfLine(); got != want {
+		t.Errorf("FileStatus.GetFzfLine() = %q, want %q", got, want)
+	}
+	if got := ParseSelectedFileStatusPath(want); got != "new.go" {
+		t.Errorf("ParseSelectedFileStatusPath() = %q, want %q", got, "new.go")
+	}
+}
+
+func TestFindFileStatusByPath(t *testing.T) {
+	t.Parallel()
+	files := []*FileStatus{
+		NewFileStatus("M", ".", "a.go", ""),
+		NewFileStatus(".", "M", "b.go", ""),
+	}
+
+	got, err := FindFileStatusByPath(files, "b.go")
+	if err != nil || !reflect.DeepEqual(got, files[1]) {
+		t.Errorf("FindFileStatusByPath() = %v, %v, want %v", got, err, files[1])
+	}
+
+	_, err = FindFileStatusByPath(files, "dummy.go")
+	if want := fmt.Errorf("file %s not found", "dummy.go"); err == nil || err.Error() != want.Error() {
+		t.Errorf("FindFileStatusByPath() error = %v, want %v", err, want)
+	}
+}
+
+func TestFileStatuses_GetFullCommand(t *testing.T) {
+	t.Parallel()
+	files := FileStatuses{
+		NewFileStatus(".", "M", "a.go", ""),
+		NewFileStatus("?", "?", "b.go", ""),
+	}
+	tests := []struct {
+		name       string
+		actionType ActionType
+		want       string
+	}{
+		{
+			name:       "選択した全てのファイルを引数に含めること",
+			actionType: FileStatusActionTypes.Stage,
+			want:       "git add -- a.go b.go",
+		},
+		{
+			name:       "ステージの取り消しでは--stagedを指定すること",
+			actionType: FileStatusActionTypes.Unstage,
+			want:       "git restore --staged -- a.go b.go",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := files.GetFullCommand(tt.actionType); got != tt.want {
+				t.Errorf("FileStatuses.GetFullCommand() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileStatuses_GetOptionsWithPaths(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		actionType ActionType
+	}
+	tests := []struct {
+		name  string
+		files FileStatuses
+		args  args
+		want  []string
+	}{
+		{
+			name: "名前を変更したファイルを破棄する場合は変更前のパスも指定すること",
+			files: FileStatuses{
+				NewFileStatus("R", ".", "new.go", "old.go"),
+				NewFileStatus("M", ".", "a.go", ""),
+			},
+			args: args{
+				actionType: FileStatusActionTypes.Discard,
+			},
+			want: []string{"restore", "--source=HEAD", "--staged", "--worktree", "--", "new.go", "old.go", "a.go"},
+		},
+		{
+			name: "名前を変更したファイルのステージを取り消す場合は変更前のパスも指定すること",
+			files: FileStatuses{
+				NewFileStatus("R", ".", "new.go", "old.go"),
+			},
+			args: args{
+				actionType: FileStatusActionTypes.Unstage,
+			},
+			want: []string{"restore", "--staged", "--", "new.go", "old.go"},
+		},
+		{
+			name: "名前を変更したファイルをステージする場合は変更後のパスだけを指定すること",
+			files: FileStatuses{
+				NewFileStatus("R", "M", "new.go", "old.go"),
+			},
+			args: args{
+				actionType: FileStatusActionTypes.Stage,
+			},
+			want: []string{"add", "--", "new.go"},
+		},
+		{
+			name: ".gitignoreに追加する場合はルートからのパターンとして特殊文字をエスケープすること",
+			files: FileStatuses{
+				NewFileStatus("?", "?", "#notes.txt", ""),
+				NewFileStatus("?", "?", "!important", ""),
+				NewFileStatus("?", "?", "data/[1]*?.csv", ""),
+				NewFileStatus("?", "?", `back\slash`, ""),
+				NewFileStatus("?", "?", "trailing  ", ""),
+			},
+			args: args{
+				actionType: FileStatusActionTypes.Ignore,
+			},
+			want: []string{
+				"-c", `if [ -s .gitignore ] && [ -n "$(tail -c 1 .gitignore)" ]; then echo >> .gitignore; fi; printf '%s\n' "$@" >> .gitignore`, "sh",
+				`/\#notes.txt`,
+				`/\!important`,
+				`/data/\[1]\*\?.csv`,
+				`/back\\slash`,
+				`/trailing\ \ `,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.files.GetOptionsWithPaths(tt.args.actionType); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FileStatuses.GetOptionsWithPaths() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileStatuses_GetAvailableActionTypes(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		files FileStatuses
+		state RepoState
+	}
+	tests := []struct {
+		name string
+		args args
+		want []ActionType
+	}{
+		{
+			name: "追跡していないファイルは追加と差分と.gitignoreへの追加だけができること",
+			args: args{
+				files: FileStatuses{
+					{Path: "tmp.txt", IndexStatus: "?", WorktreeStatus: "?", Untracked: true},
+				},
+				state: RepoState{},
+			},
+			want: []ActionType{
+				FileStatusActionTypes.Stage,
+				FileStatusActionTypes.Diff,
+				FileStatusActionTypes.Ignore,
+			},
+		},
+		{
+			name: "ステージされた変更だけがあるファイルは作業ツリーから元に戻せないこと",
+			args: args{
+				files: FileStatuses{
+					{Path: "main.go", IndexStatus: "M", WorktreeStatus: ".", Staged: true},
+				},
+				state: RepoState{Dirty: true, StagedChanges: true},
+			},
+			want: []ActionType{
+				FileStatusActionTypes.Stage,
+				FileStatusActionTypes.Unstage,
+				FileStatusActionTypes.Diff,
+				FileStatusActionTypes.Discard,
+			},
+		},
+		{
+			name: "ステージされていない変更だけがあるファイルはステージを取り消せないこと",
+			args: args{
+				files: FileStatuses{
+					{Path: "README.md", IndexStatus: ".", WorktreeStatus: "M", Unstaged: true},
+				},
+				state: RepoState{Dirty: true},
+			},
+			want: []ActionType{
+				FileStatusActionTypes.Stage,
+				FileStatusActionTypes.Diff,
+				FileStatusActionTypes.Restore,
+				FileStatusActionTypes.Discard,
+			},
+		},
+		{
+			name: "追跡しているファイルと追跡していないファイルを選択した場合は全てのファイルに実行できるアクションだけを返すこと",
+			args: args{
+				files: FileStatuses{
+					{Path: "main.go", IndexStatus: "M", WorktreeStatus: "M", Staged: true, Unstaged: true},
+					{Path: "tmp.txt", IndexStatus: "?", WorktreeStatus: "?", Untracked: true},
+				},
+				state: RepoState{Dirty: true, StagedChanges: true},
+			},
+			want: []ActionType{
+				FileStatusActionTypes.Stage,
+				FileStatusActionTypes.Diff,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.args.files.GetAvailableActionTypes(tt.args.state); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FileStatuses.GetAvailableActionTypes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
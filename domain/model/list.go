@@ -0,0 +1,107 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// --list で出力する形式
+type ListFormat string
+
+const (
+	ListFormatJSON ListFormat = "json"
+	ListFormatTSV  ListFormat = "tsv"
+)
+
+func ParseListFormat(format string) (ListFormat, error) {
+	switch ListFormat(format) {
+	case ListFormatJSON, ListFormatTSV:
+		return ListFormat(format), nil
+	default:
+		return "", fmt.Errorf("unknown list format: %s (json or tsv)", format)
+	}
+}
+
+// --list で出力する1項目のフィールド
+type ListField struct {
+	Key   string
+	Value any
+}
+
+// --list で出力する1項目 (フィールドの順番を保ったまま出力する)
+type ListItem []ListField
+
+// フィールドの順番を保ったままJSONのオブジェクトに変換する
+func (li ListItem) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("{")
+	for i, field := range li {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		key, err := json.Marshal(field.Key)
+		if err != nil {
+			return nil, err
+		}
+		fieldValue := field.Value
+		// 空の一覧は null ではなく空の配列として出力する
+		if v, ok := fieldValue.([]string); ok && v == nil {
+			fieldValue = []string{}
+		}
+		value, err := json.Marshal(fieldValue)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteString(":")
+		buf.Write(value)
+	}
+	buf.WriteString("}")
+	return buf.Bytes(), nil
+}
+
+// TSVの1行に変換する (値に含まれるタブと改行は空白に置き換える)
+// 一覧の値 (例: parents, refs) はカンマ区切りで1列にまとめ、空の場合は空文字にする
+func (li ListItem) GetTsvLine() string {
+	values := make([]string, 0, len(li))
+	for _, field := range li {
+		var value string
+		switch v := field.Value.(type) {
+		case nil:
+			value = ""
+		case []string:
+			value = strings.Join(v, ",")
+		default:
+			value = fmt.Sprint(v)
+		}
+		values = append(values, strings.NewReplacer("\t", " ", "\n", " ").Replace(value))
+	}
+	return strings.Join(values, "\t")
+}
+
+// 項目を指定した形式の文字列に変換する
+// tsv の場合はヘッダーを出力せず、各モデルの GetListItem と同じ順番で列を並べる
+// 列の位置で値を読むスクリプトを壊さないように、GetListItem に項目を追加する場合は必ず末尾に追加する
+func FormatList(items []ListItem, format ListFormat) (string, error) {
+	switch format {
+	case ListFormatJSON:
+		if items == nil {
+			items = []ListItem{}
+		}
+		b, err := json.MarshalIndent(items, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal list: %w", err)
+		}
+		return string(b) + "\n", nil
+	case ListFormatTSV:
+		var buf strings.Builder
+		for _, item := range items {
+			buf.WriteString(item.GetTsvLine() + "\n")
+		}
+		return buf.String(), nil
+	default:
+		return "", fmt.Errorf("unknown list format: %s (json or tsv)", format)
+	}
+}
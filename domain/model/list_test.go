@@ -0,0 +1,191 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFormatList(t *testing.T) {
+	t.Parallel()
+
+	items := []ListItem{
+		{
+			{Key: "name", Value: "main"},
+			{Key: "current", Value: true},
+			{Key: "last_commit_id", Value: "1a2b3c4"},
+			{Key: "last_commit_message", Value: "first commit"},
+		},
+		{
+			{Key: "name", Value: "feature/a"},
+			{Key: "current", Value: false},
+			{Key: "last_commit_id", Value: "5d6e7f8"},
+			{Key: "last_commit_message", Value: "add\ttab"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		items   []ListItem
+		format  ListFormat
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "jsonの場合はフィールドの順番を保ったオブジェクトの配列を返すこと",
+			items:  items,
+			format: ListFormatJSON,
+			want: `[
+  {
+    "name": "main",
+    "current": true,
+    "last_commit_id": "1a2b3c4",
+    "last_commit_message": "first commit"
+  },
+  {
+    "name": "feature/a",
+    "current": false,
+    "last_commit_id": "5d6e7f8",
+    "last_commit_message": "add\ttab"
+  }
+]
+`,
+		},
+		{
+			name:   "tsvの場合は値に含まれるタブを空白に置き換えて1行ずつ返すこと",
+			items:  items,
+			format: ListFormatTSV,
+			want:   "main\ttrue\t1a2b3c4\tfirst commit\nfeature/a\tfalse\t5d6e7f8\tadd tab\n",
+		},
+		{
+			name: "一覧の値はtsvではカンマ区切りにし、空の場合は空文字にすること",
+			items: []ListItem{
+				{
+					{Key: "id", Value: "1a2b3c4"},
+					{Key: "parents", Value: []string{"5d6e7f8", "9a0b1c2"}},
+					{Key: "refs", Value: []string(nil)},
+				},
+			},
+			format: ListFormatTSV,
+			want:   "1a2b3c4\t5d6e7f8,9a0b1c2\t\n",
+		},
+		{
+			name: "一覧の値が空の場合のjsonはnullではなく空の配列を返すこと",
+			items: []ListItem{
+				{
+					{Key: "id", Value: "1a2b3c4"},
+					{Key: "refs", Value: []string(nil)},
+				},
+			},
+			format: ListFormatJSON,
+			want: `[
+  {
+    "id": "1a2b3c4",
+    "refs": []
+  }
+]
+`,
+		},
+		{
+			name:   "項目がない場合のjsonは空の配列を返すこと",
+			items:  nil,
+			format: ListFormatJSON,
+			want:   "[]\n",
+		},
+		{
+			name:    "不明な形式の場合はエラーを返すこと",
+			items:   items,
+			format:  ListFormat("csv"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := FormatList(tt.items, tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FormatList() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("FormatList() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseListFormat(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		format  string
+		want    ListFormat
+		wantErr bool
+	}{
+		{name: "jsonを指定できること", format: "json", want: ListFormatJSON},
+		{name: "tsvを指定できること", format: "tsv", want: ListFormatTSV},
+		{name: "不明な形式の場合はエラーを返すこと", format: "yaml", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseListFormat(tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseListFormat() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseListFormat() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetListItem_Keys(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		item ListItem
+	}
+	tests := []struct {
+		name string
+		args args
+		want []string
+	}{
+		{
+			name: "ブランチの列は最初からある列の後に追加した列が並ぶこと",
+			args: args{
+				item: Branch{}.GetListItem(),
+			},
+			want: []string{"name", "current", "last_commit_id", "last_commit_message", "full_ref", "remote", "remote_name", "upstream", "ahead", "behind", "upstream_gone", "last_commit_date", "last_commit_author"},
+		},
+		{
+			name: "コミットの列は最初からある列の後に追加した列が並ぶこと",
+			args: args{
+				item: Commit{}.GetListItem(),
+			},
+			want: []string{"id", "message", "full_id", "parents", "author", "author_date", "committer_date", "refs"},
+		},
+		{
+			name: "reflogの列は最初からある列の後に追加した列が並ぶこと",
+			args: args{
+				item: Reflog{}.GetListItem(),
+			},
+			want: []string{"id", "head_point", "message", "ref", "timestamp"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var got []string
+			for _, field := range tt.args.item {
+				got = append(got, field.Key)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetListItem() keys = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
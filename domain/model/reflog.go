@@ -3,24 +3,34 @@ package model
 import (
 	"fmt"
 	"log/slog"
-	"regexp"
+	"strconv"
 	"strings"
+	"time"
 )
 
+// git reflog show -z で取得する reflog の出力形式 (フィールドはユニットセパレータ区切り、エントリはNUL区切り)
+// 短縮ハッシュ, reflog のセレクタ (--date=iso-strict を指定して ref@{日時} の形式にする), reflog のメッセージ
+const ReflogFormat = "%h%x1f%gd%x1f%gs"
+
+const reflogFieldSeparator = "\x1f"
+
 type Reflog struct {
-	Id          string
-	HeadPoint   string
+	Id string
+	// reflog の対象の参照 (例: HEAD, main, stash)
+	Ref string
+	// 参照の何番目のエントリか (例: HEAD@{1}, main@{0})
+	HeadPoint string
+	// 参照が更新された日時
+	Timestamp   time.Time
 	Message     string
-	RawReflog   string
 	ActionTypes []ActionType
 }
 
-func NewReflog(id string, HeadPoint string, message string, rawReflog string) *Reflog {
+func NewReflog(id string, HeadPoint string, message string) *Reflog {
 	return &Reflog{
 		Id:          id,
 		HeadPoint:   HeadPoint,
 		Message:     message,
-		RawReflog:   rawReflog,
 		ActionTypes: ReflogActionTypes.All(),
 	}
 }
@@ -29,6 +39,29 @@ func (r Reflog) String() string {
 	return r.Id
 }
 
+// --list で出力する項目を返す
+func (r Reflog) GetListItem() ListItem {
+	return ListItem{
+		{Key: "id", Value: r.Id},
+		{Key: "head_point", Value: r.HeadPoint},
+		{Key: "message", Value: r.Message},
+		{Key: "ref", Value: r.Ref},
+		{Key: "timestamp", Value: formatCommitDate(r.Timestamp)},
+	}
+}
+
+// fzfの候補として表示する1行を返す
+// 先頭はコミットID (fzfのプレビューと選択結果のパースで使う)
+// 例: "1a2b3c4 main@{1} 2024-01-02 03:04:05 commit: add feature"
+func (r Reflog) GetFzfLine() string {
+	fields := []string{r.Id, r.HeadPoint}
+	if !r.Timestamp.IsZero() {
+		fields = append(fields, r.Timestamp.Format("2006-01-02 15:04:05"))
+	}
+	fields = append(fields, r.Message)
+	return strings.Join(fields, " ")
+}
+
 func FindReflogById(reflogs []*Reflog, id string) (*Reflog, error) {
 	for _, reflog := range reflogs {
 		if reflog.Id == id {
@@ -38,6 +71,15 @@ func FindReflogById(reflogs []*Reflog, id string) (*Reflog, error) {
 	return nil, fmt.Errorf("reflog not found: %s", id)
 }
 
+func FindReflogByHeadPoint(reflogs []*Reflog, headPoint string) (*Reflog, error) {
+	for _, reflog := range reflogs {
+		if reflog.HeadPoint == headPoint {
+			return reflog, nil
+		}
+	}
+	return nil, fmt.Errorf("reflog not found: %s", headPoint)
+}
+
 func (r Reflog) GetFullCommand(actionType ActionType) string {
 	options := r.GetOptionsWithReflogId(actionType)
 	onelineOptions := strings.Join(options, " ")
@@ -49,51 +91,146 @@ func (r Reflog) GetFullCommand(actionType ActionType) string {
 }
 
 func (r Reflog) GetOptionsWithReflogId(actionType ActionType) []string {
+	if actionType.Custom {
+		return actionType.GetCustomOptions(r)
+	}
+
 	ret := actionType.Options
 	ret = append(ret, r.Id)
 	return ret
 }
 
+// リポジトリの状態に応じて実行できるアクションだけを返す
+func (r Reflog) GetAvailableActionTypes(state RepoState) []ActionType {
+	return filterAvailableActionTypes(r.ActionTypes, state)
+}
+
+// 破壊的なアクションの確認で表示する、失われる可能性がある内容の対象を返す
+// reset --hard では未コミットの変更と、HEAD から辿れなくなる未プッシュのコミットが失われる
+func (r Reflog) GetDestructiveScope(actionType ActionType) DestructiveScope {
+	return defaultDestructiveScope("HEAD")
+}
+
 func (r Reflog) GetFzfInputForSelectActionType(actionType ActionType) string {
+	// ブランチ名はアクションの選択後に入力させるため、選択時はブランチ名を指定する位置を表示する
+	if actionType.IsEqual(ReflogActionTypes.CreateBranch) && len(actionType.Options) == len(ReflogActionTypes.CreateBranch.Options) {
+		actionType = actionType.WithOptions("<branch name>")
+	}
 	// fzfに渡す形式: "表示名\tフルコマンド\t説明文"
 	return fmt.Sprintf("%s\tDescription : %s\tCommand     : %s\n", actionType.Name, actionType.Help, r.GetFullCommand(actionType))
 }
 
-func ParseReflogs(reflogs string) ([]*Reflog, error) {
-	if strings.TrimSpace(reflogs) == "" {
-		return []*Reflog{}, nil
+// 複数選択された reflog をまとめて扱うための型
+type Reflogs []*Reflog
+
+// fzfで選択された ref@{n} を reflog の表示順 (新しい順) に並べ替えて返す
+func FindReflogsByHeadPoints(reflogs []*Reflog, headPoints []string) (Reflogs, error) {
+	selected := make(map[string]bool, len(headPoints))
+	for _, headPoint := range headPoints {
+		if _, err := FindReflogByHeadPoint(reflogs, headPoint); err != nil {
+			return nil, err
+		}
+		selected[headPoint] = true
 	}
 
-	lines := strings.Split(strings.TrimSpace(reflogs), "\n")
-	result := make([]*Reflog, 0, len(lines))
+	var ret Reflogs
+	for _, reflog := range reflogs {
+		if selected[reflog.HeadPoint] {
+			ret = append(ret, reflog)
+		}
+	}
+	return ret, nil
+}
 
-	// Regular expression to parse reflog entries
-	// Pattern: {hash} HEAD@{index}: {action}: {message}
-	// HEAD@{index} が並ぶようにあえて(origin/main, origin/HEAD, main) のような情報を含めない
-	reflogPattern := regexp.MustCompile(`^([a-f0-9]+)\s+(HEAD@\{[0-9]+\}):\s+([^:]+):\s*(.*)$`)
+func (rs Reflogs) GetFullCommand(actionType ActionType) string {
+	options := rs.GetOptionsWithReflogIds(actionType)
+	onelineOptions := strings.Join(options, " ")
+
+	fullCommand := fmt.Sprintf("%s %s", actionType.Command, onelineOptions)
+	slog.Debug("Command:", "Command", actionType.Name, "fullCommand", fullCommand)
+
+	return fullCommand
+}
 
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" {
+// cherry-pick は記録された順に適用するため古いエントリから並べる
+// 同じコミットが複数回 reflog に現れることがあるため、同じコミットは1度だけ指定する
+func (rs Reflogs) GetOptionsWithReflogIds(actionType ActionType) []string {
+	ret := actionType.Options
+	seen := make(map[string]bool, len(rs))
+	for i := len(rs) - 1; i >= 0; i-- {
+		if seen[rs[i].Id] {
 			continue
 		}
+		seen[rs[i].Id] = true
+		ret = append(ret, rs[i].Id)
+	}
+	return ret
+}
 
-		matches := reflogPattern.FindStringSubmatch(line)
-		if len(matches) != 5 {
-			// If the pattern doesn't match, skip this line or handle as needed
+// リポジトリの状態に応じて、複数の reflog に実行できるアクションだけを返す
+func (rs Reflogs) GetAvailableActionTypes(state RepoState) []ActionType {
+	return filterAvailableActionTypes(FilterMultipleActionTypes(ReflogActionTypes.All()), state)
+}
+
+// 破壊的なアクションの確認で表示する、失われる可能性がある内容の対象を返す
+func (rs Reflogs) GetDestructiveScope(actionType ActionType) DestructiveScope {
+	return defaultDestructiveScope("HEAD")
+}
+
+func (rs Reflogs) GetFzfInputForSelectActionType(actionType ActionType) string {
+	// fzfに渡す形式: "表示名\tフルコマンド\t説明文"
+	return fmt.Sprintf("%s\tDescription : %s\tCommand     : %s\n", actionType.Name, actionType.Help, rs.GetFullCommand(actionType))
+}
+
+// git reflog show -z --date=iso-strict --format=ReflogFormat の形式をパースして、Reflog構造体のスライスを返す
+func ParseReflogs(reflogs string) ([]*Reflog, error) {
+	result := []*Reflog{}
+
+	index := 0
+	for _, record := range strings.Split(reflogs, "\x00") {
+		record = strings.Trim(record, "\n")
+		if record == "" {
 			continue
 		}
 
-		slog.Debug("matches", "matches", matches)
+		fields := strings.SplitN(record, reflogFieldSeparator, 3)
+		if len(fields) != 3 {
+			// 想定外の形式のエントリはスキップ
+			slog.Debug("skip invalid reflog record", "record", record)
+			continue
+		}
 
-		id := matches[1]
-		headPoint := matches[2]
-		message := strings.TrimSpace(matches[4])
+		ref, selector, err := parseReflogSelector(fields[1])
+		if err != nil {
+			return nil, err
+		}
 
-		reflog := NewReflog(id, headPoint, message, line)
+		reflog := NewReflog(fields[0], "", fields[2])
+		reflog.Ref = ref
+		// @{n} の形式の場合はその番号を、@{日時} の形式の場合は新しいものからの順番を使う
+		if n, err := strconv.Atoi(selector); err == nil {
+			index = n
+		} else {
+			timestamp, err := time.Parse(time.RFC3339, selector)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse reflog selector %s: %w", fields[1], err)
+			}
+			reflog.Timestamp = timestamp
+		}
+		reflog.HeadPoint = fmt.Sprintf("%s@{%d}", ref, index)
+		index++
 
 		result = append(result, reflog)
 	}
 
 	return result, nil
 }
+
+// reflog のセレクタ (例: HEAD@{1}, main@{2024-01-02T03:04:05+09:00}) を参照名と @{} の中身に分割する
+func parseReflogSelector(selector string) (string, string, error) {
+	i := strings.LastIndex(selector, "@{")
+	if i < 0 || !strings.HasSuffix(selector, "}") {
+		return "", "", fmt.Errorf("invalid reflog selector: %s", selector)
+	}
+	return selector[:i], selector[i+2 : len(selector)-1], nil
+}
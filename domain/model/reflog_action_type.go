@@ -7,16 +7,68 @@ import (
 )
 
 type ReflogActionTypeMap struct {
-	ResetHard ActionType
-	Unknown   ActionType
+	CreateBranch ActionType
+	Diff         ActionType
+	Checkout     ActionType
+	CherryPick   ActionType
+	ResetSoft    ActionType
+	ResetMixed   ActionType
+	ResetHard    ActionType
+	Unknown      ActionType
+	// 設定ファイルで定義されたアクション
+	Custom []ActionType
 }
 
 var ReflogActionTypes = ReflogActionTypeMap{
-	ResetHard: ActionType{
-		Name:    "reset hard",
+	CreateBranch: ActionType{
+		Name:    "create branch",
+		Command: "git",
+		// ブランチ名は実行時に入力させて末尾に追加する
+		Options: []string{"branch"},
+		Help:    "Create a new branch at the selected entry (without switching to it)",
+	},
+	Diff: ActionType{
+		Name:    "diff",
 		Command: "git",
-		Options: []string{"reset", "--hard"},
-		Help:    "Hard reset to selected commit",
+		Options: []string{"diff"},
+		Help:    "Show changes between the selected entry and the working tree",
+	},
+	Checkout: ActionType{
+		Name:       "checkout",
+		Command:    "git",
+		Options:    []string{"checkout", "--detach"},
+		Help:       "Check out the selected entry as a detached HEAD",
+		Conditions: []ActionCondition{ConditionNoOperationInProgress},
+	},
+	CherryPick: ActionType{
+		Name:       "cherry-pick",
+		Command:    "git",
+		Options:    []string{"cherry-pick"},
+		Help:       "Apply the changes of the selected entries on top of the current branch (oldest first)",
+		Multiple:   true,
+		Conditions: []ActionCondition{ConditionNoOperationInProgress},
+	},
+	ResetSoft: ActionType{
+		Name:     "reset soft",
+		Command:  "git",
+		Options:  []string{"reset", "--soft"},
+		Help:     "Soft reset to selected commit (keep the index and the working tree)",
+		Snapshot: true,
+	},
+	ResetMixed: ActionType{
+		Name:     "reset mixed",
+		Command:  "git",
+		Options:  []string{"reset", "--mixed"},
+		Help:     "Mixed reset to selected commit (keep the working tree and unstage the changes)",
+		Snapshot: true,
+	},
+	ResetHard: ActionType{
+		Name:        "reset hard",
+		Command:     "git",
+		Options:     []string{"reset", "--hard"},
+		Help:        "Hard reset to selected commit",
+		Destructive: true,
+		Snapshot:    true,
 	},
 	Unknown: ActionType{
 		Name:    "unknown",
@@ -27,13 +79,38 @@ var ReflogActionTypes = ReflogActionTypeMap{
 }
 
 func (r ReflogActionTypeMap) All() []ActionType {
-	return []ActionType{
+	// 安全なアクションから順に並べる
+	builtins := []ActionType{
+		r.CreateBranch,
+		r.Diff,
+		r.Checkout,
+		r.CherryPick,
+		r.ResetSoft,
+		r.ResetMixed,
 		r.ResetHard,
 	}
+	return mergeCustomActionTypes(builtins, r.Custom)
 }
 
 func (r ReflogActionTypeMap) GetReflogActionTypes(action string) (ActionType, error) {
+	// 組み込みのアクションと同じ名前の独自のアクションは、組み込みのアクションを置き換える
+	if custom, ok := findCustomActionType(r.Custom, action); ok {
+		return custom, nil
+	}
+
 	switch action {
+	case "create branch":
+		return r.CreateBranch, nil
+	case "diff":
+		return r.Diff, nil
+	case "checkout":
+		return r.Checkout, nil
+	case "cherry-pick":
+		return r.CherryPick, nil
+	case "reset soft":
+		return r.ResetSoft, nil
+	case "reset mixed":
+		return r.ResetMixed, nil
 	case "reset hard":
 		return r.ResetHard, nil
 	default:
@@ -8,8 +8,14 @@ import (
 
 func TestReflogActionTypeMap_All(t *testing.T) {
 	type fields struct {
-		ResetHard ActionType
-		Unknown   ActionType
+		CreateBranch ActionType
+		Diff         ActionType
+		Checkout     ActionType
+		CherryPick   ActionType
+		ResetSoft    ActionType
+		ResetMixed   ActionType
+		ResetHard    ActionType
+		Unknown      ActionType
 	}
 	tests := []struct {
 		name   string
@@ -19,9 +25,21 @@ func TestReflogActionTypeMap_All(t *testing.T) {
 		{
 			name: "全てのReflogアクションを取得すること",
 			fields: fields{
-				ResetHard: ReflogActionTypes.ResetHard,
+				CreateBranch: ReflogActionTypes.CreateBranch,
+				Diff:         ReflogActionTypes.Diff,
+				Checkout:     ReflogActionTypes.Checkout,
+				CherryPick:   ReflogActionTypes.CherryPick,
+				ResetSoft:    ReflogActionTypes.ResetSoft,
+				ResetMixed:   ReflogActionTypes.ResetMixed,
+				ResetHard:    ReflogActionTypes.ResetHard,
 			},
 			want: []ActionType{
+				ReflogActionTypes.CreateBranch,
+				ReflogActionTypes.Diff,
+				ReflogActionTypes.Checkout,
+				ReflogActionTypes.CherryPick,
+				ReflogActionTypes.ResetSoft,
+				ReflogActionTypes.ResetMixed,
 				ReflogActionTypes.ResetHard,
 			},
 		},
@@ -29,8 +47,14 @@ func TestReflogActionTypeMap_All(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := ReflogActionTypeMap{
-				ResetHard: tt.fields.ResetHard,
-				Unknown:   tt.fields.Unknown,
+				CreateBranch: tt.fields.CreateBranch,
+				Diff:         tt.fields.Diff,
+				Checkout:     tt.fields.Checkout,
+				CherryPick:   tt.fields.CherryPick,
+				ResetSoft:    tt.fields.ResetSoft,
+				ResetMixed:   tt.fields.ResetMixed,
+				ResetHard:    tt.fields.ResetHard,
+				Unknown:      tt.fields.Unknown,
 			}
 			if got := r.All(); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("ReflogActionTypeMap.All() = %v, want %v", got, tt.want)
@@ -59,6 +83,15 @@ func TestParseSelectedReflogActionType(t *testing.T) {
 			wantErr:        false,
 			wantErrMessage: nil,
 		},
+		{
+			name: "fzfの選択結果を元に、ブランチを作成するアクションを取得すること",
+			args: args{
+				selectedLine: "create branch\tDescription : hogehoge\tCommand     : fugafuga\n",
+			},
+			want:           ReflogActionTypes.CreateBranch,
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
 		{
 			name: "不明な文字列が指定された場合、Unknownを返却すること",
 			args: args{
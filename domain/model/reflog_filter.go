@@ -0,0 +1,24 @@
+package model
+
+// git reflog で表示するエントリの絞り込み条件
+type ReflogFilter struct {
+	// reflog の対象の参照 (空の場合は HEAD)
+	Ref string
+	// 日付は git reflog がそのまま解釈する (例: "2 weeks ago", "2024-01-01")
+	Since string
+}
+
+// git reflog show に渡すオプションを返す
+func (rf ReflogFilter) GetReflogOptions() []string {
+	var ret []string
+	if rf.Since != "" {
+		ret = append(ret, "--since="+rf.Since)
+	}
+
+	ref := rf.Ref
+	if ref == "" {
+		ref = "HEAD"
+	}
+	// 参照名をオプションやパスと区別するため "--" を付ける
+	return append(ret, ref, "--")
+}
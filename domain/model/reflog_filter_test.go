@@ -0,0 +1,35 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReflogFilter_GetReflogOptions(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		filter ReflogFilter
+		want   []string
+	}{
+		{
+			name:   "参照と日付をgit reflog showのオプションに変換できること",
+			filter: ReflogFilter{Ref: "refs/stash", Since: "2 weeks ago"},
+			want:   []string{"--since=2 weeks ago", "refs/stash", "--"},
+		},
+		{
+			name:   "参照がない場合はHEADのreflogを表示すること",
+			filter: ReflogFilter{},
+			want:   []string{"HEAD", "--"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.filter.GetReflogOptions(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReflogFilter.GetReflogOptions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
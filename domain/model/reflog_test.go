@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestFindReflogById(t *testing.T) {
@@ -23,13 +24,13 @@ func TestFindReflogById(t *testing.T) {
 			name: "reflogのIDを指定して対象のreflogが取得できること",
 			args: args{
 				reflogs: []*Reflog{
-					NewReflog("5d5d5d", "HEAD@{0}", "test message", "5d5d5d HEAD@{0}: test action: test message"),
-					NewReflog("1x1x1x", "HEAD@{1}", "test message", "5d5d5d HEAD@{1}: test action: test message"),
-					NewReflog("2x2x2x", "HEAD@{2}", "test message", "5d5d5d HEAD@{2}: test action: test message"),
+					NewReflog("5d5d5d", "HEAD@{0}", "test message"),
+					NewReflog("1x1x1x", "HEAD@{1}", "test message"),
+					NewReflog("2x2x2x", "HEAD@{2}", "test message"),
 				},
 				id: "2x2x2x",
 			},
-			want:           NewReflog("2x2x2x", "HEAD@{2}", "test message", "5d5d5d HEAD@{2}: test action: test message"),
+			want:           NewReflog("2x2x2x", "HEAD@{2}", "test message"),
 			wantErr:        false,
 			wantErrMessage: nil,
 		},
@@ -37,9 +38,9 @@ func TestFindReflogById(t *testing.T) {
 			name: "存在しないreflogIDを指定した場合にエラーが返ること(検索対象に含まれない)",
 			args: args{
 				reflogs: []*Reflog{
-					NewReflog("5d5d5d", "HEAD@{0}", "test message", "5d5d5d HEAD@{0}: test action: test message"),
-					NewReflog("1x1x1x", "HEAD@{1}", "test message", "5d5d5d HEAD@{1}: test action: test message"),
-					NewReflog("2x2x2x", "HEAD@{2}", "test message", "5d5d5d HEAD@{2}: test action: test message"),
+					NewReflog("5d5d5d", "HEAD@{0}", "test message"),
+					NewReflog("1x1x1x", "HEAD@{1}", "test message"),
+					NewReflog("2x2x2x", "HEAD@{2}", "test message"),
 				},
 				id: "dummy",
 			},
@@ -70,7 +71,6 @@ func TestReflog_GetFzfInputForSelectActionType(t *testing.T) {
 		Id          string
 		HeadPoint   string
 		Message     string
-		RawReflog   string
 		ActionTypes []ActionType
 	}
 	type args struct {
@@ -87,8 +87,7 @@ func TestReflog_GetFzfInputForSelectActionType(t *testing.T) {
 			fields: fields{
 				Id:          "dummy",
 				HeadPoint:   "HEAD@{0}",
-				Message:     "commit message",       // 使わない
-				RawReflog:   "dummy commit message", // 使わない
+				Message:     "commit message", // 使わない
 				ActionTypes: []ActionType{ReflogActionTypes.ResetHard},
 			},
 			args: args{
@@ -96,6 +95,32 @@ func TestReflog_GetFzfInputForSelectActionType(t *testing.T) {
 			},
 			want: "reset hard\tDescription : Hard reset to selected commit\tCommand     : git reset --hard dummy\n",
 		},
+		{
+			name: "ブランチ名が入力される前はブランチ名を指定する位置を表示すること",
+			fields: fields{
+				Id:          "dummy",
+				HeadPoint:   "HEAD@{1}",
+				Message:     "commit message", // 使わない
+				ActionTypes: []ActionType{ReflogActionTypes.CreateBranch},
+			},
+			args: args{
+				actionType: ReflogActionTypes.CreateBranch,
+			},
+			want: "create branch\tDescription : Create a new branch at the selected entry (without switching to it)\tCommand     : git branch <branch name> dummy\n",
+		},
+		{
+			name: "ブランチを作成する場合は入力されたブランチ名の後にコミットIDを指定すること",
+			fields: fields{
+				Id:          "dummy",
+				HeadPoint:   "HEAD@{1}",
+				Message:     "commit message", // 使わない
+				ActionTypes: []ActionType{ReflogActionTypes.CreateBranch},
+			},
+			args: args{
+				actionType: ReflogActionTypes.CreateBranch.WithOptions("rescue/dummy"),
+			},
+			want: "create branch\tDescription : Create a new branch at the selected entry (without switching to it)\tCommand     : git branch rescue/dummy dummy\n",
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -105,7 +130,6 @@ func TestReflog_GetFzfInputForSelectActionType(t *testing.T) {
 				Id:          tt.fields.Id,
 				HeadPoint:   tt.fields.HeadPoint,
 				Message:     tt.fields.Message,
-				RawReflog:   tt.fields.RawReflog,
 				ActionTypes: tt.fields.ActionTypes,
 			}
 			if got := r.GetFzfInputForSelectActionType(tt.args.actionType); got != tt.want {
@@ -114,3 +138,284 @@ func TestReflog_GetFzfInputForSelectActionType(t *testing.T) {
 		})
 	}
 }
+
+func TestParseReflogs(t *testing.T) {
+	t.Parallel()
+
+	amend := NewReflog("4a77a8e", "main@{0}", "commit (amend): fix typo")
+	amend.Ref = "main"
+	amend.Timestamp = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	forced := NewReflog("75d39af", "main@{1}", "pull --force: forced-update")
+	forced.Ref = "main"
+	forced.Timestamp = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	stash := NewReflog("5e2aa26", "refs/stash@{0}", "WIP on main: 4a77a8e add feature")
+	stash.Ref = "refs/stash"
+
+	tests := []struct {
+		name    string
+		reflogs string
+		want    []*Reflog
+		wantErr bool
+	}{
+		{
+			name: "日時のセレクタを参照名と日時に分けて、新しいものからの順番をHeadPointにすること",
+			reflogs: "4a77a8e\x1fmain@{2024-01-02T03:04:05Z}\x1fcommit (amend): fix typo\x00" +
+				"75d39af\x1fmain@{2024-01-01T00:00:00Z}\x1fpull --force: forced-update\x00",
+			want: []*Reflog{amend, forced},
+		},
+		{
+			name:    "番号のセレクタはそのままHeadPointにすること",
+			reflogs: "5e2aa26\x1frefs/stash@{0}\x1fWIP on main: 4a77a8e add feature\x00",
+			want:    []*Reflog{stash},
+		},
+		{
+			name:    "reflogがない場合は空のスライスを返すこと",
+			reflogs: "",
+			want:    []*Reflog{},
+		},
+		{
+			name:    "フィールドの数が合わないエントリはスキップすること",
+			reflogs: "invalid\x00",
+			want:    []*Reflog{},
+		},
+		{
+			name:    "セレクタの形式が不正な場合はエラーを返すこと",
+			reflogs: "4a77a8e\x1fmain\x1fcommit: add feature\x00",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseReflogs(tt.reflogs)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseReflogs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseReflogs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReflog_GetFzfLine(t *testing.T) {
+	t.Parallel()
+
+	dated := NewReflog("4a77a8e", "main@{1}", "commit: add feature")
+	dated.Timestamp = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		reflog *Reflog
+		want   string
+	}{
+		{
+			name:   "コミットID、セレクタ、日時、メッセージの順に表示すること",
+			reflog: dated,
+			want:   "4a77a8e main@{1} 2024-01-02 03:04:05 commit: add feature",
+		},
+		{
+			name:   "日時がない場合は表示しないこと",
+			reflog: NewReflog("75d39af", "HEAD@{0}", "checkout: moving from main to x"),
+			want:   "75d39af HEAD@{0} checkout: moving from main to x",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.reflog.GetFzfLine(); got != tt.want {
+				t.Errorf("Reflog.GetFzfLine() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReflog_GetAvailableActionTypes(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		reflog Reflog
+		state  RepoState
+	}
+	tests := []struct {
+		name string
+		args args
+		want []ActionType
+	}{
+		{
+			name: "操作の途中でない場合は全てのアクションを選択できること",
+			args: args{
+				reflog: Reflog{Id: "4a77a8e", HeadPoint: "HEAD@{1}", Message: "commit: add feature", ActionTypes: ReflogActionTypes.All()},
+				state:  RepoState{},
+			},
+			want: []ActionType{
+				ReflogActionTypes.CreateBranch,
+				ReflogActionTypes.Diff,
+				ReflogActionTypes.Checkout,
+				ReflogActionTypes.CherryPick,
+				ReflogActionTypes.ResetSoft,
+				ReflogActionTypes.ResetMixed,
+				ReflogActionTypes.ResetHard,
+			},
+		},
+		{
+			name: "rebaseの途中はチェックアウトやcherry-pickができないこと",
+			args: args{
+				reflog: Reflog{Id: "4a77a8e", HeadPoint: "HEAD@{1}", Message: "commit: add feature", ActionTypes: ReflogActionTypes.All()},
+				state:  RepoState{OperationInProgress: "rebase"},
+			},
+			want: []ActionType{
+				ReflogActionTypes.CreateBranch,
+				ReflogActionTypes.Diff,
+				ReflogActionTypes.ResetSoft,
+				ReflogActionTypes.ResetMixed,
+				ReflogActionTypes.ResetHard,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.args.reflog.GetAvailableActionTypes(tt.args.state); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Reflog.GetAvailableActionTypes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindReflogsByHeadPoints(t *testing.T) {
+	t.Parallel()
+	reflogs := []*Reflog{
+		NewReflog("ccccccc", "HEAD@{0}", "commit: third"),
+		NewReflog("bbbbbbb", "HEAD@{1}", "commit: second"),
+		NewReflog("aaaaaaa", "HEAD@{2}", "commit: first"),
+	}
+	type args struct {
+		headPoints []string
+	}
+	tests := []struct {
+		name           string
+		args           args
+		want           Reflogs
+		wantErr        bool
+		wantErrMessage error
+	}{
+		{
+			name: "選択された順に関係なくreflogの表示順に並べること",
+			args: args{
+				headPoints: []string{"HEAD@{2}", "HEAD@{0}"},
+			},
+			want:           Reflogs{reflogs[0], reflogs[2]},
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "存在しないエントリが指定された場合、errorを返却すること",
+			args: args{
+				headPoints: []string{"HEAD@{0}", "HEAD@{9}"},
+			},
+			want:           nil,
+			wantErr:        true,
+			wantErrMessage: fmt.Errorf("reflog not found: %s", "HEAD@{9}"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := FindReflogsByHeadPoints(reflogs, tt.args.headPoints)
+			if (err != nil) != tt.wantErr || err != nil && err.Error() != tt.wantErrMessage.Error() {
+				t.Errorf("FindReflogsByHeadPoints() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("FindReflogsByHeadPoints() error message = %v, wantErrMessage %v", err, tt.wantErrMessage)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindReflogsByHeadPoints() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReflogs_GetFullCommand(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		actionType ActionType
+	}
+	tests := []struct {
+		name    string
+		reflogs Reflogs
+		args    args
+		want    string
+	}{
+		{
+			name: "cherry-pickの場合は古いエントリから順に指定すること",
+			reflogs: Reflogs{
+				NewReflog("ccccccc", "HEAD@{0}", "commit: third"),
+				NewReflog("aaaaaaa", "HEAD@{2}", "commit: first"),
+			},
+			args: args{
+				actionType: ReflogActionTypes.CherryPick,
+			},
+			want: "git cherry-pick aaaaaaa ccccccc",
+		},
+		{
+			name: "同じコミットのエントリが複数選択された場合は1度だけ指定すること",
+			reflogs: Reflogs{
+				NewReflog("bbbbbbb", "HEAD@{0}", "checkout: moving from main to feature"),
+				NewReflog("aaaaaaa", "HEAD@{1}", "commit: first"),
+				NewReflog("bbbbbbb", "HEAD@{2}", "commit: second"),
+			},
+			args: args{
+				actionType: ReflogActionTypes.CherryPick,
+			},
+			want: "git cherry-pick bbbbbbb aaaaaaa",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.reflogs.GetFullCommand(tt.args.actionType); got != tt.want {
+				t.Errorf("Reflogs.GetFullCommand() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReflogs_GetAvailableActionTypes(t *testing.T) {
+	t.Parallel()
+	reflogs := Reflogs{
+		NewReflog("ccccccc", "HEAD@{0}", "commit: third"),
+		NewReflog("aaaaaaa", "HEAD@{2}", "commit: first"),
+	}
+	tests := []struct {
+		name  string
+		state RepoState
+		want  []ActionType
+	}{
+		{
+			name:  "複数のエントリに実行できるアクションだけを表示すること",
+			state: RepoState{},
+			want:  []ActionType{ReflogActionTypes.CherryPick},
+		},
+		{
+			name:  "rebase等の途中の場合はcherry-pickを表示しないこと",
+			state: RepoState{OperationInProgress: "rebase"},
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := reflogs.GetAvailableActionTypes(tt.state); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Reflogs.GetAvailableActionTypes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package model
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// git remote -v で対象となったリモートを表す構造体
+type Remote struct {
+	Name        string
+	FetchURL    string
+	PushURL     string
+	ActionTypes []ActionType
+}
+
+func NewRemote(name string, fetchURL string, pushURL string) *Remote {
+	return &Remote{
+		Name:        name,
+		FetchURL:    fetchURL,
+		PushURL:     pushURL,
+		ActionTypes: RemoteActionTypes.All(),
+	}
+}
+
+func (r Remote) String() string {
+	return r.Name
+}
+
+// --list で出力する項目を返す
+func (r Remote) GetListItem() ListItem {
+	return ListItem{
+		{Key: "name", Value: r.Name},
+		{Key: "fetch_url", Value: r.FetchURL},
+		{Key: "push_url", Value: r.PushURL},
+	}
+}
+
+func FindRemoteByName(remotes []*Remote, name string) (*Remote, error) {
+	for _, remote := range remotes {
+		if remote.Name == name {
+			return remote, nil
+		}
+	}
+	return nil, fmt.Errorf("remote %s not found", name)
+}
+
+// fzfの候補として表示する1行を返す
+// 形式: "リモート名\tURL" (push の URL が異なる場合は併記する)
+func (r Remote) GetFzfLine() string {
+	url := r.FetchURL
+	if r.PushURL != "" && r.PushURL != r.FetchURL {
+		url = fmt.Sprintf("%s (push: %s)", url, r.PushURL)
+	}
+	return fmt.Sprintf("%s\t%s", r.Name, url)
+}
+
+func (r Remote) GetFullCommand(actionType ActionType) string {
+	options := r.GetOptionsWithRemoteName(actionType)
+	onelineOptions := strings.Join(options, " ")
+
+	fullCommand := fmt.Sprintf("%s %s", actionType.Command, onelineOptions)
+	slog.Debug("Command:", "Command", actionType.Name, "fullCommand", fullCommand)
+
+	return fullCommand
+}
+
+func (r Remote) GetOptionsWithRemoteName(actionType ActionType) []string {
+	if actionType.Custom {
+		return actionType.GetCustomOptions(r)
+	}
+
+	ret := actionType.Options
+
+	// add は選択したリモートを引数に取らない
+	if actionType.IsEqual(RemoteActionTypes.Add) {
+		return ret
+	}
+	ret = append(ret, r.Name)
+	return append(ret, actionType.TrailingOptions...)
+}
+
+// 破壊的なアクションの確認で表示する、失われる可能性がある内容の対象を返す
+// リモートの削除はローカルの変更やコミットに影響しない
+func (r Remote) GetDestructiveScope(actionType ActionType) DestructiveScope {
+	if actionType.IsEqual(RemoteActionTypes.Remove) {
+		return DestructiveScope{}
+	}
+	return defaultDestructiveScope()
+}
+
+func (r Remote) GetFzfInputForSelectActionType(actionType ActionType) string {
+	// fzfに渡す形式: "表示名\tフルコマンド\t説明文"
+	return fmt.Sprintf("%s\tDescription : %s\tCommand     : %s\n", actionType.Name, actionType.Help, r.GetFullCommand(actionType))
+}
+
+// git remote -v の形式をパースして、Remote構造体のスライスを返す
+// 例: "origin\thttps://github.com/o-kaisan/gitman.git (fetch)"
+func ParseRemotes(remotes string) ([]*Remote, error) {
+	var result []*Remote
+
+	for _, line := range strings.Split(remotes, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		name, rest, ok := strings.Cut(line, "\t")
+		if !ok {
+			// 不正な行はスキップ
+			slog.Debug("skip invalid remote line", "line", line)
+			continue
+		}
+
+		// URLの後に種類が表示される (例: "(fetch)")
+		// partial clone の場合は種類の後にフィルタが表示されるため無視する (例: "(fetch) [blob:none]")
+		url, kind := rest, ""
+		if i := strings.LastIndex(rest, " ("); i >= 0 {
+			if k, _, ok := strings.Cut(rest[i+2:], ")"); ok {
+				url, kind = rest[:i], k
+			}
+		}
+
+		remote, err := FindRemoteByName(result, name)
+		if err != nil {
+			remote = NewRemote(name, "", "")
+			result = append(result, remote)
+		}
+		switch kind {
+		case "fetch":
+			remote.FetchURL = url
+		case "push":
+			remote.PushURL = url
+		default:
+			return nil, fmt.Errorf("unknown remote url type: %s", line)
+		}
+	}
+
+	slog.Debug("get remotes from git", "remotes", result)
+	return result, nil
+}
+
+// fzfで選択された行からリモート名を取り出す
+func ParseSelectedRemoteName(selectedLine string) string {
+	return strings.SplitN(selectedLine, "\t", 2)[0]
+}
+
+// push 先のリモートを決める
+// configured には設定されたリモート名を優先度の高い順に指定する (例: branch.<name>.pushRemote, remote.pushDefault, branch.<name>.remote)
+// 設定がない場合は、リモートが1つだけならそのリモート、origin があれば origin を返す (決められない場合は空文字)
+func ResolvePushRemote(configured []string, remotes []string) string {
+	exists := func(name string) bool {
+		for _, remote := range remotes {
+			if remote == name {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, name := range configured {
+		if name != "" && exists(name) {
+			return name
+		}
+	}
+	if len(remotes) == 1 {
+		return remotes[0]
+	}
+	if exists("origin") {
+		return "origin"
+	}
+	return ""
+}
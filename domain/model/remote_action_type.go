@@ -0,0 +1,131 @@
+package model
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+type RemoteActionTypeMap struct {
+	Fetch      ActionType
+	FetchPrune ActionType
+	Show       ActionType
+	Rename     ActionType
+	Remove     ActionType
+	SetUrl     ActionType
+	Add        ActionType
+	Unknown    ActionType
+	// 設定ファイルで定義されたアクション
+	Custom []ActionType
+}
+
+var RemoteActionTypes = RemoteActionTypeMap{
+	Fetch: ActionType{
+		Name:    "fetch",
+		Command: "git",
+		Options: []string{"fetch"},
+		Help:    "Fetch branches and tags from the remote",
+	},
+	FetchPrune: ActionType{
+		Name:    "fetch prune",
+		Command: "git",
+		Options: []string{"fetch", "--prune"},
+		Help:    "Fetch from the remote and remove remote-tracking branches that no longer exist",
+	},
+	Show: ActionType{
+		Name:    "show",
+		Command: "git",
+		Options: []string{"remote", "show"},
+		Help:    "Show the branches and tracking information of the remote",
+	},
+	Rename: ActionType{
+		Name:    "rename",
+		Command: "git",
+		Options: []string{"remote", "rename"},
+		Help:    "Rename the remote and its remote-tracking branches",
+	},
+	Remove: ActionType{
+		Name:        "remove",
+		Command:     "git",
+		Options:     []string{"remote", "remove"},
+		Help:        "Remove the remote and its remote-tracking branches",
+		Destructive: true,
+	},
+	SetUrl: ActionType{
+		Name:    "set url",
+		Command: "git",
+		Options: []string{"remote", "set-url"},
+		Help:    "Change the URL of the remote",
+	},
+	Add: ActionType{
+		Name:    "add",
+		Command: "git",
+		Options: []string{"remote", "add"},
+		Help:    "Add a new remote",
+	},
+	Unknown: ActionType{
+		Name:    "unknown",
+		Command: "unknown",
+		Options: nil,
+		Help:    "unknown",
+	},
+}
+
+func (r RemoteActionTypeMap) All() []ActionType {
+	builtins := []ActionType{
+		r.Fetch,
+		r.FetchPrune,
+		r.Show,
+		r.Rename,
+		r.Remove,
+		r.SetUrl,
+		r.Add,
+	}
+	return mergeCustomActionTypes(builtins, r.Custom)
+}
+
+func (r RemoteActionTypeMap) GetRemoteActionTypes(action string) (ActionType, error) {
+	// 組み込みのアクションと同じ名前の独自のアクションは、組み込みのアクションを置き換える
+	if custom, ok := findCustomActionType(r.Custom, action); ok {
+		return custom, nil
+	}
+
+	switch action {
+	case "fetch":
+		return r.Fetch, nil
+	case "fetch prune":
+		return r.FetchPrune, nil
+	case "show":
+		return r.Show, nil
+	case "rename":
+		return r.Rename, nil
+	case "remove":
+		return r.Remove, nil
+	case "set url":
+		return r.SetUrl, nil
+	case "add":
+		return r.Add, nil
+	default:
+		return r.Unknown, fmt.Errorf("unknown action: %s", action)
+	}
+}
+
+func ParseSelectedRemoteActionType(selectedLine string) (ActionType, error) {
+	slog.Debug("Selected action from fzf", "selected", selectedLine)
+	if selectedLine == "" {
+		slog.Debug("No action selected")
+		return RemoteActionTypes.Unknown, nil
+	}
+
+	// タブで分割
+	fields := strings.Split(selectedLine, "\t")
+
+	// 最初のフィールドだけ取得
+	selectedActionType := fields[0]
+
+	result, err := RemoteActionTypes.GetRemoteActionTypes(selectedActionType)
+	if err != nil {
+		return RemoteActionTypes.Unknown, err
+	}
+	return result, nil
+}
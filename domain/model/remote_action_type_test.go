@@ -0,0 +1,166 @@
+package model
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestRemoteActionTypeMap_GetRemoteActionTypes(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		action string
+	}
+	tests := []struct {
+		name           string
+		args           args
+		want           ActionType
+		wantErr        bool
+		wantErrMessage error
+	}{
+		{
+			name: "対応するリモートアクション(fetch)を取得すること",
+			args: args{
+				action: "fetch",
+			},
+			want:           RemoteActionTypes.Fetch,
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "対応するリモートアクション(fetch prune)を取得すること",
+			args: args{
+				action: "fetch prune",
+			},
+			want:           RemoteActionTypes.FetchPrune,
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "対応するリモートアクション(show)を取得すること",
+			args: args{
+				action: "show",
+			},
+			want:           RemoteActionTypes.Show,
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "対応するリモートアクション(rename)を取得すること",
+			args: args{
+				action: "rename",
+			},
+			want:           RemoteActionTypes.Rename,
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "対応するリモートアクション(remove)を取得すること",
+			args: args{
+				action: "remove",
+			},
+			want:           RemoteActionTypes.Remove,
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "対応するリモートアクション(set url)を取得すること",
+			args: args{
+				action: "set url",
+			},
+			want:           RemoteActionTypes.SetUrl,
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "対応するリモートアクション(add)を取得すること",
+			args: args{
+				action: "add",
+			},
+			want:           RemoteActionTypes.Add,
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "不明なアクションが指定された場合、errorを返却すること",
+			args: args{
+				action: "dummy",
+			},
+			want:           RemoteActionTypes.Unknown,
+			wantErr:        true,
+			wantErrMessage: fmt.Errorf("unknown action: %s", "dummy"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := RemoteActionTypes.GetRemoteActionTypes(tt.args.action)
+			if (err != nil) != tt.wantErr || err != nil && err.Error() != tt.wantErrMessage.Error() {
+				t.Errorf("RemoteActionTypeMap.GetRemoteActionTypes() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("RemoteActionTypeMap.GetRemoteActionTypes() error = %v, wantErrMessage %v", err, tt.wantErrMessage)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RemoteActionTypeMap.GetRemoteActionTypes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSelectedRemoteActionType(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		selectedLine string
+	}
+	tests := []struct {
+		name           string
+		args           args
+		want           ActionType
+		wantErr        bool
+		wantErrMessage error
+	}{
+		{
+			name: "fzfの選択結果を元に、対応するリモートアクションを取得すること",
+			args: args{
+				selectedLine: "fetch prune\tDescription : hogehoge\tCommand     : fugafuga\n",
+			},
+			want:           RemoteActionTypes.FetchPrune,
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "何も選択されなかった場合、Unknownを返却すること",
+			args: args{
+				selectedLine: "",
+			},
+			want:           RemoteActionTypes.Unknown,
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "不明な文字列が指定された場合、Unknownを返却すること",
+			args: args{
+				selectedLine: "dummy\tDescription : hogehoge\tCommand     : fugafuga\n",
+			},
+			want:           RemoteActionTypes.Unknown,
+			wantErr:        true,
+			wantErrMessage: fmt.Errorf("unknown action: %s", "dummy"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseSelectedRemoteActionType(tt.args.selectedLine)
+			if (err != nil) != tt.wantErr || err != nil && err.Error() != tt.wantErrMessage.Error() {
+				t.Errorf("ParseSelectedRemoteActionType() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("ParseSelectedRemoteActionType() error = %v, wantErrMessage %v", err, tt.wantErrMessage)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSelectedRemoteActionType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
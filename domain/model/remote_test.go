@@ -0,0 +1,245 @@
+package model
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseRemotes(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		remotes string
+	}
+	tests := []struct {
+		name           string
+		args           args
+		want           []*Remote
+		wantErr        bool
+		wantErrMessage error
+	}{
+		{
+			name: "git remote -vの出力をリモートごとにまとめてパースできること",
+			args: args{
+				remotes: "origin\thttps://github.com/o-kaisan/gitman.git (fetch)\n" +
+					"origin\thttps://github.com/o-kaisan/gitman.git (push)\n" +
+					"upstream\thttps://example.com/gitman.git (fetch)\n" +
+					"upstream\tgit@example.com:gitman.git (push)\n",
+			},
+			want: []*Remote{
+				NewRemote("origin", "https://github.com/o-kaisan/gitman.git", "https://github.com/o-kaisan/gitman.git"),
+				NewRemote("upstream", "https://example.com/gitman.git", "git@example.com:gitman.git"),
+			},
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "partial cloneのフィルタが表示される場合もパースできること",
+			args: args{
+				remotes: "origin\thttps://github.com/o-kaisan/gitman.git (fetch) [blob:none]\n" +
+					"origin\thttps://github.com/o-kaisan/gitman.git (push)\n",
+			},
+			want: []*Remote{
+				NewRemote("origin", "https://github.com/o-kaisan/gitman.git", "https://github.com/o-kaisan/gitman.git"),
+			},
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "リモートがない場合はnilを返すこと",
+			args: args{
+				remotes: "",
+			},
+			want:           nil,
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "URLの種類がない場合はエラーを返すこと",
+			args: args{
+				remotes: "origin\thttps://github.com/o-kaisan/gitman.git\n",
+			},
+			want:           nil,
+			wantErr:        true,
+			wantErrMessage: errors.New("unknown remote url type: origin\thttps://github.com/o-kaisan/gitman.git"),
+		},
+		{
+			name: "URLの種類が不明な場合はエラーを返すこと",
+			args: args{
+				remotes: "origin\thttps://github.com/o-kaisan/gitman.git (mirror)\n",
+			},
+			want:           nil,
+			wantErr:        true,
+			wantErrMessage: errors.New("unknown remote url type: origin\thttps://github.com/o-kaisan/gitman.git (mirror)"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseRemotes(tt.args.remotes)
+			if (err != nil) != tt.wantErr || err != nil && err.Error() != tt.wantErrMessage.Error() {
+				t.Errorf("ParseRemotes() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("ParseRemotes() error = %v, wantErrMessage %v", err, tt.wantErrMessage)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseRemotes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRemote_GetFzfLine(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		remote *Remote
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "リモート名とURLをタブ区切りで表示すること",
+			args: args{
+				remote: NewRemote("origin", "https://example.com/gitman.git", "https://example.com/gitman.git"),
+			},
+			want: "origin\thttps://example.com/gitman.git",
+		},
+		{
+			name: "pushのURLが異なる場合は併記すること",
+			args: args{
+				remote: NewRemote("origin", "https://example.com/gitman.git", "git@example.com:gitman.git"),
+			},
+			want: "origin\thttps://example.com/gitman.git (push: git@example.com:gitman.git)",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.args.remote.GetFzfLine(); got != tt.want {
+				t.Errorf("Remote.GetFzfLine() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRemote_GetFullCommand(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		remote     *Remote
+		actionType ActionType
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "fetch --pruneは対象のリモートを指定すること",
+			args: args{
+				remote:     NewRemote("origin", "https://example.com/gitman.git", "https://example.com/gitman.git"),
+				actionType: RemoteActionTypes.FetchPrune,
+			},
+			want: "git fetch --prune origin",
+		},
+		{
+			name: "名前を変更する場合は対象のリモートの後に変更後の名前を指定すること",
+			args: args{
+				remote:     NewRemote("origin", "https://example.com/gitman.git", "https://example.com/gitman.git"),
+				actionType: RemoteActionTypes.Rename.WithTrailingOptions("upstream"),
+			},
+			want: "git remote rename origin upstream",
+		},
+		{
+			name: "URLを変更する場合は対象のリモートの後に変更後のURLを指定すること",
+			args: args{
+				remote:     NewRemote("origin", "https://example.com/gitman.git", "https://example.com/gitman.git"),
+				actionType: RemoteActionTypes.SetUrl.WithTrailingOptions("git@example.com:gitman.git"),
+			},
+			want: "git remote set-url origin git@example.com:gitman.git",
+		},
+		{
+			name: "追加する場合は選択したリモートを指定しないこと",
+			args: args{
+				remote:     NewRemote("origin", "https://example.com/gitman.git", "https://example.com/gitman.git"),
+				actionType: RemoteActionTypes.Add.WithOptions("fork", "https://example.com/fork.git"),
+			},
+			want: "git remote add fork https://example.com/fork.git",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.args.remote.GetFullCommand(tt.args.actionType); got != tt.want {
+				t.Errorf("Remote.GetFullCommand() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolvePushRemote(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		configured []string
+		remotes    []string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "設定されたリモートのうち、存在する最初のリモートを返すこと",
+			args: args{
+				configured: []string{"", "fork", "origin"},
+				remotes:    []string{"origin", "fork"},
+			},
+			want: "fork",
+		},
+		{
+			name: "存在しないリモートが設定されている場合は無視すること",
+			args: args{
+				configured: []string{"deleted"},
+				remotes:    []string{"upstream"},
+			},
+			want: "upstream",
+		},
+		{
+			name: "設定がなくリモートが複数ある場合はoriginを返すこと",
+			args: args{
+				configured: []string{"", "", ""},
+				remotes:    []string{"fork", "origin"},
+			},
+			want: "origin",
+		},
+		{
+			name: "設定がなくoriginもない場合は空文字を返すこと",
+			args: args{
+				configured: []string{},
+				remotes:    []string{"fork", "upstream"},
+			},
+			want: "",
+		},
+		{
+			name: "リモートがない場合は空文字を返すこと",
+			args: args{
+				configured: []string{"origin"},
+				remotes:    []string{},
+			},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := ResolvePushRemote(tt.args.configured, tt.args.remotes); got != tt.want {
+				t.Errorf("ResolvePushRemote() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
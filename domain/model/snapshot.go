@@ -0,0 +1,196 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+)
+
+// 破壊的な操作の前に記録するリポジトリの状態 (.git/gitman/journal.jsonl に1行ずつ保存する)
+// 追跡していないファイルは git stash create に含まれないため記録しない
+type Snapshot struct {
+	Id        string    `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	// 実行しようとしたコマンド
+	Command string `json:"command"`
+	Head    string `json:"head"`
+	// HEADが指すブランチ名 (detached HEADの場合は空文字)
+	HeadRef string `json:"head_ref"`
+	// ブランチ名とコミットIDの対応
+	Branches map[string]string `json:"branches"`
+	// git stash create で作成した未コミットの変更のコミットID (変更がない場合は空文字)
+	Stash string `json:"stash"`
+}
+
+func NewSnapshot(createdAt time.Time, command string, head string, headRef string, branches map[string]string, stash string) *Snapshot {
+	return &Snapshot{
+		Id:        createdAt.Format("20060102T150405.000000"),
+		CreatedAt: createdAt,
+		Command:   command,
+		Head:      head,
+		HeadRef:   headRef,
+		Branches:  branches,
+		Stash:     stash,
+	}
+}
+
+func (s Snapshot) String() string {
+	return s.Id
+}
+
+// スナップショットのコミットを git gc から保護するための参照名
+func (s Snapshot) GetRefName() string {
+	return "refs/gitman/snapshots/" + s.Id
+}
+
+// ジャーナルに書き込む1行を返す
+func (s Snapshot) MarshalJournalLine() (string, error) {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal snapshot: %w", err)
+	}
+	return string(b) + "\n", nil
+}
+
+// 名前順に並べたブランチ名を返す
+func (s Snapshot) GetBranchNames() []string {
+	names := make([]string, 0, len(s.Branches))
+	for name := range s.Branches {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// 他のワークツリーでチェックアウトされているブランチを除いて、復元するブランチ名を名前順に返す
+// checkedOut にはブランチ名とチェックアウトしているワークツリーのパスの対応を指定する
+// 他のワークツリーのブランチを動かすと、そのワークツリーの作業ツリーとブランチの先端が食い違うため復元しない
+func (s Snapshot) GetRestorableBranchNames(checkedOut map[string]string) ([]string, []string) {
+	var restorable, skipped []string
+	for _, name := range s.GetBranchNames() {
+		if _, ok := checkedOut[name]; ok {
+			skipped = append(skipped, name)
+			continue
+		}
+		restorable = append(restorable, name)
+	}
+	return restorable, skipped
+}
+
+// 復元の確認で表示する、失われる可能性がある内容の対象を返す
+// 復元ではワークツリーの未コミットの変更と、HEAD から辿れなくなる未プッシュのコミットが失われる
+func (s Snapshot) GetDestructiveScope() DestructiveScope {
+	return defaultDestructiveScope("HEAD")
+}
+
+// fzfの候補として表示する1行を返す
+// 形式: "表示用の文字列\tスナップショットID\tプレビュー用の詳細 (改行は\nでエスケープ)"
+func (s Snapshot) GetFzfLine() string {
+	head := s.HeadRef
+	if head == "" {
+		head = shortId(s.Head)
+	}
+	display := fmt.Sprintf("%s  %-20s %s", s.CreatedAt.Local().Format("2006-01-02 15:04:05"), head, s.Command)
+	return fmt.Sprintf("%s\t%s\t%s", display, s.Id, strings.ReplaceAll(s.GetDetail(), "\n", `\n`))
+}
+
+// プレビューに表示する詳細
+func (s Snapshot) GetDetail() string {
+	lines := []string{
+		fmt.Sprintf("Command : %s", s.Command),
+		fmt.Sprintf("HEAD    : %s %s", s.Head, s.HeadRef),
+	}
+	if s.Stash != "" {
+		lines = append(lines, fmt.Sprintf("Stash   : %s", s.Stash))
+	} else {
+		lines = append(lines, "Stash   : (working tree was clean)")
+	}
+	lines = append(lines, "Branches:")
+	for _, name := range s.GetBranchNames() {
+		lines = append(lines, fmt.Sprintf("  %s %s", shortId(s.Branches[name]), name))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// fzfで選択された行からスナップショットIDを取り出す
+func ParseSelectedSnapshotId(selectedLine string) string {
+	fields := strings.Split(selectedLine, "\t")
+	if len(fields) < 2 {
+		return ""
+	}
+	return fields[1]
+}
+
+func FindSnapshotById(snapshots []*Snapshot, id string) (*Snapshot, error) {
+	for _, snapshot := range snapshots {
+		if snapshot.Id == id {
+			return snapshot, nil
+		}
+	}
+	return nil, fmt.Errorf("snapshot not found: %s", id)
+}
+
+// ジャーナルの内容をパースして、新しいものから順にスナップショットを返す
+func ParseSnapshots(journal string) ([]*Snapshot, error) {
+	var result []*Snapshot
+	for _, line := range strings.Split(journal, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		var snapshot Snapshot
+		if err := json.Unmarshal([]byte(line), &snapshot); err != nil {
+			// 壊れた行があっても他のスナップショットは復元できるようにスキップする
+			slog.Warn("skip invalid journal line", "line", line, "error", err)
+			continue
+		}
+		result = append(result, &snapshot)
+	}
+
+	// 新しいものを先頭にする
+	for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+		result[i], result[j] = result[j], result[i]
+	}
+	return result, nil
+}
+
+// 新しいものから順に並んだスナップショットを、保持する数までのものとそれより古いものに分ける
+// limit が0以下の場合は全て保持する
+func SplitSnapshotsByLimit(snapshots []*Snapshot, limit int) ([]*Snapshot, []*Snapshot) {
+	if limit <= 0 || len(snapshots) <= limit {
+		return snapshots, nil
+	}
+	return snapshots[:limit], snapshots[limit:]
+}
+
+// git for-each-ref refs/heads --format='%(refname:short) %(objectname)' の形式をパースして、ブランチ名とコミットIDの対応を返す
+func ParseBranchTips(out string) map[string]string {
+	tips := map[string]string{}
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		tips[fields[0]] = fields[1]
+	}
+	return tips
+}
+
+func shortId(id string) string {
+	if len(id) > 7 {
+		return id[:7]
+	}
+	return id
+}
+
+// スナップショットを復元するアクション
+var UndoActionType = ActionType{
+	Name:        "undo",
+	Command:     "gitman",
+	Options:     []string{"undo"},
+	Help:        "Restore HEAD, branch tips and uncommitted changes recorded before the operation",
+	Destructive: true,
+}
@@ -0,0 +1,238 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseSnapshots(t *testing.T) {
+	t.Parallel()
+
+	first := NewSnapshot(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), "git reset --hard HEAD~1", "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", "main",
+		map[string]string{"main": "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"}, "")
+	second := NewSnapshot(time.Date(2024, 1, 2, 3, 5, 0, 0, time.UTC), "git branch -D feature", "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb", "",
+		map[string]string{"main": "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", "feature": "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"}, "cccccccccccccccccccccccccccccccccccccccc")
+	firstLine, err := first.MarshalJournalLine()
+	if err != nil {
+		t.Fatalf("MarshalJournalLine() error = %v", err)
+	}
+	secondLine, err := second.MarshalJournalLine()
+	if err != nil {
+		t.Fatalf("MarshalJournalLine() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		journal string
+		want    []*Snapshot
+	}{
+		{
+			name:    "新しいスナップショットから順に返すこと",
+			journal: firstLine + secondLine,
+			want:    []*Snapshot{second, first},
+		},
+		{
+			name:    "壊れた行はスキップすること",
+			journal: firstLine + "{broken\n" + secondLine,
+			want:    []*Snapshot{second, first},
+		},
+		{
+			name:    "ジャーナルが空の場合はnilを返すこと",
+			journal: "",
+			want:    nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseSnapshots(tt.journal)
+			if err != nil {
+				t.Errorf("ParseSnapshots() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSnapshots() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseBranchTips(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		out  string
+		want map[string]string
+	}{
+		{
+			name: "ブランチ名とコミットIDの対応を返すこと",
+			out:  "feature/a 1111111\nmain 2222222\n",
+			want: map[string]string{"feature/a": "1111111", "main": "2222222"},
+		},
+		{
+			name: "ブランチが存在しない場合は空のマップを返すこと",
+			out:  "",
+			want: map[string]string{},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := ParseBranchTips(tt.out); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseBranchTips() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSelectedSnapshotId(t *testing.T) {
+	t.Parallel()
+	snapshot := NewSnapshot(time.Date(2024, 1, 2, 3, 4, 5, 123456000, time.UTC), "git rebase main", "aaaaaaa", "feature",
+		map[string]string{"feature": "aaaaaaa"}, "")
+	tests := []struct {
+		name string
+		line string
+		want string
+	}{
+		{
+			name: "fzfの行からスナップショットIDを取り出せること",
+			line: snapshot.GetFzfLine(),
+			want: "20240102T030405.123456",
+		},
+		{
+			name: "タブを含まない行の場合は空文字を返すこと",
+			line: "invalid",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := ParseSelectedSnapshotId(tt.line); got != tt.want {
+				t.Errorf("ParseSelectedSnapshotId() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindSnapshotById(t *testing.T) {
+	t.Parallel()
+	snapshot := NewSnapshot(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), "git revert aaaaaaa", "aaaaaaa", "main", nil, "")
+	snapshots := []*Snapshot{snapshot}
+
+	got, err := FindSnapshotById(snapshots, snapshot.Id)
+	if err != nil || got != snapshot {
+		t.Errorf("FindSnapshotById() = %v, %v, want %v", got, err, snapshot)
+	}
+	if got.GetRefName() != "refs/gitman/snapshots/20240102T030405.000000" {
+		t.Errorf("GetRefName() = %v", got.GetRefName())
+	}
+	if _, err := FindSnapshotById(snapshots, "unknown"); err == nil {
+		t.Errorf("FindSnapshotById() error = nil, want error")
+	}
+}
+
+func TestSplitSnapshotsByLimit(t *testing.T) {
+	t.Parallel()
+	snapshots := []*Snapshot{
+		NewSnapshot(time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), "git reset --hard HEAD~1", "c", "main", nil, ""),
+		NewSnapshot(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), "git branch -D a", "b", "main", nil, ""),
+		NewSnapshot(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), "git rebase main", "a", "main", nil, ""),
+	}
+	type args struct {
+		limit int
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantKept    []*Snapshot
+		wantExpired []*Snapshot
+	}{
+		{
+			name: "保持する数を超えた古いスナップショットを分けること",
+			args: args{
+				limit: 2,
+			},
+			wantKept:    snapshots[:2],
+			wantExpired: snapshots[2:],
+		},
+		{
+			name: "保持する数以下の場合は全て保持すること",
+			args: args{
+				limit: 3,
+			},
+			wantKept:    snapshots,
+			wantExpired: nil,
+		},
+		{
+			name: "0の場合は全て保持すること",
+			args: args{
+				limit: 0,
+			},
+			wantKept:    snapshots,
+			wantExpired: nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			gotKept, gotExpired := SplitSnapshotsByLimit(snapshots, tt.args.limit)
+			if !reflect.DeepEqual(gotKept, tt.wantKept) {
+				t.Errorf("SplitSnapshotsByLimit() kept = %v, want %v", gotKept, tt.wantKept)
+			}
+			if !reflect.DeepEqual(gotExpired, tt.wantExpired) {
+				t.Errorf("SplitSnapshotsByLimit() expired = %v, want %v", gotExpired, tt.wantExpired)
+			}
+		})
+	}
+}
+
+func TestSnapshot_GetRestorableBranchNames(t *testing.T) {
+	t.Parallel()
+	snapshot := NewSnapshot(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), "git reset --hard HEAD~1", "a", "main",
+		map[string]string{"main": "a", "feature": "b", "hotfix": "c"}, "")
+	type args struct {
+		checkedOut map[string]string
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantRestore []string
+		wantSkipped []string
+	}{
+		{
+			name: "他のワークツリーでチェックアウトされているブランチを除くこと",
+			args: args{
+				checkedOut: map[string]string{"feature": "/home/user/gitman-feature"},
+			},
+			wantRestore: []string{"hotfix", "main"},
+			wantSkipped: []string{"feature"},
+		},
+		{
+			name: "他のワークツリーがない場合は全てのブランチを復元すること",
+			args: args{
+				checkedOut: map[string]string{},
+			},
+			wantRestore: []string{"feature", "hotfix", "main"},
+			wantSkipped: nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			gotRestore, gotSkipped := snapshot.GetRestorableBranchNames(tt.args.checkedOut)
+			if !reflect.DeepEqual(gotRestore, tt.wantRestore) {
+				t.Errorf("Snapshot.GetRestorableBranchNames() restorable = %v, want %v", gotRestore, tt.wantRestore)
+			}
+			if !reflect.DeepEqual(gotSkipped, tt.wantSkipped) {
+				t.Errorf("Snapshot.GetRestorableBranchNames() skipped = %v, want %v", gotSkipped, tt.wantSkipped)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package model
+
+import (
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// git stash listで対象となったスタッシュを表す構造体
+type Stash struct {
+	Id          string
+	Branch      string
+	Message     string
+	RawStash    string
+	ActionTypes []ActionType
+}
+
+func NewStash(id string, branch string, message string, rawStash string) *Stash {
+	return &Stash{
+		Id:          id,
+		Branch:      branch,
+		Message:     message,
+		RawStash:    rawStash,
+		ActionTypes: StashActionTypes.All(),
+	}
+}
+
+func (s Stash) String() string {
+	return s.Id
+}
+
+// --list で出力する項目を返す
+func (s Stash) GetListItem() ListItem {
+	return ListItem{
+		{Key: "id", Value: s.Id},
+		{Key: "branch", Value: s.Branch},
+		{Key: "message", Value: s.Message},
+	}
+}
+
+func FindStashById(stashes []*Stash, id string) (*Stash, error) {
+	for _, stash := range stashes {
+		if stash.Id == id {
+			return stash, nil
+		}
+	}
+	return nil, fmt.Errorf("stash not found: %s", id)
+}
+
+func (s Stash) GetFullCommand(actionType ActionType) string {
+	options := s.GetOptionsWithStashId(actionType)
+	onelineOptions := strings.Join(options, " ")
+
+	fullCommand := fmt.Sprintf("%s %s", actionType.Command, onelineOptions)
+	slog.Debug("Command:", "Command", actionType.Name, "fullCommand", fullCommand)
+
+	return fullCommand
+}
+
+func (s Stash) GetOptionsWithStashId(actionType ActionType) []string {
+	if actionType.Custom {
+		return actionType.GetCustomOptions(s)
+	}
+
+	ret := actionType.Options
+	ret = append(ret, s.Id)
+	return ret
+}
+
+// リポジトリの状態に応じて実行できるアクションだけを返す
+func (s Stash) GetAvailableActionTypes(state RepoState) []ActionType {
+	return filterAvailableActionTypes(s.ActionTypes, state)
+}
+
+// 破壊的なアクションの確認で表示する、失われる可能性がある内容の対象を返す
+// 削除はスタッシュの内容が失われる
+func (s Stash) GetDestructiveScope(actionType ActionType) DestructiveScope {
+	if actionType.IsEqual(StashActionTypes.Drop) {
+		return DestructiveScope{Stash: s.Id}
+	}
+	return defaultDestructiveScope()
+}
+
+func (s Stash) GetFzfInputForSelectActionType(actionType ActionType) string {
+	// fzfに渡す形式: "表示名\tフルコマンド\t説明文"
+	return fmt.Sprintf("%s\tDescription : %s\tCommand     : %s\n", actionType.Name, actionType.Help, s.GetFullCommand(actionType))
+}
+
+// git stash list の形式をパースして、Stash構造体のスライスを返す
+func ParseStashes(stashes string) ([]*Stash, error) {
+	if strings.TrimSpace(stashes) == "" {
+		return []*Stash{}, nil
+	}
+
+	lines := strings.Split(strings.TrimSpace(stashes), "\n")
+	result := make([]*Stash, 0, len(lines))
+
+	// Pattern: stash@{index}: WIP on {branch}: {message} / stash@{index}: On {branch}: {message}
+	stashPattern := regexp.MustCompile(`^(stash@\{[0-9]+\}):\s+(?:WIP on|On)\s+([^:]+):\s*(.*)$`)
+	// git stash store -m などで作成され、ブランチ名を含まないスタッシュはメッセージをそのまま使う
+	// Pattern: stash@{index}: {subject}
+	subjectPattern := regexp.MustCompile(`^(stash@\{[0-9]+\}):\s*(.*)$`)
+
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if matches := stashPattern.FindStringSubmatch(line); len(matches) == 4 {
+			id := matches[1]
+			branch := strings.TrimSpace(matches[2])
+			message := strings.TrimSpace(matches[3])
+
+			result = append(result, NewStash(id, branch, message, line))
+			continue
+		}
+
+		matches := subjectPattern.FindStringSubmatch(line)
+		if len(matches) != 3 {
+			// スタッシュIDがない想定外の形式の行はスキップ
+			continue
+		}
+		result = append(result, NewStash(matches[1], "", strings.TrimSpace(matches[2]), line))
+	}
+
+	slog.Debug("get stashes from git", "stashes", result)
+	return result, nil
+}
+
+// fzfで選択された行からスタッシュIDを取り出す
+// 例: "stash@{0}: WIP on main: 1a2b3c message" -> "stash@{0}"
+func ParseSelectedStashId(selectedLine string) string {
+	return strings.TrimSpace(strings.SplitN(selectedLine, ":", 2)[0])
+}
@@ -0,0 +1,114 @@
+package model
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+type StashActionTypeMap struct {
+	Apply   ActionType
+	Pop     ActionType
+	Drop    ActionType
+	Show    ActionType
+	Branch  ActionType
+	Unknown ActionType
+	// 設定ファイルで定義されたアクション
+	Custom []ActionType
+}
+
+var StashActionTypes = StashActionTypeMap{
+	Apply: ActionType{
+		Name:       "apply",
+		Command:    "git",
+		Options:    []string{"stash", "apply"},
+		Help:       "Apply the stash on top of the working tree",
+		Conditions: []ActionCondition{ConditionNoConflicts},
+	},
+	Pop: ActionType{
+		Name:       "pop",
+		Command:    "git",
+		Options:    []string{"stash", "pop"},
+		Help:       "Apply the stash and remove it from the stash list",
+		Conditions: []ActionCondition{ConditionNoConflicts},
+	},
+	Drop: ActionType{
+		Name:        "drop",
+		Command:     "git",
+		Options:     []string{"stash", "drop"},
+		Help:        "Remove the stash from the stash list",
+		Destructive: true,
+	},
+	Show: ActionType{
+		Name:    "show",
+		Command: "git",
+		Options: []string{"stash", "show", "-p"},
+		Help:    "Show the changes recorded in the stash",
+	},
+	Branch: ActionType{
+		Name:       "branch from stash",
+		Command:    "git",
+		Options:    []string{"stash", "branch"},
+		Help:       "Create a new branch from the commit the stash was created on and apply the stash",
+		Conditions: []ActionCondition{ConditionNoOperationInProgress, ConditionNoConflicts},
+	},
+	Unknown: ActionType{
+		Name:    "unknown",
+		Command: "unknown",
+		Options: nil,
+		Help:    "unknown",
+	},
+}
+
+func (s StashActionTypeMap) All() []ActionType {
+	builtins := []ActionType{
+		s.Apply,
+		s.Pop,
+		s.Drop,
+		s.Show,
+		s.Branch,
+	}
+	return mergeCustomActionTypes(builtins, s.Custom)
+}
+
+func (s StashActionTypeMap) GetStashActionTypes(action string) (ActionType, error) {
+	// 組み込みのアクションと同じ名前の独自のアクションは、組み込みのアクションを置き換える
+	if custom, ok := findCustomActionType(s.Custom, action); ok {
+		return custom, nil
+	}
+
+	switch action {
+	case "apply":
+		return s.Apply, nil
+	case "pop":
+		return s.Pop, nil
+	case "drop":
+		return s.Drop, nil
+	case "show":
+		return s.Show, nil
+	case "branch from stash":
+		return s.Branch, nil
+	default:
+		return s.Unknown, fmt.Errorf("unknown action: %s", action)
+	}
+}
+
+func ParseSelectedStashActionType(selectedLine string) (ActionType, error) {
+	slog.Debug("Selected action from fzf", "selected", selectedLine)
+	if selectedLine == "" {
+		slog.Debug("No action selected")
+		return StashActionTypes.Unknown, nil
+	}
+
+	// タブで分割
+	fields := strings.Split(selectedLine, "\t")
+
+	// 最初のフィールドだけ取得
+	selectedActionType := fields[0]
+
+	result, err := StashActionTypes.GetStashActionTypes(selectedActionType)
+	if err != nil {
+		return StashActionTypes.Unknown, err
+	}
+	return result, nil
+}
@@ -0,0 +1,113 @@
+package model
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestStashActionTypeMap_GetStashActionTypes(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name           string
+		action         string
+		want           ActionType
+		wantErr        bool
+		wantErrMessage error
+	}{
+		{
+			name:    "対応するスタッシュアクション(apply)を取得すること",
+			action:  "apply",
+			want:    StashActionTypes.Apply,
+			wantErr: false,
+		},
+		{
+			name:    "対応するスタッシュアクション(pop)を取得すること",
+			action:  "pop",
+			want:    StashActionTypes.Pop,
+			wantErr: false,
+		},
+		{
+			name:    "対応するスタッシュアクション(drop)を取得すること",
+			action:  "drop",
+			want:    StashActionTypes.Drop,
+			wantErr: false,
+		},
+		{
+			name:    "対応するスタッシュアクション(show)を取得すること",
+			action:  "show",
+			want:    StashActionTypes.Show,
+			wantErr: false,
+		},
+		{
+			name:    "対応するスタッシュアクション(branch from stash)を取得すること",
+			action:  "branch from stash",
+			want:    StashActionTypes.Branch,
+			wantErr: false,
+		},
+		{
+			name:           "不明なアクションが指定された場合、errorを返却すること",
+			action:         "dummy",
+			want:           StashActionTypes.Unknown,
+			wantErr:        true,
+			wantErrMessage: fmt.Errorf("unknown action: %s", "dummy"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := StashActionTypes.GetStashActionTypes(tt.action)
+			if (err != nil) != tt.wantErr || err != nil && err.Error() != tt.wantErrMessage.Error() {
+				t.Errorf("StashActionTypeMap.GetStashActionTypes() error = %v, wantErrMessage %v", err, tt.wantErrMessage)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("StashActionTypeMap.GetStashActionTypes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSelectedStashActionType(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		selectedLine string
+		want         ActionType
+		wantErr      bool
+	}{
+		{
+			name:         "fzfの選択結果を元に、対応するスタッシュアクションを取得すること",
+			selectedLine: "pop\tDescription : hogehoge\tCommand     : fugafuga\n",
+			want:         StashActionTypes.Pop,
+			wantErr:      false,
+		},
+		{
+			name:         "何も選択されなかった場合、Unknownを返却すること",
+			selectedLine: "",
+			want:         StashActionTypes.Unknown,
+			wantErr:      false,
+		},
+		{
+			name:         "不明な文字列が指定された場合、Unknownを返却すること",
+			selectedLine: "dummy\tDescription : hogehoge\tCommand     : fugafuga\n",
+			want:         StashActionTypes.Unknown,
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseSelectedStashActionType(tt.selectedLine)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseSelectedStashActionType() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSelectedStashActionType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
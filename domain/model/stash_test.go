@@ -0,0 +1,215 @@
+package model
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestParseStashes(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		stashes string
+	}
+	tests := []struct {
+		name string
+		args args
+		want []*Stash
+	}{
+		{
+			name: "git stash listの出力をパースしてスタッシュを取得できること",
+			args: args{
+				stashes: "stash@{0}: WIP on main: 1a2b3c4 fix typo\nstash@{1}: On feature/login: work in progress\n",
+			},
+			want: []*Stash{
+				NewStash("stash@{0}", "main", "1a2b3c4 fix typo", "stash@{0}: WIP on main: 1a2b3c4 fix typo"),
+				NewStash("stash@{1}", "feature/login", "work in progress", "stash@{1}: On feature/login: work in progress"),
+			},
+		},
+		{
+			name: "想定外の形式の行はスキップすること",
+			args: args{
+				stashes: "stash@{0}: WIP on main: 1a2b3c4 fix typo\ninvalid line\n",
+			},
+			want: []*Stash{
+				NewStash("stash@{0}", "main", "1a2b3c4 fix typo", "stash@{0}: WIP on main: 1a2b3c4 fix typo"),
+			},
+		},
+		{
+			name: "ブランチ名を含まないスタッシュはメッセージをそのまま使うこと",
+			args: args{
+				stashes: "stash@{0}: WIP on main: 1a2b3c4 fix typo\nstash@{1}: backup before rebase\n",
+			},
+			want: []*Stash{
+				NewStash("stash@{0}", "main", "1a2b3c4 fix typo", "stash@{0}: WIP on main: 1a2b3c4 fix typo"),
+				NewStash("stash@{1}", "", "backup before rebase", "stash@{1}: backup before rebase"),
+			},
+		},
+		{
+			name: "スタッシュが存在しない場合は空のスライスを返すこと",
+			args: args{
+				stashes: "",
+			},
+			want: []*Stash{},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseStashes(tt.args.stashes)
+			if err != nil {
+				t.Errorf("ParseStashes() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseStashes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindStashById(t *testing.T) {
+	t.Parallel()
+	stashes := []*Stash{
+		NewStash("stash@{0}", "main", "message0", "stash@{0}: WIP on main: message0"),
+		NewStash("stash@{1}", "main", "message1", "stash@{1}: WIP on main: message1"),
+	}
+	tests := []struct {
+		name           string
+		id             string
+		want           *Stash
+		wantErr        bool
+		wantErrMessage error
+	}{
+		{
+			name:           "スタッシュIDを指定して対象のスタッシュが取得できること",
+			id:             "stash@{1}",
+			want:           NewStash("stash@{1}", "main", "message1", "stash@{1}: WIP on main: message1"),
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name:           "存在しないスタッシュIDを指定した場合にエラーが返ること",
+			id:             "stash@{9}",
+			want:           nil,
+			wantErr:        true,
+			wantErrMessage: fmt.Errorf("stash not found: %s", "stash@{9}"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := FindStashById(stashes, tt.id)
+			if (err != nil) != tt.wantErr || err != nil && err.Error() != tt.wantErrMessage.Error() {
+				t.Errorf("FindStashById() error = %v, wantErrMessage %v", err, tt.wantErrMessage)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindStashById() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSelectedStashId(t *testing.T) {
+	t.Parallel()
+	got := ParseSelectedStashId("stash@{2}: WIP on main: 1a2b3c4 fix: typo")
+	if got != "stash@{2}" {
+		t.Errorf("ParseSelectedStashId() = %v, want %v", got, "stash@{2}")
+	}
+}
+
+func TestStash_GetAvailableActionTypes(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		stash Stash
+		state RepoState
+	}
+	tests := []struct {
+		name string
+		args args
+		want []ActionType
+	}{
+		{
+			name: "競合がない場合は全てのアクションを選択できること",
+			args: args{
+				stash: Stash{Id: "stash@{0}", ActionTypes: StashActionTypes.All()},
+				state: RepoState{Dirty: true},
+			},
+			want: []ActionType{
+				StashActionTypes.Apply,
+				StashActionTypes.Pop,
+				StashActionTypes.Drop,
+				StashActionTypes.Show,
+				StashActionTypes.Branch,
+			},
+		},
+		{
+			name: "競合している場合は適用やブランチの作成ができないこと",
+			args: args{
+				stash: Stash{Id: "stash@{0}", ActionTypes: StashActionTypes.All()},
+				state: RepoState{Dirty: true, Conflicted: true, OperationInProgress: "merge"},
+			},
+			want: []ActionType{
+				StashActionTypes.Drop,
+				StashActionTypes.Show,
+			},
+		},
+		{
+			name: "rebaseの途中はブランチを作成できないこと",
+			args: args{
+				stash: Stash{Id: "stash@{0}", ActionTypes: StashActionTypes.All()},
+				state: RepoState{OperationInProgress: "rebase"},
+			},
+			want: []ActionType{
+				StashActionTypes.Apply,
+				StashActionTypes.Pop,
+				StashActionTypes.Drop,
+				StashActionTypes.Show,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.args.stash.GetAvailableActionTypes(tt.args.state); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Stash.GetAvailableActionTypes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStash_GetFzfInputForSelectActionType(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		stash      *Stash
+		actionType ActionType
+		want       string
+	}{
+		{
+			name:       "アクションを選択するためにfzfに渡す文字列を生成できること",
+			stash:      NewStash("stash@{0}", "main", "message", "stash@{0}: WIP on main: message"),
+			actionType: StashActionTypes.Pop,
+			want:       "pop\tDescription : Apply the stash and remove it from the stash list\tCommand     : git stash pop stash@{0}\n",
+		},
+		{
+			name:       "追加の引数を持つアクションはスタッシュIDの前に引数を含めること",
+			stash:      NewStash("stash@{0}", "main", "message", "stash@{0}: WIP on main: message"),
+			actionType: StashActionTypes.Branch.WithOptions("new-branch"),
+			want:       "branch from stash\tDescription : Create a new branch from the commit the stash was created on and apply the stash\tCommand     : git stash branch new-branch stash@{0}\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.stash.GetFzfInputForSelectActionType(tt.actionType); got != tt.want {
+				t.Errorf("Stash.GetFzfInputForSelectActionType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,225 @@
+package model
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// git for-each-ref で取得するタグの出力形式(タブ区切り)
+// 名前, オブジェクトの種類, 注釈付きタグが指すコミットID, タグのオブジェクトID, タグ作成日, 件名
+const TagFormat = "%(refname:short)%09%(objecttype)%09%(*objectname:short)%09%(objectname:short)%09%(taggerdate:short)%09%(contents:subject)"
+
+// git tagで対象となったタグを表す構造体
+type Tag struct {
+	Name       string
+	CommitId   string
+	Annotated  bool
+	TaggerDate string
+	Subject    string
+	// push や削除に使うリモート (空の場合は push できない)
+	Remote      string
+	ActionTypes []ActionType
+}
+
+func NewTag(name string, commitId string, annotated bool, taggerDate string, subject string) *Tag {
+	return &Tag{
+		Name:        name,
+		CommitId:    commitId,
+		Annotated:   annotated,
+		TaggerDate:  taggerDate,
+		Subject:     subject,
+		ActionTypes: TagActionTypes.All(),
+	}
+}
+
+func (t Tag) String() string {
+	return t.Name
+}
+
+// --list で出力する項目を返す
+func (t Tag) GetListItem() ListItem {
+	return ListItem{
+		{Key: "name", Value: t.Name},
+		{Key: "commit_id", Value: t.CommitId},
+		{Key: "annotated", Value: t.Annotated},
+		{Key: "tagger_date", Value: t.TaggerDate},
+		{Key: "subject", Value: t.Subject},
+	}
+}
+
+// リポジトリの状態とタグが条件を満たすアクションだけを返す
+func (t Tag) GetAvailableActionTypes(state RepoState) []ActionType {
+	return filterAvailableActionTypes(t.ActionTypes, state, t)
+}
+
+// タグの種類や push 先のリモートがアクションの条件を満たすか
+func (t Tag) meetsCondition(condition ActionCondition, state RepoState) bool {
+	switch condition {
+	case ConditionLightweightTag:
+		return !t.Annotated
+	case ConditionPushRemote:
+		return t.Remote != ""
+	}
+	return true
+}
+
+func FindTagByName(tags []*Tag, name string) (*Tag, error) {
+	for _, tag := range tags {
+		if tag.Name == name {
+			return tag, nil
+		}
+	}
+	return nil, fmt.Errorf("tag %s not found", name)
+}
+
+// fzfの候補として表示する1行を返す
+// 先頭のタグ名で選択結果からタグを特定するため、タグ名は必ず1列目に置く
+func (t Tag) GetFzfLine() string {
+	kind := "lightweight"
+	if t.Annotated {
+		kind = "annotated"
+	}
+	return strings.TrimSpace(fmt.Sprintf("%s %s %-11s %s %s", t.Name, t.CommitId, kind, t.TaggerDate, t.Subject))
+}
+
+func (t Tag) GetFullCommand(actionType ActionType) string {
+	options := t.GetOptionsWithTagName(actionType)
+	onelineOptions := strings.Join(options, " ")
+
+	fullCommand := fmt.Sprintf("%s %s", actionType.Command, onelineOptions)
+	slog.Debug("Command:", "Command", actionType.Name, "fullCommand", fullCommand)
+
+	return fullCommand
+}
+
+func (t Tag) GetOptionsWithTagName(actionType ActionType) []string {
+	if actionType.Custom {
+		return actionType.GetCustomOptions(t)
+	}
+
+	ret := actionType.Options
+	switch {
+	// 作成するタグ名は実行時に追加するため、選択したタグは引数に取らない
+	case actionType.IsEqual(TagActionTypes.Create):
+		return ret
+	// 同じコミットに注釈付きタグを作り直す
+	case actionType.IsEqual(TagActionTypes.Annotate):
+		return append(ret, t.Name, t.CommitId)
+	// 同名のリモートブランチと区別するため、完全な参照名で指定する
+	case actionType.IsEqual(TagActionTypes.Push), actionType.IsEqual(TagActionTypes.DeleteRemote):
+		return append(ret, t.Remote, t.GetRefName())
+	}
+	return append(ret, t.Name)
+}
+
+// タグの完全な参照名を返す (例: refs/tags/v1.0.0)
+func (t Tag) GetRefName() string {
+	return "refs/tags/" + t.Name
+}
+
+// 破壊的なアクションの確認で表示する、失われる可能性がある内容の対象を返す
+// タグの削除はローカルの変更やコミットに影響しない
+func (t Tag) GetDestructiveScope(actionType ActionType) DestructiveScope {
+	if actionType.IsEqual(TagActionTypes.DeleteLocal) || actionType.IsEqual(TagActionTypes.DeleteRemote) {
+		return DestructiveScope{}
+	}
+	return defaultDestructiveScope()
+}
+
+func (t Tag) GetFzfInputForSelectActionType(actionType ActionType) string {
+	// fzfに渡す形式: "表示名\tフルコマンド\t説明文"
+	return fmt.Sprintf("%s\tDescription : %s\tCommand     : %s\n", actionType.Name, actionType.Help, t.GetFullCommand(actionType))
+}
+
+// TagFormat で出力された git for-each-ref refs/tags の結果をパースして、Tag構造体のスライスを返す
+func ParseTags(tags string) ([]*Tag, error) {
+	if strings.TrimSpace(tags) == "" {
+		return []*Tag{}, nil
+	}
+
+	lines := strings.Split(strings.TrimSpace(tags), "\n")
+	result := make([]*Tag, 0, len(lines))
+
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		fields := strings.SplitN(line, "\t", 6)
+		if len(fields) != 6 {
+			// 不正な行はスキップ
+			continue
+		}
+
+		name := fields[0]
+		objectType := fields[1]
+		peeledId := fields[2]
+		objectId := fields[3]
+		taggerDate := fields[4]
+		subject := strings.TrimSpace(fields[5])
+
+		// 注釈付きタグはタグオブジェクトを指すため、参照先のコミットIDを使う
+		annotated := objectType == "tag"
+		commitId := objectId
+		if annotated && peeledId != "" {
+			commitId = peeledId
+		}
+
+		result = append(result, NewTag(name, commitId, annotated, taggerDate, subject))
+	}
+
+	slog.Debug("get tags from git", "tags", result)
+	return result, nil
+}
+
+// 複数選択されたタグをまとめて扱うための型
+type Tags []*Tag
+
+func (ts Tags) GetFullCommand(actionType ActionType) string {
+	options := ts.GetOptionsWithTagNames(actionType)
+	onelineOptions := strings.Join(options, " ")
+
+	fullCommand := fmt.Sprintf("%s %s", actionType.Command, onelineOptions)
+	slog.Debug("Command:", "Command", actionType.Name, "fullCommand", fullCommand)
+
+	return fullCommand
+}
+
+// リポジトリの状態と全てのタグが条件を満たす、複数のタグに実行できるアクションだけを返す
+func (ts Tags) GetAvailableActionTypes(state RepoState) []ActionType {
+	targets := make([]actionTarget, 0, len(ts))
+	for _, t := range ts {
+		targets = append(targets, t)
+	}
+	return filterAvailableActionTypes(FilterMultipleActionTypes(TagActionTypes.All()), state, targets...)
+}
+
+func (ts Tags) GetOptionsWithTagNames(actionType ActionType) []string {
+	ret := actionType.Options
+	// 同名のリモートブランチと区別するため、完全な参照名で指定する
+	if len(ts) > 0 && (actionType.IsEqual(TagActionTypes.Push) || actionType.IsEqual(TagActionTypes.DeleteRemote)) {
+		ret = append(ret, ts[0].Remote)
+		for _, t := range ts {
+			ret = append(ret, t.GetRefName())
+		}
+		return ret
+	}
+	for _, t := range ts {
+		ret = append(ret, t.Name)
+	}
+	return ret
+}
+
+// 破壊的なアクションの確認で表示する、失われる可能性がある内容の対象を返す
+func (ts Tags) GetDestructiveScope(actionType ActionType) DestructiveScope {
+	if actionType.IsEqual(TagActionTypes.DeleteLocal) || actionType.IsEqual(TagActionTypes.DeleteRemote) {
+		return DestructiveScope{}
+	}
+	return defaultDestructiveScope()
+}
+
+func (ts Tags) GetFzfInputForSelectActionType(actionType ActionType) string {
+	// fzfに渡す形式: "表示名\tフルコマンド\t説明文"
+	return fmt.Sprintf("%s\tDescription : %s\tCommand     : %s\n", actionType.Name, actionType.Help, ts.GetFullCommand(actionType))
+}
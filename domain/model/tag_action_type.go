@@ -0,0 +1,146 @@
+package model
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+type TagActionTypeMap struct {
+	Checkout     ActionType
+	Show         ActionType
+	Create       ActionType
+	Annotate     ActionType
+	DeleteLocal  ActionType
+	DeleteRemote ActionType
+	Push         ActionType
+	Unknown      ActionType
+	// 設定ファイルで定義されたアクション
+	Custom []ActionType
+}
+
+var TagActionTypes = TagActionTypeMap{
+	Checkout: ActionType{
+		Name:       "checkout",
+		Command:    "git",
+		Options:    []string{"checkout"},
+		Help:       "Checkout the tag (detached HEAD)",
+		Conditions: []ActionCondition{ConditionNoOperationInProgress},
+	},
+	Show: ActionType{
+		Name:    "show",
+		Command: "git",
+		Options: []string{"show"},
+		Help:    "Show the tag message and the tagged commit",
+	},
+	Create: ActionType{
+		Name:    "create annotated tag",
+		Command: "git",
+		// タグ名は実行時に入力させて末尾に追加する (HEAD にタグを作成する)
+		Options: []string{"tag", "-a"},
+		Help:    "Create an annotated tag at HEAD (the tag message is written in the editor)",
+	},
+	Annotate: ActionType{
+		Name:    "annotate",
+		Command: "git",
+		Options: []string{"tag", "-a", "-f"},
+		Help:    "Replace the lightweight tag with an annotated tag on the same commit",
+		Conditions: []ActionCondition{
+			ConditionLightweightTag,
+		},
+	},
+	DeleteLocal: ActionType{
+		Name:        "delete local",
+		Command:     "git",
+		Options:     []string{"tag", "-d"},
+		Help:        "Delete the local tag",
+		Multiple:    true,
+		Destructive: true,
+	},
+	DeleteRemote: ActionType{
+		Name:        "delete remote",
+		Command:     "git",
+		Options:     []string{"push", "--delete"},
+		Help:        "Delete the tag from the push remote",
+		Multiple:    true,
+		Destructive: true,
+		Conditions: []ActionCondition{
+			ConditionPushRemote,
+		},
+	},
+	Push: ActionType{
+		Name:     "push",
+		Command:  "git",
+		Options:  []string{"push"},
+		Help:     "Push the tag to the push remote",
+		Multiple: true,
+		Conditions: []ActionCondition{
+			ConditionPushRemote,
+		},
+	},
+	Unknown: ActionType{
+		Name:    "unknown",
+		Command: "unknown",
+		Options: nil,
+		Help:    "unknown",
+	},
+}
+
+func (t TagActionTypeMap) All() []ActionType {
+	builtins := []ActionType{
+		t.Checkout,
+		t.Show,
+		t.Create,
+		t.Annotate,
+		t.Push,
+		t.DeleteLocal,
+		t.DeleteRemote,
+	}
+	return mergeCustomActionTypes(builtins, t.Custom)
+}
+
+func (t TagActionTypeMap) GetTagActionTypes(action string) (ActionType, error) {
+	// 組み込みのアクションと同じ名前の独自のアクションは、組み込みのアクションを置き換える
+	if custom, ok := findCustomActionType(t.Custom, action); ok {
+		return custom, nil
+	}
+
+	switch action {
+	case "checkout":
+		return t.Checkout, nil
+	case "show":
+		return t.Show, nil
+	case "create annotated tag":
+		return t.Create, nil
+	case "annotate":
+		return t.Annotate, nil
+	case "delete local":
+		return t.DeleteLocal, nil
+	case "delete remote":
+		return t.DeleteRemote, nil
+	case "push":
+		return t.Push, nil
+	default:
+		return t.Unknown, fmt.Errorf("unknown action: %s", action)
+	}
+}
+
+func ParseSelectedTagActionType(selectedLine string) (ActionType, error) {
+	slog.Debug("Selected action from fzf", "selected", selectedLine)
+	if selectedLine == "" {
+		slog.Debug("No action selected")
+		return TagActionTypes.Unknown, nil
+	}
+
+	// タブで分割
+	fields := strings.Split(selectedLine, "\t")
+
+	// 最初のフィールドだけ取得
+	selectedActionType := fields[0]
+
+	result, err := TagActionTypes.GetTagActionTypes(selectedActionType)
+	if err != nil {
+		return TagActionTypes.Unknown, err
+	}
+	return result, nil
+}
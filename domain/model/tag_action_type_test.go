@@ -0,0 +1,187 @@
+package model
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestTagActionTypeMap_GetTagActionTypes(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		action string
+	}
+	tests := []struct {
+		name           string
+		args           args
+		want           ActionType
+		wantErr        bool
+		wantErrMessage error
+	}{
+		{
+			name: "対応するタグアクション(checkout)を取得すること",
+			args: args{
+				action: "checkout",
+			},
+			want:           TagActionTypes.Checkout,
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "対応するタグアクション(show)を取得すること",
+			args: args{
+				action: "show",
+			},
+			want:           TagActionTypes.Show,
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "対応するタグアクション(create annotated tag)を取得すること",
+			args: args{
+				action: "create annotated tag",
+			},
+			want:           TagActionTypes.Create,
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "対応するタグアクション(annotate)を取得すること",
+			args: args{
+				action: "annotate",
+			},
+			want:           TagActionTypes.Annotate,
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "対応するタグアクション(delete local)を取得すること",
+			args: args{
+				action: "delete local",
+			},
+			want:           TagActionTypes.DeleteLocal,
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "対応するタグアクション(delete remote)を取得すること",
+			args: args{
+				action: "delete remote",
+			},
+			want:           TagActionTypes.DeleteRemote,
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "対応するタグアクション(push)を取得すること",
+			args: args{
+				action: "push",
+			},
+			want:           TagActionTypes.Push,
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "不明なアクションが指定された場合、errorを返却すること",
+			args: args{
+				action: "dummy",
+			},
+			want:           TagActionTypes.Unknown,
+			wantErr:        true,
+			wantErrMessage: fmt.Errorf("unknown action: %s", "dummy"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt := tt
+			got, err := TagActionTypes.GetTagActionTypes(tt.args.action)
+			if (err != nil) != tt.wantErr || err != nil && err.Error() != tt.wantErrMessage.Error() {
+				t.Errorf("TagActionTypeMap.GetTagActionTypes() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("TagActionTypeMap.GetTagActionTypes() error message = %v, wantErrMessage %v", err, tt.wantErrMessage)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TagActionTypeMap.GetTagActionTypes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTagActionTypeMap_All(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		want []ActionType
+	}{
+		{
+			name: "Unknown以外の全てのタグアクションを取得すること",
+			want: []ActionType{
+				TagActionTypes.Checkout,
+				TagActionTypes.Show,
+				TagActionTypes.Create,
+				TagActionTypes.Annotate,
+				TagActionTypes.Push,
+				TagActionTypes.DeleteLocal,
+				TagActionTypes.DeleteRemote,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt := tt
+			if got := TagActionTypes.All(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TagActionTypeMap.All() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSelectedTagActionType(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		selectedLine string
+	}
+	tests := []struct {
+		name           string
+		args           args
+		want           ActionType
+		wantErr        bool
+		wantErrMessage error
+	}{
+		{
+			name: "fzfの選択結果を元に、対応するタグアクションを取得すること",
+			args: args{
+				selectedLine: "push\tDescription : hogehoge\tCommand     : fugafuga\n",
+			},
+			want:           TagActionTypes.Push,
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "不明な文字列が指定された場合、Unknownを返却すること",
+			args: args{
+				selectedLine: "dummy\tDescription : hogehoge\tCommand     : fugafuga\n",
+			},
+			want:           TagActionTypes.Unknown,
+			wantErr:        true,
+			wantErrMessage: fmt.Errorf("unknown action: %s", "dummy"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt := tt
+			got, err := ParseSelectedTagActionType(tt.args.selectedLine)
+			if (err != nil) != tt.wantErr || err != nil && err.Error() != tt.wantErrMessage.Error() {
+				t.Errorf("ParseSelectedTagActionType() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("ParseSelectedTagActionType() error = %v, wantErrMessage %v", err, tt.wantErrMessage)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSelectedTagActionType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,311 @@
+package model
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestParseTags(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		tags string
+	}
+	tests := []struct {
+		name string
+		args args
+		want []*Tag
+	}{
+		{
+			name: "注釈付きタグと軽量タグをパースできること",
+			args: args{
+				tags: "v2\ttag\ted412de\t5e2aa26\t2025-01-02\trelease two\nv1\tcommit\t\ted412de\t\tinit\n",
+			},
+			want: []*Tag{
+				NewTag("v2", "ed412de", true, "2025-01-02", "release two"),
+				NewTag("v1", "ed412de", false, "", "init"),
+			},
+		},
+		{
+			name: "不正な行はスキップすること",
+			args: args{
+				tags: "invalid line\nv1\tcommit\t\ted412de\t\tinit\n",
+			},
+			want: []*Tag{
+				NewTag("v1", "ed412de", false, "", "init"),
+			},
+		},
+		{
+			name: "タグが存在しない場合は空のスライスを返すこと",
+			args: args{
+				tags: "",
+			},
+			want: []*Tag{},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseTags(tt.args.tags)
+			if err != nil {
+				t.Errorf("ParseTags() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseTags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindTagByName(t *testing.T) {
+	t.Parallel()
+	tags := []*Tag{
+		NewTag("v1", "aaaaaaa", false, "", "init"),
+		NewTag("v2", "bbbbbbb", true, "2025-01-02", "release two"),
+	}
+	tests := []struct {
+		name           string
+		tagName        string
+		want           *Tag
+		wantErr        bool
+		wantErrMessage error
+	}{
+		{
+			name:    "タグ名を指定してタグを取得すること",
+			tagName: "v2",
+			want:    NewTag("v2", "bbbbbbb", true, "2025-01-02", "release two"),
+			wantErr: false,
+		},
+		{
+			name:           "指定したタグ名に該当するタグが存在しない場合にエラーを返すこと",
+			tagName:        "dummy",
+			want:           nil,
+			wantErr:        true,
+			wantErrMessage: fmt.Errorf("tag %s not found", "dummy"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := FindTagByName(tags, tt.tagName)
+			if (err != nil) != tt.wantErr || err != nil && err.Error() != tt.wantErrMessage.Error() {
+				t.Errorf("FindTagByName() error = %v, wantErrMessage %v", err, tt.wantErrMessage)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindTagByName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTag_GetFzfLine(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		tag  *Tag
+		want string
+	}{
+		{
+			name: "注釈付きタグの表示行を生成できること",
+			tag:  NewTag("v2", "ed412de", true, "2025-01-02", "release two"),
+			want: "v2 ed412de annotated   2025-01-02 release two",
+		},
+		{
+			name: "軽量タグの表示行を生成できること",
+			tag:  NewTag("v1", "ed412de", false, "", "init"),
+			want: "v1 ed412de lightweight  init",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.tag.GetFzfLine(); got != tt.want {
+				t.Errorf("Tag.GetFzfLine() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTag_GetFzfInputForSelectActionType(t *testing.T) {
+	t.Parallel()
+	remoteTag := NewTag("v1", "ed412de", false, "", "init")
+	remoteTag.Remote = "upstream"
+	type args struct {
+		actionType ActionType
+	}
+	tests := []struct {
+		name string
+		tag  *Tag
+		args args
+		want string
+	}{
+		{
+			name: "push先のリモートとタグの完全な参照名を含むコマンドを表示すること",
+			tag:  remoteTag,
+			args: args{
+				actionType: TagActionTypes.DeleteRemote,
+			},
+			want: "delete remote\tDescription : Delete the tag from the push remote\tCommand     : git push --delete upstream refs/tags/v1\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.tag.GetFzfInputForSelectActionType(tt.args.actionType); got != tt.want {
+				t.Errorf("Tag.GetFzfInputForSelectActionType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTag_GetOptionsWithTagName(t *testing.T) {
+	t.Parallel()
+	tag := NewTag("v1", "ed412de", false, "", "init")
+	tag.Remote = "upstream"
+	type args struct {
+		actionType ActionType
+	}
+	tests := []struct {
+		name string
+		args args
+		want []string
+	}{
+		{
+			name: "pushの場合は同名のブランチと区別するため、リモートとタグの完全な参照名を指定すること",
+			args: args{
+				actionType: TagActionTypes.Push,
+			},
+			want: []string{"push", "upstream", "refs/tags/v1"},
+		},
+		{
+			name: "delete remoteの場合は同名のブランチを削除しないよう、リモートとタグの完全な参照名を指定すること",
+			args: args{
+				actionType: TagActionTypes.DeleteRemote,
+			},
+			want: []string{"push", "--delete", "upstream", "refs/tags/v1"},
+		},
+		{
+			name: "annotateの場合はタグ名と同じコミットを指定すること",
+			args: args{
+				actionType: TagActionTypes.Annotate,
+			},
+			want: []string{"tag", "-a", "-f", "v1", "ed412de"},
+		},
+		{
+			name: "create annotated tagの場合は選択したタグを指定しないこと",
+			args: args{
+				actionType: TagActionTypes.Create.WithOptions("v2"),
+			},
+			want: []string{"tag", "-a", "v2"},
+		},
+		{
+			name: "それ以外の場合はタグ名だけを指定すること",
+			args: args{
+				actionType: TagActionTypes.DeleteLocal,
+			},
+			want: []string{"tag", "-d", "v1"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tag.GetOptionsWithTagName(tt.args.actionType); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Tag.GetOptionsWithTagName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTag_GetAvailableActionTypes(t *testing.T) {
+	t.Parallel()
+	lightweight := NewTag("v1", "ed412de", false, "", "init")
+	lightweight.Remote = "origin"
+	annotated := NewTag("v2", "ed412de", true, "2025-01-02", "release two")
+	tests := []struct {
+		name string
+		tag  *Tag
+		want []ActionType
+	}{
+		{
+			name: "push先のリモートがある軽量タグの場合は全てのアクションを表示すること",
+			tag:  lightweight,
+			want: TagActionTypes.All(),
+		},
+		{
+			name: "push先のリモートがない注釈付きタグの場合はannotateとリモートへのアクションを表示しないこと",
+			tag:  annotated,
+			want: []ActionType{
+				TagActionTypes.Checkout,
+				TagActionTypes.Show,
+				TagActionTypes.Create,
+				TagActionTypes.DeleteLocal,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.tag.GetAvailableActionTypes(RepoState{}); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Tag.GetAvailableActionTypes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTags_GetFullCommand(t *testing.T) {
+	t.Parallel()
+	tags := Tags{
+		NewTag("v1", "aaaaaaa", false, "", "init"),
+		NewTag("v2", "bbbbbbb", true, "2025-01-02", "release two"),
+	}
+	for _, tag := range tags {
+		tag.Remote = "upstream"
+	}
+	type args struct {
+		actionType ActionType
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "delete remoteの場合はリモートの後に全てのタグの完全な参照名を指定すること",
+			args: args{
+				actionType: TagActionTypes.DeleteRemote,
+			},
+			want: "git push --delete upstream refs/tags/v1 refs/tags/v2",
+		},
+		{
+			name: "pushの場合はリモートの後に全てのタグの完全な参照名を指定すること",
+			args: args{
+				actionType: TagActionTypes.Push,
+			},
+			want: "git push upstream refs/tags/v1 refs/tags/v2",
+		},
+		{
+			name: "delete localの場合は全てのタグ名を指定すること",
+			args: args{
+				actionType: TagActionTypes.DeleteLocal,
+			},
+			want: "git tag -d v1 v2",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tags.GetFullCommand(tt.args.actionType); got != tt.want {
+				t.Errorf("Tags.GetFullCommand() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
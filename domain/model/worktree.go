@@ -0,0 +1,204 @@
+package model
+
+import (
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"strings"
+)
+
+// git worktree listで対象となったワークツリーを表す構造体
+type Worktree struct {
+	Path     string
+	Head     string
+	Branch   string
+	Detached bool
+	Bare     bool
+	// メインのワークツリーか (git worktree list の先頭)
+	Main        bool
+	Locked      bool
+	LockReason  string
+	Prunable    bool
+	ActionTypes []ActionType
+}
+
+func NewWorktree(path string, head string, branch string) *Worktree {
+	return &Worktree{
+		Path:        path,
+		Head:        head,
+		Branch:      branch,
+		ActionTypes: WorktreeActionTypes.All(),
+	}
+}
+
+func (w Worktree) String() string {
+	return w.Path
+}
+
+// --list で出力する項目を返す
+func (w Worktree) GetListItem() ListItem {
+	return ListItem{
+		{Key: "path", Value: w.Path},
+		{Key: "head", Value: w.Head},
+		{Key: "branch", Value: w.Branch},
+		{Key: "detached", Value: w.Detached},
+		{Key: "bare", Value: w.Bare},
+		{Key: "locked", Value: w.Locked},
+		{Key: "lock_reason", Value: w.LockReason},
+		{Key: "prunable", Value: w.Prunable},
+	}
+}
+
+func FindWorktreeByPath(worktrees []*Worktree, path string) (*Worktree, error) {
+	for _, worktree := range worktrees {
+		if worktree.Path == path {
+			return worktree, nil
+		}
+	}
+	return nil, fmt.Errorf("worktree %s not found", path)
+}
+
+// fzfの候補として表示する1行を返す
+// 形式: "パス\tコミットID\tブランチ(状態)"
+func (w Worktree) GetFzfLine() string {
+	head := w.Head
+	if len(head) > 7 {
+		head = head[:7]
+	}
+
+	ref := w.Branch
+	switch {
+	case w.Bare:
+		ref = "(bare)"
+	case w.Detached:
+		ref = "(detached HEAD)"
+	}
+
+	var states []string
+	if w.Locked {
+		states = append(states, "locked")
+	}
+	if w.Prunable {
+		states = append(states, "prunable")
+	}
+	if len(states) > 0 {
+		ref = fmt.Sprintf("%s [%s]", ref, strings.Join(states, ", "))
+	}
+
+	return fmt.Sprintf("%s\t%s\t%s", w.Path, head, ref)
+}
+
+func (w Worktree) GetFullCommand(actionType ActionType) string {
+	options := w.GetOptionsWithWorktreeInfo(actionType)
+	onelineOptions := strings.Join(options, " ")
+
+	fullCommand := fmt.Sprintf("%s %s", actionType.Command, onelineOptions)
+	slog.Debug("Command:", "Command", actionType.Name, "fullCommand", fullCommand)
+
+	return fullCommand
+}
+
+func (w Worktree) GetOptionsWithWorktreeInfo(actionType ActionType) []string {
+	if actionType.Custom {
+		return actionType.GetCustomOptions(w)
+	}
+
+	ret := actionType.Options
+
+	// prune と add は選択したワークツリーを引数に取らない
+	if actionType.IsEqual(WorktreeActionTypes.Prune) || actionType.IsEqual(WorktreeActionTypes.Add) {
+		return ret
+	}
+	ret = append(ret, w.Path)
+	return ret
+}
+
+// 破壊的なアクションの確認で表示する、失われる可能性がある内容の対象を返す
+// 削除は対象のワークツリーの未コミットの変更が失われる
+func (w Worktree) GetDestructiveScope(actionType ActionType) DestructiveScope {
+	if actionType.IsEqual(WorktreeActionTypes.Remove) {
+		return DestructiveScope{Worktree: w.Path}
+	}
+	return defaultDestructiveScope()
+}
+
+func (w Worktree) GetFzfInputForSelectActionType(actionType ActionType) string {
+	// fzfに渡す形式: "表示名\tフルコマンド\t説明文"
+	return fmt.Sprintf("%s\tDescription : %s\tCommand     : %s\n", actionType.Name, actionType.Help, w.GetFullCommand(actionType))
+}
+
+// git worktree list --porcelain の形式をパースして、Worktree構造体のスライスを返す
+func ParseWorktrees(worktrees string) ([]*Worktree, error) {
+	var result []*Worktree
+	var current *Worktree
+
+	// ワークツリーごとに空行で区切られている
+	for _, line := range strings.Split(worktrees, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if line == "" {
+			current = nil
+			continue
+		}
+
+		key, value, _ := strings.Cut(line, " ")
+		if key == "worktree" {
+			current = NewWorktree(value, "", "")
+			current.Main = len(result) == 0
+			result = append(result, current)
+			continue
+		}
+		if current == nil {
+			// worktree行より前の属性は不正なためスキップ
+			continue
+		}
+
+		switch key {
+		case "HEAD":
+			current.Head = value
+		case "branch":
+			current.Branch = strings.TrimPrefix(value, "refs/heads/")
+		case "detached":
+			current.Detached = true
+		case "bare":
+			current.Bare = true
+		case "locked":
+			current.Locked = true
+			current.LockReason = value
+		case "prunable":
+			current.Prunable = true
+		}
+	}
+
+	slog.Debug("get worktrees from git", "worktrees", result)
+	return result, nil
+}
+
+// リポジトリの状態とワークツリーが条件を満たすアクションだけを返す
+func (w Worktree) GetAvailableActionTypes(state RepoState) []ActionType {
+	return filterAvailableActionTypes(w.ActionTypes, state, w)
+}
+
+// ワークツリーの種類やロックの状態がアクションの条件を満たすか
+func (w Worktree) meetsCondition(condition ActionCondition, state RepoState) bool {
+	switch condition {
+	case ConditionNotMainWorktree:
+		return !w.Main
+	case ConditionLockedWorktree:
+		return w.Locked
+	case ConditionUnlockedWorktree:
+		return !w.Locked
+	}
+	return true
+}
+
+// fzfで選択された行からワークツリーのパスを取り出す
+func ParseSelectedWorktreePath(selectedLine string) string {
+	return strings.SplitN(selectedLine, "\t", 2)[0]
+}
+
+// ブランチからワークツリーを作成する際のデフォルトのパスを返す
+// リポジトリと同じ階層に "<リポジトリ名>-<ブランチ名>" のディレクトリを作る
+func DefaultWorktreePath(topLevelDir string, branchName string) string {
+	name := strings.ReplaceAll(branchName, "/", "-")
+	return filepath.Join(filepath.Dir(topLevelDir), fmt.Sprintf("%s-%s", filepath.Base(topLevelDir), name))
+}
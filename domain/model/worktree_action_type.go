@@ -0,0 +1,124 @@
+package model
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+type WorktreeActionTypeMap struct {
+	PrintPath ActionType
+	Add       ActionType
+	Remove    ActionType
+	Lock      ActionType
+	Unlock    ActionType
+	Prune     ActionType
+	Unknown   ActionType
+	// 設定ファイルで定義されたアクション
+	Custom []ActionType
+}
+
+var WorktreeActionTypes = WorktreeActionTypeMap{
+	PrintPath: ActionType{
+		Name:    "print path",
+		Command: "echo",
+		Options: nil,
+		Help:    "print worktree path (e.g. cd $(gitman worktree))",
+	},
+	Add: ActionType{
+		Name:    "add from branch",
+		Command: "git",
+		Options: []string{"worktree", "add"},
+		Help:    "Create a new worktree from a selected branch",
+	},
+	Remove: ActionType{
+		Name:        "remove",
+		Command:     "git",
+		Options:     []string{"worktree", "remove"},
+		Help:        "Remove the worktree",
+		Destructive: true,
+		Conditions:  []ActionCondition{ConditionNotMainWorktree, ConditionUnlockedWorktree},
+	},
+	Lock: ActionType{
+		Name:       "lock",
+		Command:    "git",
+		Options:    []string{"worktree", "lock"},
+		Help:       "Lock the worktree to prevent it from being pruned",
+		Conditions: []ActionCondition{ConditionNotMainWorktree, ConditionUnlockedWorktree},
+	},
+	Unlock: ActionType{
+		Name:       "unlock",
+		Command:    "git",
+		Options:    []string{"worktree", "unlock"},
+		Help:       "Unlock the worktree",
+		Conditions: []ActionCondition{ConditionNotMainWorktree, ConditionLockedWorktree},
+	},
+	Prune: ActionType{
+		Name:    "prune",
+		Command: "git",
+		Options: []string{"worktree", "prune", "--verbose"},
+		Help:    "Prune worktree information whose directories no longer exist",
+	},
+	Unknown: ActionType{
+		Name:    "unknown",
+		Command: "unknown",
+		Options: nil,
+		Help:    "unknown",
+	},
+}
+
+func (w WorktreeActionTypeMap) All() []ActionType {
+	builtins := []ActionType{
+		w.PrintPath,
+		w.Add,
+		w.Remove,
+		w.Lock,
+		w.Unlock,
+		w.Prune,
+	}
+	return mergeCustomActionTypes(builtins, w.Custom)
+}
+
+func (w WorktreeActionTypeMap) GetWorktreeActionTypes(action string) (ActionType, error) {
+	// 組み込みのアクションと同じ名前の独自のアクションは、組み込みのアクションを置き換える
+	if custom, ok := findCustomActionType(w.Custom, action); ok {
+		return custom, nil
+	}
+
+	switch action {
+	case "print path":
+		return w.PrintPath, nil
+	case "add from branch":
+		return w.Add, nil
+	case "remove":
+		return w.Remove, nil
+	case "lock":
+		return w.Lock, nil
+	case "unlock":
+		return w.Unlock, nil
+	case "prune":
+		return w.Prune, nil
+	default:
+		return w.Unknown, fmt.Errorf("unknown action: %s", action)
+	}
+}
+
+func ParseSelectedWorktreeActionType(selectedLine string) (ActionType, error) {
+	slog.Debug("Selected action from fzf", "selected", selectedLine)
+	if selectedLine == "" {
+		slog.Debug("No action selected")
+		return WorktreeActionTypes.Unknown, nil
+	}
+
+	// タブで分割
+	fields := strings.Split(selectedLine, "\t")
+
+	// 最初のフィールドだけ取得
+	selectedActionType := fields[0]
+
+	result, err := WorktreeActionTypes.GetWorktreeActionTypes(selectedActionType)
+	if err != nil {
+		return WorktreeActionTypes.Unknown, err
+	}
+	return result, nil
+}
@@ -0,0 +1,46 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWorktreeActionTypeMap_GetWorktreeActionTypes(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		action  string
+		want    ActionType
+		wantErr bool
+	}{
+		{name: "対応するワークツリーアクション(print path)を取得すること", action: "print path", want: WorktreeActionTypes.PrintPath},
+		{name: "対応するワークツリーアクション(add from branch)を取得すること", action: "add from branch", want: WorktreeActionTypes.Add},
+		{name: "対応するワークツリーアクション(remove)を取得すること", action: "remove", want: WorktreeActionTypes.Remove},
+		{name: "対応するワークツリーアクション(lock)を取得すること", action: "lock", want: WorktreeActionTypes.Lock},
+		{name: "対応するワークツリーアクション(unlock)を取得すること", action: "unlock", want: WorktreeActionTypes.Unlock},
+		{name: "対応するワークツリーアクション(prune)を取得すること", action: "prune", want: WorktreeActionTypes.Prune},
+		{name: "不明なアクションが指定された場合、errorを返却すること", action: "dummy", want: WorktreeActionTypes.Unknown, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := WorktreeActionTypes.GetWorktreeActionTypes(tt.action)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WorktreeActionTypeMap.GetWorktreeActionTypes() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("WorktreeActionTypeMap.GetWorktreeActionTypes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSelectedWorktreeActionType(t *testing.T) {
+	t.Parallel()
+	got, err := ParseSelectedWorktreeActionType("remove\tDescription : hogehoge\tCommand     : fugafuga\n")
+	if err != nil || !reflect.DeepEqual(got, WorktreeActionTypes.Remove) {
+		t.Errorf("ParseSelectedWorktreeActionType() = %v, %v, want %v", got, err, WorktreeActionTypes.Remove)
+	}
+}
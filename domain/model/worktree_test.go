@@ -0,0 +1,239 @@
+package model
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestWorktree_GetAvailableActionTypes(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		worktree Worktree
+		state    RepoState
+	}
+	tests := []struct {
+		name string
+		args args
+		want []ActionType
+	}{
+		{
+			name: "メインのワークツリーは削除やロックができないこと",
+			args: args{
+				worktree: Worktree{Path: "/repo/gitman", Branch: "main", Main: true, ActionTypes: WorktreeActionTypes.All()},
+				state:    RepoState{},
+			},
+			want: []ActionType{
+				WorktreeActionTypes.PrintPath,
+				WorktreeActionTypes.Add,
+				WorktreeActionTypes.Prune,
+			},
+		},
+		{
+			name: "ロックされていないワークツリーは削除とロックができること",
+			args: args{
+				worktree: Worktree{Path: "/repo/gitman-feature", Branch: "feature", ActionTypes: WorktreeActionTypes.All()},
+				state:    RepoState{},
+			},
+			want: []ActionType{
+				WorktreeActionTypes.PrintPath,
+				WorktreeActionTypes.Add,
+				WorktreeActionTypes.Remove,
+				WorktreeActionTypes.Lock,
+				WorktreeActionTypes.Prune,
+			},
+		},
+		{
+			name: "ロックされたワークツリーはロックの解除だけができること",
+			args: args{
+				worktree: Worktree{Path: "/repo/gitman-feature", Branch: "feature", Locked: true, ActionTypes: WorktreeActionTypes.All()},
+				state:    RepoState{},
+			},
+			want: []ActionType{
+				WorktreeActionTypes.PrintPath,
+				WorktreeActionTypes.Add,
+				WorktreeActionTypes.Unlock,
+				WorktreeActionTypes.Prune,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.args.worktree.GetAvailableActionTypes(tt.args.state); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Worktree.GetAvailableActionTypes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseWorktrees(t *testing.T) {
+	t.Parallel()
+
+	primary := NewWorktree("/repo/gitman", "ed412dee217c63f70e11d4577003ab73ee67c45f", "main")
+	primary.Main = true
+	review := NewWorktree("/repo/gitman-review", "5e2aa26fa1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6", "")
+	review.Detached = true
+	review.Locked = true
+	review.LockReason = "busy"
+	bare := NewWorktree("/repo/gitman.git", "", "")
+	bare.Bare = true
+	gone := NewWorktree("/repo/gitman-gone", "ed412dee217c63f70e11d4577003ab73ee67c45f", "feature/gone")
+	gone.Prunable = true
+
+	tests := []struct {
+		name      string
+		worktrees string
+		want      []*Worktree
+	}{
+		{
+			name: "git worktree list --porcelainの出力をパースできること",
+			worktrees: "worktree /repo/gitman\nHEAD ed412dee217c63f70e11d4577003ab73ee67c45f\nbranch refs/heads/main\n\n" +
+				"worktree /repo/gitman-review\nHEAD 5e2aa26fa1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6\ndetached\nlocked busy\n\n" +
+				"worktree /repo/gitman.git\nbare\n\n" +
+				"worktree /repo/gitman-gone\nHEAD ed412dee217c63f70e11d4577003ab73ee67c45f\nbranch refs/heads/feature/gone\nprunable gitdir file points to non-existent location\n\n",
+			want: []*Worktree{primary, review, bare, gone},
+		},
+		{
+			name:      "ワークツリーが存在しない場合はnilを返すこと",
+			worktrees: "",
+			want:      nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseWorktrees(tt.worktrees)
+			if err != nil {
+				t.Errorf("ParseWorktrees() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseWorktrees() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindWorktreeByPath(t *testing.T) {
+	t.Parallel()
+	worktrees := []*Worktree{
+		NewWorktree("/repo/gitman", "aaaaaaa", "main"),
+		NewWorktree("/repo/gitman-review", "bbbbbbb", "review"),
+	}
+
+	got, err := FindWorktreeByPath(worktrees, "/repo/gitman-review")
+	if err != nil || !reflect.DeepEqual(got, worktrees[1]) {
+		t.Errorf("FindWorktreeByPath() = %v, %v, want %v", got, err, worktrees[1])
+	}
+
+	_, err = FindWorktreeByPath(worktrees, "/dummy")
+	if want := fmt.Errorf("worktree %s not found", "/dummy"); err == nil || err.Error() != want.Error() {
+		t.Errorf("FindWorktreeByPath() error = %v, want %v", err, want)
+	}
+}
+
+func TestWorktree_GetFzfLine(t *testing.T) {
+	t.Parallel()
+	locked := NewWorktree("/repo/gitman-review", "5e2aa26fa1c2d3e4", "")
+	locked.Detached = true
+	locked.Locked = true
+
+	tests := []struct {
+		name     string
+		worktree *Worktree
+		want     string
+	}{
+		{
+			name:     "ブランチをチェックアウトしているワークツリーの表示行を生成できること",
+			worktree: NewWorktree("/repo/gitman", "ed412dee217c63f7", "main"),
+			want:     "/repo/gitman\ted412de\tmain",
+		},
+		{
+			name:     "detached HEADかつロックされたワークツリーの表示行を生成できること",
+			worktree: locked,
+			want:     "/repo/gitman-review\t5e2aa26\t(detached HEAD) [locked]",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.worktree.GetFzfLine(); got != tt.want {
+				t.Errorf("Worktree.GetFzfLine() = %q, want %q", got, tt.want)
+			}
+			if got := ParseSelectedWorktreePath(tt.worktree.GetFzfLine()); got != tt.worktree.Path {
+				t.Errorf("ParseSelectedWorktreePath() = %q, want %q", got, tt.worktree.Path)
+			}
+		})
+	}
+}
+
+func TestWorktree_GetFullCommand(t *testing.T) {
+	t.Parallel()
+	worktree := NewWorktree("/repo/gitman-review", "5e2aa26", "review")
+	tests := []struct {
+		name       string
+		actionType ActionType
+		want       string
+	}{
+		{
+			name:       "ワークツリーのパスを引数に含めること",
+			actionType: WorktreeActionTypes.Remove,
+			want:       "git worktree remove /repo/gitman-review",
+		},
+		{
+			name:       "pruneはワークツリーのパスを引数に含めないこと",
+			actionType: WorktreeActionTypes.Prune,
+			want:       "git worktree prune --verbose",
+		},
+		{
+			name:       "print pathはパスを出力すること",
+			actionType: WorktreeActionTypes.PrintPath,
+			want:       "echo /repo/gitman-review",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := worktree.GetFullCommand(tt.actionType); got != tt.want {
+				t.Errorf("Worktree.GetFullCommand() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultWorktreePath(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		topLevelDir string
+		branchName  string
+		want        string
+	}{
+		{
+			name:        "リポジトリと同じ階層にブランチ名のディレクトリを作ること",
+			topLevelDir: "/home/user/gitman",
+			branchName:  "feature/login",
+			want:        "/home/user/gitman-feature-login",
+		},
+		{
+			name:        "リモート追跡ブランチの場合はリモート名を含めること",
+			topLevelDir: "/home/user/gitman",
+			branchName:  "origin/fix",
+			want:        "/home/user/gitman-origin-fix",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := DefaultWorktreePath(tt.topLevelDir, tt.branchName); got != tt.want {
+				t.Errorf("DefaultWorktreePath() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
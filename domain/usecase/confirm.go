@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"fmt"
+	"gitman/domain/model"
+	"gitman/infrastructure/fzf"
+	"gitman/infrastructure/git"
+)
+
+// 確認が必要なアクションの場合は実行してよいかユーザに確認する
+// 破壊的なアクションの場合は、アクションの対象ごとに scope で決めた失われる可能性がある内容を表示する
+// confirmDestructive が false の場合は破壊的なアクションでも確認しない
+// 確認が不要なアクションの場合は常に true を返す
+func confirmAction(fm fzf.FzfManager, gm git.GitManager, confirmDestructive bool, actionType model.ActionType, fullCommand string, scope model.DestructiveScope) (bool, error) {
+	destructive := actionType.Destructive && confirmDestructive
+	if !actionType.Confirm && !destructive {
+		return true, nil
+	}
+
+	if destructive {
+		return confirmDestructiveAction(fm, gm, fullCommand, scope)
+	}
+	return fm.Confirm(fmt.Sprintf("Run '%s' ?", fullCommand))
+}
+
+// 失われる可能性がある内容を表示して、実行してよいかユーザに確認する
+// 設定で破壊的なアクションの確認を無効にしていても確認する
+func confirmDestructiveAction(fm fzf.FzfManager, gm git.GitManager, fullCommand string, scope model.DestructiveScope) (bool, error) {
+	// タグの削除など、ローカルの変更やコミットに影響しない場合は要約を表示しない
+	if scope.IsEmpty() {
+		return fm.Confirm(fmt.Sprintf("Run '%s' ?", fullCommand))
+	}
+
+	summary, err := gm.GetDestructiveSummary(scope)
+	if err != nil {
+		return false, err
+	}
+	return fm.Confirm(fmt.Sprintf("%s\n\nRun '%s' ?", summary, fullCommand))
+}
@@ -1,33 +1,74 @@
 package usecase
 
 import (
+	"fmt"
+	"gitman/common"
 	"gitman/domain/model"
 	"gitman/infrastructure/fzf"
 	"gitman/infrastructure/git"
+	"strconv"
+	"time"
 )
 
 type GitBranchUsecase struct {
 	fzfManager fzf.FzfManager
 	gitManager git.GitManager
+	// 破壊的なアクションの実行前に、失われる可能性がある内容を表示して確認するか
+	confirmDestructive bool
+	// 不要なブランチを探すときにマージ済みか判定する基準のブランチ (空の場合はリモートのデフォルトブランチ)
+	pruneBase string
 }
 
-func NewGitBranchUsecase(fm fzf.FzfManager, gm git.GitManager) GitBranchUsecase {
+func NewGitBranchUsecase(fm fzf.FzfManager, gm git.GitManager, pruneBase string, confirmDestructive bool) GitBranchUsecase {
 	return GitBranchUsecase{
-		fzfManager: fm,
-		gitManager: gm,
+		fzfManager:         fm,
+		gitManager:         gm,
+		confirmDestructive: confirmDestructive,
+		pruneBase:          pruneBase,
 	}
 }
 
 func (gau GitBranchUsecase) InteractiveBranchAction() error {
-	targeBranch, err := gau.getBranch()
+	targetBranches, err := gau.getBranches()
 	if err != nil {
 		return err
 	}
-	// コミットIDの選択をキャンセルした等の理由でnilとなった場合は何もしない
-	if targeBranch == nil {
+	// ブランチの選択をキャンセルした等の理由で空となった場合は何もしない
+	if len(targetBranches) == 0 {
 		return nil
 	}
 
+	// ブランチの種類やリポジトリの状態に応じて実行できるアクションのみ選択させる
+	state, err := getRepoState(gau.gitManager)
+	if err != nil {
+		return err
+	}
+
+	// 複数選択された場合はまとめて実行できるアクションのみ選択させる
+	if len(targetBranches) > 1 {
+		actionType, err := gau.fzfManager.SelectBranchesAction(targetBranches, targetBranches.GetAvailableActionTypes(state))
+		if err != nil {
+			return err
+		}
+		if actionType.IsEqual(model.BranchActionTypes.Unknown) {
+			return nil
+		}
+
+		// 独自のアクションも含めて、保護されたブランチは強制的に push させない
+		if err := targetBranches.CheckForcePush(actionType, state.ProtectedBranches); err != nil {
+			return err
+		}
+
+		ok, err := confirmAction(gau.fzfManager, gau.gitManager, gau.confirmDestructive, actionType, targetBranches.GetFullCommand(actionType), targetBranches.GetDestructiveScope(actionType))
+		if err != nil || !ok {
+			return err
+		}
+
+		return gau.gitManager.ExecuteBranchesActionCommand(actionType, targetBranches)
+	}
+
+	targeBranch := targetBranches[0]
+	targeBranch.ActionTypes = targeBranch.GetAvailableActionTypes(state)
 	actionType, err := gau.fzfManager.SelectBranchAction(targeBranch)
 	if err != nil {
 		return err
@@ -36,25 +77,245 @@ func (gau GitBranchUsecase) InteractiveBranchAction() error {
 		return nil
 	}
 
-	gau.gitManager.ExecuteBranchActionCommand(actionType, targeBranch)
+	switch {
+	// ブランチを作成する場合は新しいブランチ名を入力させる
+	case actionType.IsEqual(model.BranchActionTypes.Create):
+		defaultName := ""
+		if targeBranch.Remote {
+			defaultName = targeBranch.GetLocalName()
+		}
+		branchName, err := inputBranchName(gau.fzfManager, gau.gitManager, defaultName)
+		if err != nil {
+			return err
+		}
+		if branchName == "" {
+			return nil
+		}
+		actionType = actionType.WithOptions(branchName)
+	// 名前を変更する場合は変更後のブランチ名を入力させる
+	case actionType.IsEqual(model.BranchActionTypes.Rename):
+		branchName, err := inputBranchName(gau.fzfManager, gau.gitManager, targeBranch.Name)
+		if err != nil {
+			return err
+		}
+		if branchName == "" || branchName == targeBranch.Name {
+			return nil
+		}
+		actionType = actionType.WithTrailingOptions(branchName)
+	// 上流ブランチを設定して push する場合は push 先のリモートを選択させる
+	case actionType.IsEqual(model.BranchActionTypes.PushSetUpstream):
+		remote, err := gau.selectPushRemote()
+		if err != nil {
+			return err
+		}
+		if remote == nil {
+			return nil
+		}
+		actionType = actionType.WithOptions(remote.Name)
+	// 上流ブランチを設定する場合はリモート追跡ブランチを選択させる
+	case actionType.IsEqual(model.BranchActionTypes.SetUpstream):
+		upstream, err := gau.selectUpstream()
+		if err != nil {
+			return err
+		}
+		if upstream == nil {
+			return nil
+		}
+		actionType = actionType.WithOptions("--set-upstream-to=" + upstream.Name)
+	}
+
+	// 新しいワークツリーで開く場合は作成先のパスを入力させる
+	// リモート追跡ブランチと同じ名前のローカルブランチがある場合はローカルブランチを開く
+	if actionType.IsEqual(model.BranchActionTypes.Worktree) {
+		branches, err := gau.gitManager.GetBranches()
+		if err != nil {
+			return err
+		}
+		targeBranch = model.ResolveWorktreeBranch(branches, targeBranch)
+
+		path, err := inputWorktreePath(gau.fzfManager, gau.gitManager, targeBranch)
+		if err != nil {
+			return err
+		}
+		if path == "" {
+			return nil
+		}
+		actionType = targeBranch.GetWorktreeActionType(path)
+	}
+
+	// 独自のアクションも含めて、保護されたブランチは強制的に push させない
+	if err := targeBranch.CheckForcePush(actionType, state.ProtectedBranches); err != nil {
+		return err
+	}
+
+	ok, err := confirmAction(gau.fzfManager, gau.gitManager, gau.confirmDestructive, actionType, targeBranch.GetFullCommand(actionType), targeBranch.GetDestructiveScope(actionType))
+	if err != nil || !ok {
+		return err
+	}
+
+	// merge や rebase が競合で止まった場合は続けて解消できるようにする
+	return offerConflictResolution(gau.fzfManager, gau.gitManager, gau.confirmDestructive, gau.gitManager.ExecuteBranchActionCommand(actionType, targeBranch))
+}
+
+// ユーザに対象となるブランチを選択させる
+func (gau GitBranchUsecase) getBranches() (model.Branches, error) {
+	branches, err := gau.gitManager.GetBranches()
+	if err != nil {
+		return nil, err
+	}
+
+	selectedBranches, err := gau.fzfManager.SelectBranches(branches)
+	if err != nil {
+		return nil, err
+	}
+	return selectedBranches, nil
+}
+
+// 不要なブランチを選択させて削除する
+func (gau GitBranchUsecase) InteractivePruneBranches() error {
+	// 削除されたリモートブランチを反映してから削除候補を探す
+	if err := gau.gitManager.FetchPrune(); err != nil {
+		return err
+	}
+
+	candidates, err := gau.getPruneCandidates()
+	if err != nil {
+		return err
+	}
+	if len(candidates) == 0 {
+		fmt.Println("no branches to prune")
+		return nil
+	}
+
+	selected, err := gau.fzfManager.SelectPruneCandidates(candidates)
+	if err != nil {
+		return err
+	}
+	// 選択をキャンセルした等の理由で空となった場合は何もしない
+	if len(selected) == 0 {
+		return nil
+	}
 
+	// マージ済みや上流ブランチが削除済みのブランチは git branch -d で削除し、マージされていない場合は git に削除を拒否させる
+	deletable, forceDeletable := model.SplitPruneCandidateBranches(selected)
+	if len(deletable) > 0 {
+		actionType := model.BranchActionTypes.Delete
+		ok, err := confirmAction(gau.fzfManager, gau.gitManager, gau.confirmDestructive, actionType, deletable.GetFullCommand(actionType), deletable.GetDestructiveScope(actionType))
+		if err != nil || !ok {
+			return err
+		}
+		if err := gau.gitManager.ExecuteBranchesActionCommand(actionType, deletable); err != nil {
+			return err
+		}
+	}
+
+	// マージされていない、一定期間コミットがないだけのブランチは未プッシュのコミットを失う可能性があるため、
+	// 破壊的なアクションの確認を無効にしていても必ず確認してから強制的に削除する
+	if len(forceDeletable) > 0 {
+		actionType := model.BranchActionTypes.ForceDelete
+		ok, err := confirmDestructiveAction(gau.fzfManager, gau.gitManager, forceDeletable.GetFullCommand(actionType), forceDeletable.GetDestructiveScope(actionType))
+		if err != nil || !ok {
+			return err
+		}
+		return gau.gitManager.ExecuteBranchesActionCommand(actionType, forceDeletable)
+	}
 	return nil
 }
 
-func (gau GitBranchUsecase) getBranch() (*model.Branch, error) {
+// 不要なブランチを fzf を起動せずに指定した形式で出力する
+// 出力するだけのためリモートの状態を変更する git fetch --prune は実行しない
+func (gau GitBranchUsecase) ListPruneCandidates(format string) error {
+	candidates, err := gau.getPruneCandidates()
+	if err != nil {
+		return err
+	}
+	return printList(candidates, format)
+}
+
+// 削除候補のブランチを探す
+func (gau GitBranchUsecase) getPruneCandidates() ([]*model.PruneCandidate, error) {
+	base := gau.pruneBase
+	if base == "" {
+		defaultBranch, err := gau.gitManager.GetDefaultBranch()
+		if err != nil {
+			return nil, err
+		}
+		base = defaultBranch
+	}
+
+	staleDays, err := strconv.Atoi(common.GetEnvWithString("GITMAN_PRUNE_STALE_DAYS", "90"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid GITMAN_PRUNE_STALE_DAYS: %w", err)
+	}
+
 	branches, err := gau.gitManager.GetBranches()
 	if err != nil {
 		return nil, err
 	}
+	merged, err := gau.gitManager.GetMergedBranchNames(base)
+	if err != nil {
+		return nil, err
+	}
 
-	selectedBranch, err := gau.fzfManager.SelectBranch(branches)
+	rule := model.PruneRule{Base: base, StaleDays: staleDays}
+	return model.FindPruneCandidates(branches, merged, rule, time.Now()), nil
+}
+
+// push 先のリモートを選択させる (リモートが1つの場合は選択せずに返す)
+func (gau GitBranchUsecase) selectPushRemote() (*model.Remote, error) {
+	remotes, err := gau.gitManager.GetRemotes()
+	if err != nil {
+		return nil, err
+	}
+	switch len(remotes) {
+	case 0:
+		return nil, fmt.Errorf("no remotes to push to")
+	case 1:
+		return remotes[0], nil
+	}
+	// --query などは最初のブランチの選択のためのオプションのため、リモートの選択には使わない
+	return gau.fzfManager.WithoutSelectOptions().SelectRemote(remotes)
+}
+
+// 上流ブランチとして設定するリモート追跡ブランチを選択させる
+func (gau GitBranchUsecase) selectUpstream() (*model.Branch, error) {
+	branches, err := gau.gitManager.GetBranches()
 	if err != nil {
 		return nil, err
 	}
-	if selectedBranch == nil {
-		return nil, nil
+
+	var remoteBranches []*model.Branch
+	for _, branch := range branches {
+		if branch.Remote {
+			remoteBranches = append(remoteBranches, branch)
+		}
+	}
+	if len(remoteBranches) == 0 {
+		return nil, fmt.Errorf("no remote branches to set as upstream")
 	}
 
-	return selectedBranch, nil
+	// --query などは最初のブランチの選択のためのオプションのため、上流ブランチの選択には使わない
+	return gau.fzfManager.WithoutSelectOptions().SelectBranch(remoteBranches)
+}
+
+// 新しいブランチ名を入力させ、ブランチ名として使えるか検証する
+// 入力がキャンセルされた場合は空文字を返す
+func inputBranchName(fm fzf.FzfManager, gm git.GitManager, defaultValue string) (string, error) {
+	branchName, err := fm.InputText("branch name> ", defaultValue)
+	if err != nil || branchName == "" {
+		return "", err
+	}
+	if err := gm.CheckBranchName(branchName); err != nil {
+		return "", err
+	}
+	return branchName, nil
+}
 
+// branches を fzf を起動せずに指定した形式で出力する
+func (gau GitBranchUsecase) ListBranches(format string) error {
+	branches, err := gau.gitManager.GetBranches()
+	if err != nil {
+		return err
+	}
+	return printList(branches, format)
 }
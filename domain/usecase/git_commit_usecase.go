@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"gitman/domain/model"
 	"gitman/infrastructure/fzf"
 	"gitman/infrastructure/git"
@@ -9,25 +10,61 @@ import (
 type GitCommitUsecase struct {
 	fzfManager fzf.FzfManager
 	gitManager git.GitManager
+	// 破壊的なアクションの実行前に、失われる可能性がある内容を表示して確認するか
+	confirmDestructive bool
+	// 表示するコミットの絞り込み条件
+	commitFilter model.CommitFilter
+	// --query で指定されたクエリ (コミットIDの場合は fzf を開かずに選択する)
+	query string
 }
 
-func NewGitCommitUsecase(fm fzf.FzfManager, gm git.GitManager) GitCommitUsecase {
+func NewGitCommitUsecase(fm fzf.FzfManager, gm git.GitManager, commitFilter model.CommitFilter, query string, confirmDestructive bool) GitCommitUsecase {
 	return GitCommitUsecase{
-		fzfManager: fm,
-		gitManager: gm,
+		fzfManager:         fm,
+		gitManager:         gm,
+		confirmDestructive: confirmDestructive,
+		commitFilter:       commitFilter,
+		query:              query,
 	}
 }
 
 func (gciu GitCommitUsecase) InteractiveCommitAction() error {
-	targetCommit, err := gciu.getCommit()
+	targetCommits, err := gciu.getCommits()
 	if err != nil {
 		return err
 	}
-	// コミットIDの選択をキャンセルした等の理由でnilとなった場合は何もしない
-	if targetCommit == nil {
+	// コミットIDの選択をキャンセルした等の理由で空となった場合は何もしない
+	if len(targetCommits) == 0 {
 		return nil
 	}
 
+	// コミットの種類やリポジトリの状態に応じて実行できるアクションのみ選択させる
+	// (例: ステージされた変更がない場合の fixup、rebase の途中の rebase)
+	state, err := getRepoState(gciu.gitManager)
+	if err != nil {
+		return err
+	}
+
+	// 複数選択された場合はまとめて実行できるアクションのみ選択させる
+	if len(targetCommits) > 1 {
+		actionType, err := gciu.fzfManager.SelectCommitsAction(targetCommits, targetCommits.GetAvailableActionTypes(state))
+		if err != nil {
+			return err
+		}
+		if actionType.IsEqual(model.CommitActionTypes.Unknown) {
+			return nil
+		}
+
+		ok, err := confirmAction(gciu.fzfManager, gciu.gitManager, gciu.confirmDestructive, actionType, targetCommits.GetFullCommand(actionType), targetCommits.GetDestructiveScope(actionType))
+		if err != nil || !ok {
+			return err
+		}
+
+		return offerConflictResolution(gciu.fzfManager, gciu.gitManager, gciu.confirmDestructive, gciu.gitManager.ExecuteCommitsActionCommand(actionType, targetCommits))
+	}
+
+	targetCommit := targetCommits[0]
+	targetCommit.ActionTypes = targetCommit.GetAvailableActionTypes(state)
 	actionType, err := gciu.fzfManager.SelectCommitAction(targetCommit)
 	if err != nil {
 		return err
@@ -36,24 +73,65 @@ func (gciu GitCommitUsecase) InteractiveCommitAction() error {
 		return nil
 	}
 
-	gciu.gitManager.ExecuteCommitActionCommand(actionType, targetCommit)
+	ok, err := confirmAction(gciu.fzfManager, gciu.gitManager, gciu.confirmDestructive, actionType, targetCommit.GetFullCommand(actionType), targetCommit.GetDestructiveScope(actionType))
+	if err != nil || !ok {
+		return err
+	}
 
-	return nil
+	// cherry-pick や revert が競合で止まった場合は続けて解消できるようにする
+	return offerConflictResolution(gciu.fzfManager, gciu.gitManager, gciu.confirmDestructive, gciu.gitManager.ExecuteCommitActionCommand(actionType, targetCommit))
 }
 
-// ユーザに対象となるコミットと実行したいコマンドを選択させる
-func (gciu GitCommitUsecase) getCommit() (*model.Commit, error) {
-	commits, err := gciu.gitManager.GetCommits()
+// ユーザに対象となるコミットを選択させる
+func (gciu GitCommitUsecase) getCommits() (model.Commits, error) {
+	// 選択が終わったら読み込み途中の git log を終了する
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// クエリに一致するコミットがある場合は fzf を開かずに選択する
+	commit, err := gciu.findQueriedCommit(ctx)
 	if err != nil {
 		return nil, err
 	}
+	if commit != nil {
+		return model.Commits{commit}, nil
+	}
 
-	selectedCommit, err := gciu.fzfManager.SelectCommit(commits)
+	selectedCommits, err := gciu.fzfManager.SelectCommits(gciu.gitManager.StreamCommits(ctx, gciu.commitFilter))
 	if err != nil {
 		return nil, err
 	}
-	if selectedCommit == nil {
+	return selectedCommits, nil
+}
+
+// --query に指定されたコミットIDに前方一致する、表示範囲のコミットを探す
+// git rev-parse で1つのコミットに解決できないクエリ (例: cafe) の場合は履歴を読み込まない
+// 見つかった時点で git log を終了し、読み込んだコミットは保持しない (fzf には git log を再度実行して渡す)
+func (gciu GitCommitUsecase) findQueriedCommit(ctx context.Context) (*model.Commit, error) {
+	if !model.IsCommitIdLike(gciu.query) {
 		return nil, nil
 	}
-	return selectedCommit, nil
+	fullId, err := gciu.gitManager.ResolveCommitId(gciu.query)
+	if err != nil || fullId == "" {
+		return nil, err
+	}
+
+	for commit, err := range gciu.gitManager.StreamCommits(ctx, gciu.commitFilter) {
+		if err != nil {
+			return nil, err
+		}
+		if commit.FullId == fullId {
+			return commit, nil
+		}
+	}
+	return nil, nil
+}
+
+// commits を fzf を起動せずに指定した形式で出力する
+func (gciu GitCommitUsecase) ListCommits(format string) error {
+	commits, err := gciu.gitManager.GetCommits(gciu.commitFilter)
+	if err != nil {
+		return err
+	}
+	return printList(commits, format)
 }
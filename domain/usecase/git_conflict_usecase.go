@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"fmt"
+	"gitman/domain/model"
+	"gitman/infrastructure/fzf"
+	"gitman/infrastructure/git"
+)
+
+type GitConflictUsecase struct {
+	fzfManager fzf.FzfManager
+	gitManager git.GitManager
+	// 破壊的なアクションの実行前に、失われる可能性がある内容を表示して確認するか
+	confirmDestructive bool
+}
+
+func NewGitConflictUsecase(fm fzf.FzfManager, gm git.GitManager, confirmDestructive bool) GitConflictUsecase {
+	return GitConflictUsecase{
+		fzfManager:         fm,
+		gitManager:         gm,
+		confirmDestructive: confirmDestructive,
+	}
+}
+
+// 競合しているファイルを選択させて解消する、または途中で止まっている操作を続行・中止する
+func (gcu GitConflictUsecase) InteractiveConflictAction() error {
+	state, err := getRepoState(gcu.gitManager)
+	if err != nil {
+		return err
+	}
+	fileStatuses, err := gcu.gitManager.GetFileStatuses()
+	if err != nil {
+		return err
+	}
+	conflictedFiles := model.FilterConflictedFileStatuses(fileStatuses)
+	if state.OperationInProgress == "" && len(conflictedFiles) == 0 {
+		fmt.Println("no conflicts to resolve")
+		return nil
+	}
+
+	// 競合が残っている場合は対象のファイルを選択させる (全て解消済みの場合は操作の続行・中止のみ選択させる)
+	conflicts := model.Conflicts{Operation: state.OperationInProgress}
+	if len(conflictedFiles) > 0 {
+		targetFiles, err := gcu.fzfManager.SelectConflictedFiles(conflictedFiles)
+		if err != nil {
+			return err
+		}
+		// ファイルの選択をキャンセルした等の理由で空となった場合は何もしない
+		if len(targetFiles) == 0 {
+			return nil
+		}
+		conflicts.Files = targetFiles
+	}
+
+	actionType, err := gcu.fzfManager.SelectConflictAction(conflicts, conflicts.GetAvailableActionTypes(state))
+	if err != nil {
+		return err
+	}
+	if actionType.IsEqual(model.ConflictActionTypes.Unknown) {
+		return nil
+	}
+
+	ok, err := confirmAction(gcu.fzfManager, gcu.gitManager, gcu.confirmDestructive, actionType, conflicts.GetFullCommand(actionType), conflicts.GetDestructiveScope(actionType))
+	if err != nil || !ok {
+		return err
+	}
+
+	return gcu.gitManager.ExecuteConflictActionCommand(actionType, conflicts)
+}
+
+// 競合しているファイルを fzf を起動せずに指定した形式で出力する
+func (gcu GitConflictUsecase) ListConflicts(format string) error {
+	fileStatuses, err := gcu.gitManager.GetFileStatuses()
+	if err != nil {
+		return err
+	}
+	return printList(model.FilterConflictedFileStatuses(fileStatuses), format)
+}
+
+// アクションの実行が競合で止まった場合は、続けて競合を解消するか確認する
+// 競合していない場合や解消しない場合は実行時のエラーをそのまま返す
+func offerConflictResolution(fm fzf.FzfManager, gm git.GitManager, confirmDestructive bool, executeErr error) error {
+	if executeErr == nil {
+		return nil
+	}
+
+	state, err := gm.GetRepoState()
+	if err != nil || !state.Conflicted {
+		return executeErr
+	}
+
+	operation := state.OperationInProgress
+	if operation == "" {
+		operation = "the action"
+	}
+	ok, err := fm.Confirm(fmt.Sprintf("%s stopped with conflicts. Resolve them now ?", operation))
+	if err != nil || !ok {
+		return executeErr
+	}
+	// --query や --action は競合の原因となったアクションのためのオプションのため、競合の解消には使わない
+	return NewGitConflictUsecase(fm.WithoutSelectOptions(), gm, confirmDestructive).InteractiveConflictAction()
+}
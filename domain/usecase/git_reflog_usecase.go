@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"fmt"
 	"gitman/domain/model"
 	"gitman/infrastructure/fzf"
 	"gitman/infrastructure/git"
@@ -9,25 +10,59 @@ import (
 type GitReflogUsecase struct {
 	fzfManager fzf.FzfManager
 	gitManager git.GitManager
+	// 破壊的なアクションの実行前に、失われる可能性がある内容を表示して確認するか
+	confirmDestructive bool
+	// reflog の対象の参照と絞り込み条件
+	reflogFilter model.ReflogFilter
 }
 
-func NewGitReflogUsecase(fm fzf.FzfManager, gm git.GitManager) GitReflogUsecase {
+func NewGitReflogUsecase(fm fzf.FzfManager, gm git.GitManager, filter model.ReflogFilter, confirmDestructive bool) GitReflogUsecase {
 	return GitReflogUsecase{
-		fzfManager: fm,
-		gitManager: gm,
+		fzfManager:         fm,
+		gitManager:         gm,
+		confirmDestructive: confirmDestructive,
+		reflogFilter:       filter,
 	}
 }
 
 func (gru GitReflogUsecase) InteractiveReflogAction() error {
-	targetReflog, err := gru.getReflog()
+	targetReflogs, err := gru.getReflogs()
 	if err != nil {
 		return err
 	}
-	// コミットIDの選択をキャンセルした等の理由でnilとなった場合は何もしない
-	if targetReflog == nil {
+	// コミットIDの選択をキャンセルした等の理由で空となった場合は何もしない
+	if len(targetReflogs) == 0 {
 		return nil
 	}
 
+	// リポジトリの状態に応じて実行できるアクションのみ選択させる
+	state, err := getRepoState(gru.gitManager)
+	if err != nil {
+		return err
+	}
+
+	// 複数選択された場合はまとめて実行できるアクションのみ選択させる
+	if len(targetReflogs) > 1 {
+		actionType, err := gru.fzfManager.SelectReflogsAction(targetReflogs, targetReflogs.GetAvailableActionTypes(state))
+		if err != nil {
+			return err
+		}
+		if actionType.IsEqual(model.ReflogActionTypes.Unknown) {
+			return nil
+		}
+
+		ok, err := confirmAction(gru.fzfManager, gru.gitManager, gru.confirmDestructive, actionType, targetReflogs.GetFullCommand(actionType), targetReflogs.GetDestructiveScope(actionType))
+		if err != nil || !ok {
+			return err
+		}
+
+		// cherry-pick が競合で止まった場合は続けて解消できるようにする
+		return offerConflictResolution(gru.fzfManager, gru.gitManager, gru.confirmDestructive, gru.gitManager.ExecuteReflogsActionCommand(actionType, targetReflogs))
+	}
+
+	targetReflog := targetReflogs[0]
+	targetReflog.ActionTypes = targetReflog.GetAvailableActionTypes(state)
+
 	actionType, err := gru.fzfManager.SelectReflogAction(targetReflog)
 	if err != nil {
 		return err
@@ -36,24 +71,46 @@ func (gru GitReflogUsecase) InteractiveReflogAction() error {
 		return nil
 	}
 
-	gru.gitManager.ExecuteReflogActionCommand(actionType, targetReflog)
+	// ブランチを作成する場合はブランチ名を入力させる
+	if actionType.IsEqual(model.ReflogActionTypes.CreateBranch) {
+		branchName, err := inputBranchName(gru.fzfManager, gru.gitManager, fmt.Sprintf("rescue/%s", targetReflog.Id))
+		if err != nil {
+			return err
+		}
+		if branchName == "" {
+			return nil
+		}
+		actionType = actionType.WithOptions(branchName)
+	}
+
+	ok, err := confirmAction(gru.fzfManager, gru.gitManager, gru.confirmDestructive, actionType, targetReflog.GetFullCommand(actionType), targetReflog.GetDestructiveScope(actionType))
+	if err != nil || !ok {
+		return err
+	}
 
-	return nil
+	// cherry-pick が競合で止まった場合は続けて解消できるようにする
+	return offerConflictResolution(gru.fzfManager, gru.gitManager, gru.confirmDestructive, gru.gitManager.ExecuteReflogActionCommand(actionType, targetReflog))
 }
 
-// ユーザに対象となるコミットと実行したいコマンドを選択させる
-func (gru GitReflogUsecase) getReflog() (*model.Reflog, error) {
-	reflogs, err := gru.gitManager.GetReflogs()
+// ユーザに対象となる reflog を選択させる
+func (gru GitReflogUsecase) getReflogs() (model.Reflogs, error) {
+	reflogs, err := gru.gitManager.GetReflogs(gru.reflogFilter)
 	if err != nil {
 		return nil, err
 	}
 
-	selectedReflog, err := gru.fzfManager.SelectReflog(reflogs)
+	selectedReflogs, err := gru.fzfManager.SelectReflogs(reflogs)
 	if err != nil {
 		return nil, err
 	}
-	if selectedReflog == nil {
-		return nil, nil
+	return selectedReflogs, nil
+}
+
+// reflogs を fzf を起動せずに指定した形式で出力する
+func (gru GitReflogUsecase) ListReflogs(format string) error {
+	reflogs, err := gru.gitManager.GetReflogs(gru.reflogFilter)
+	if err != nil {
+		return err
 	}
-	return selectedReflog, nil
+	return printList(reflogs, format)
 }
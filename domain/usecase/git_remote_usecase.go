@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"gitman/domain/model"
+	"gitman/infrastructure/fzf"
+	"gitman/infrastructure/git"
+)
+
+type GitRemoteUsecase struct {
+	fzfManager fzf.FzfManager
+	gitManager git.GitManager
+	// 破壊的なアクションの実行前に、失われる可能性がある内容を表示して確認するか
+	confirmDestructive bool
+}
+
+func NewGitRemoteUsecase(fm fzf.FzfManager, gm git.GitManager, confirmDestructive bool) GitRemoteUsecase {
+	return GitRemoteUsecase{
+		fzfManager:         fm,
+		gitManager:         gm,
+		confirmDestructive: confirmDestructive,
+	}
+}
+
+func (gru GitRemoteUsecase) InteractiveRemoteAction() error {
+	remotes, err := gru.gitManager.GetRemotes()
+	if err != nil {
+		return err
+	}
+	// リモートがない場合は選択できるものがないため追加から始める
+	if len(remotes) == 0 {
+		return gru.addRemote()
+	}
+
+	targetRemote, err := gru.fzfManager.SelectRemote(remotes)
+	if err != nil {
+		return err
+	}
+	// リモートの選択をキャンセルした等の理由でnilとなった場合は何もしない
+	if targetRemote == nil {
+		return nil
+	}
+
+	actionType, err := gru.fzfManager.SelectRemoteAction(targetRemote)
+	if err != nil {
+		return err
+	}
+	if actionType.IsEqual(model.RemoteActionTypes.Unknown) {
+		return nil
+	}
+
+	switch {
+	// 追加は選択したリモートに関係なく、名前とURLを入力させる
+	case actionType.IsEqual(model.RemoteActionTypes.Add):
+		return gru.addRemote()
+	// 名前を変更する場合は変更後の名前を入力させる
+	case actionType.IsEqual(model.RemoteActionTypes.Rename):
+		name, err := gru.fzfManager.InputText("remote name> ", targetRemote.Name)
+		if err != nil {
+			return err
+		}
+		if name == "" || name == targetRemote.Name {
+			return nil
+		}
+		actionType = actionType.WithTrailingOptions(name)
+	// URLを変更する場合は変更後のURLを入力させる
+	case actionType.IsEqual(model.RemoteActionTypes.SetUrl):
+		url, err := gru.fzfManager.InputText("remote url> ", targetRemote.FetchURL)
+		if err != nil {
+			return err
+		}
+		if url == "" || url == targetRemote.FetchURL {
+			return nil
+		}
+		actionType = actionType.WithTrailingOptions(url)
+	}
+
+	ok, err := confirmAction(gru.fzfManager, gru.gitManager, gru.confirmDestructive, actionType, targetRemote.GetFullCommand(actionType), targetRemote.GetDestructiveScope(actionType))
+	if err != nil || !ok {
+		return err
+	}
+
+	return gru.gitManager.ExecuteRemoteActionCommand(actionType, targetRemote)
+}
+
+// ユーザにリモートの名前とURLを入力させ、リモートを追加する
+func (gru GitRemoteUsecase) addRemote() error {
+	name, err := gru.fzfManager.InputText("remote name> ", "")
+	if err != nil || name == "" {
+		return err
+	}
+	url, err := gru.fzfManager.InputText("remote url> ", "")
+	if err != nil || url == "" {
+		return err
+	}
+
+	actionType := model.RemoteActionTypes.Add.WithOptions(name, url)
+	remote := model.NewRemote(name, url, url)
+	ok, err := confirmAction(gru.fzfManager, gru.gitManager, gru.confirmDestructive, actionType, remote.GetFullCommand(actionType), remote.GetDestructiveScope(actionType))
+	if err != nil || !ok {
+		return err
+	}
+
+	return gru.gitManager.ExecuteRemoteActionCommand(actionType, remote)
+}
+
+// remotes を fzf を起動せずに指定した形式で出力する
+func (gru GitRemoteUsecase) ListRemotes(format string) error {
+	remotes, err := gru.gitManager.GetRemotes()
+	if err != nil {
+		return err
+	}
+	return printList(remotes, format)
+}
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"gitman/domain/model"
+	"gitman/infrastructure/fzf"
+	"gitman/infrastructure/git"
+)
+
+type GitStashUsecase struct {
+	fzfManager fzf.FzfManager
+	gitManager git.GitManager
+	// 破壊的なアクションの実行前に、失われる可能性がある内容を表示して確認するか
+	confirmDestructive bool
+}
+
+func NewGitStashUsecase(fm fzf.FzfManager, gm git.GitManager, confirmDestructive bool) GitStashUsecase {
+	return GitStashUsecase{
+		fzfManager:         fm,
+		gitManager:         gm,
+		confirmDestructive: confirmDestructive,
+	}
+}
+
+func (gsu GitStashUsecase) InteractiveStashAction() error {
+	targetStash, err := gsu.getStash()
+	if err != nil {
+		return err
+	}
+	// スタッシュの選択をキャンセルした等の理由でnilとなった場合は何もしない
+	if targetStash == nil {
+		return nil
+	}
+
+	// 競合の解消中など、リポジトリの状態に応じて実行できるアクションのみ選択させる
+	state, err := getRepoState(gsu.gitManager)
+	if err != nil {
+		return err
+	}
+	targetStash.ActionTypes = targetStash.GetAvailableActionTypes(state)
+
+	actionType, err := gsu.fzfManager.SelectStashAction(targetStash)
+	if err != nil {
+		return err
+	}
+	if actionType.IsEqual(model.StashActionTypes.Unknown) {
+		return nil
+	}
+
+	// スタッシュからブランチを作成する場合はブランチ名を入力させ、ブランチ名として使えるか検証する
+	if actionType.IsEqual(model.StashActionTypes.Branch) {
+		branchName, err := inputBranchName(gsu.fzfManager, gsu.gitManager, "")
+		if err != nil {
+			return err
+		}
+		if branchName == "" {
+			return nil
+		}
+		actionType = actionType.WithOptions(branchName)
+	}
+
+	ok, err := confirmAction(gsu.fzfManager, gsu.gitManager, gsu.confirmDestructive, actionType, targetStash.GetFullCommand(actionType), targetStash.GetDestructiveScope(actionType))
+	if err != nil || !ok {
+		return err
+	}
+
+	return gsu.gitManager.ExecuteStashActionCommand(actionType, targetStash)
+}
+
+// ユーザに対象となるスタッシュを選択させる
+func (gsu GitStashUsecase) getStash() (*model.Stash, error) {
+	stashes, err := gsu.gitManager.GetStashes()
+	if err != nil {
+		return nil, err
+	}
+
+	selectedStash, err := gsu.fzfManager.SelectStash(stashes)
+	if err != nil {
+		return nil, err
+	}
+	if selectedStash == nil {
+		return nil, nil
+	}
+	return selectedStash, nil
+}
+
+// stashes を fzf を起動せずに指定した形式で出力する
+func (gsu GitStashUsecase) ListStashes(format string) error {
+	stashes, err := gsu.gitManager.GetStashes()
+	if err != nil {
+		return err
+	}
+	return printList(stashes, format)
+}
@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"fmt"
+	"gitman/domain/model"
+	"gitman/infrastructure/fzf"
+	"gitman/infrastructure/git"
+)
+
+type GitStatusUsecase struct {
+	fzfManager fzf.FzfManager
+	gitManager git.GitManager
+	// 破壊的なアクションの実行前に、失われる可能性がある内容を表示して確認するか
+	confirmDestructive bool
+}
+
+func NewGitStatusUsecase(fm fzf.FzfManager, gm git.GitManager, confirmDestructive bool) GitStatusUsecase {
+	return GitStatusUsecase{
+		fzfManager:         fm,
+		gitManager:         gm,
+		confirmDestructive: confirmDestructive,
+	}
+}
+
+func (gsu GitStatusUsecase) InteractiveStatusAction() error {
+	fileStatuses, err := gsu.gitManager.GetFileStatuses()
+	if err != nil {
+		return err
+	}
+	if len(fileStatuses) == 0 {
+		fmt.Println("nothing to commit, working tree clean")
+		return nil
+	}
+
+	targetFiles, err := gsu.fzfManager.SelectFileStatuses(fileStatuses)
+	if err != nil {
+		return err
+	}
+	// ファイルの選択をキャンセルした等の理由で空となった場合は何もしない
+	if len(targetFiles) == 0 {
+		return nil
+	}
+
+	state, err := getRepoState(gsu.gitManager)
+	if err != nil {
+		return err
+	}
+	actionType, err := gsu.fzfManager.SelectFileStatusAction(targetFiles, targetFiles.GetAvailableActionTypes(state))
+	if err != nil {
+		return err
+	}
+	if actionType.IsEqual(model.FileStatusActionTypes.Unknown) {
+		return nil
+	}
+
+	ok, err := confirmAction(gsu.fzfManager, gsu.gitManager, gsu.confirmDestructive, actionType, targetFiles.GetFullCommand(actionType), targetFiles.GetDestructiveScope(actionType))
+	if err != nil || !ok {
+		return err
+	}
+
+	return gsu.gitManager.ExecuteFileStatusActionCommand(actionType, targetFiles)
+}
+
+// fileStatuses を fzf を起動せずに指定した形式で出力する
+func (gsu GitStatusUsecase) ListFileStatuses(format string) error {
+	fileStatuses, err := gsu.gitManager.GetFileStatuses()
+	if err != nil {
+		return err
+	}
+	return printList(fileStatuses, format)
+}
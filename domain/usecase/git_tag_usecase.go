@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"fmt"
+	"gitman/domain/model"
+	"gitman/infrastructure/fzf"
+	"gitman/infrastructure/git"
+)
+
+type GitTagUsecase struct {
+	fzfManager fzf.FzfManager
+	gitManager git.GitManager
+	// 破壊的なアクションの実行前に、失われる可能性がある内容を表示して確認するか
+	confirmDestructive bool
+}
+
+func NewGitTagUsecase(fm fzf.FzfManager, gm git.GitManager, confirmDestructive bool) GitTagUsecase {
+	return GitTagUsecase{
+		fzfManager:         fm,
+		gitManager:         gm,
+		confirmDestructive: confirmDestructive,
+	}
+}
+
+func (gtu GitTagUsecase) InteractiveTagAction() error {
+	tags, err := gtu.gitManager.GetTags()
+	if err != nil {
+		return err
+	}
+	// タグがない場合は選択できるものがないため作成から始める
+	// --exit-0 や --action が指定された場合はスクリプトからの実行のため、入力を求めずに終了する
+	if len(tags) == 0 {
+		selectOptions := gtu.fzfManager.GetSelectOptions()
+		switch {
+		case selectOptions.Exit0:
+			return nil
+		case selectOptions.Action == model.TagActionTypes.Create.Name:
+			return gtu.createTag()
+		case selectOptions.Action != "":
+			return fmt.Errorf("no tags to run the action on: %s", selectOptions.Action)
+		default:
+			return gtu.createTag()
+		}
+	}
+
+	targetTags, err := gtu.fzfManager.SelectTags(tags)
+	if err != nil {
+		return err
+	}
+	// タグの選択をキャンセルした等の理由で空となった場合は何もしない
+	if len(targetTags) == 0 {
+		return nil
+	}
+
+	// push や削除に使うリモートを設定し、リモートがない場合は push 等を表示しない
+	remote, err := gtu.gitManager.GetPushRemote()
+	if err != nil {
+		return err
+	}
+	for _, tag := range targetTags {
+		tag.Remote = remote
+	}
+	state, err := getRepoState(gtu.gitManager)
+	if err != nil {
+		return err
+	}
+
+	// 複数選択された場合はまとめて実行できるアクションのみ選択させる
+	if len(targetTags) > 1 {
+		actionType, err := gtu.fzfManager.SelectTagsAction(targetTags, targetTags.GetAvailableActionTypes(state))
+		if err != nil {
+			return err
+		}
+		if actionType.IsEqual(model.TagActionTypes.Unknown) {
+			return nil
+		}
+
+		ok, err := confirmAction(gtu.fzfManager, gtu.gitManager, gtu.confirmDestructive, actionType, targetTags.GetFullCommand(actionType), targetTags.GetDestructiveScope(actionType))
+		if err != nil || !ok {
+			return err
+		}
+
+		return gtu.gitManager.ExecuteTagsActionCommand(actionType, targetTags)
+	}
+
+	targetTag := targetTags[0]
+	targetTag.ActionTypes = targetTag.GetAvailableActionTypes(state)
+	actionType, err := gtu.fzfManager.SelectTagAction(targetTag)
+	if err != nil {
+		return err
+	}
+	if actionType.IsEqual(model.TagActionTypes.Unknown) {
+		return nil
+	}
+	// 作成する場合は選択したタグに関係なく、新しいタグ名を入力させる
+	if actionType.IsEqual(model.TagActionTypes.Create) {
+		return gtu.createTag()
+	}
+
+	ok, err := confirmAction(gtu.fzfManager, gtu.gitManager, gtu.confirmDestructive, actionType, targetTag.GetFullCommand(actionType), targetTag.GetDestructiveScope(actionType))
+	if err != nil || !ok {
+		return err
+	}
+
+	return gtu.gitManager.ExecuteTagActionCommand(actionType, targetTag)
+}
+
+// ユーザにタグ名を入力させ、HEAD に注釈付きタグを作成する
+func (gtu GitTagUsecase) createTag() error {
+	name, err := gtu.fzfManager.InputText("tag name> ", "")
+	if err != nil || name == "" {
+		return err
+	}
+	if err := gtu.gitManager.CheckTagName(name); err != nil {
+		return err
+	}
+
+	actionType := model.TagActionTypes.Create.WithOptions(name)
+	tag := model.NewTag(name, "HEAD", true, "", "")
+	ok, err := confirmAction(gtu.fzfManager, gtu.gitManager, gtu.confirmDestructive, actionType, tag.GetFullCommand(actionType), tag.GetDestructiveScope(actionType))
+	if err != nil || !ok {
+		return err
+	}
+
+	return gtu.gitManager.ExecuteTagActionCommand(actionType, tag)
+}
+
+// tags を fzf を起動せずに指定した形式で出力する
+func (gtu GitTagUsecase) ListTags(format string) error {
+	tags, err := gtu.gitManager.GetTags()
+	if err != nil {
+		return err
+	}
+	return printList(tags, format)
+}
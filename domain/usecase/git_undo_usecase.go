@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"fmt"
+	"gitman/domain/model"
+	"gitman/infrastructure/fzf"
+	"gitman/infrastructure/git"
+)
+
+type GitUndoUsecase struct {
+	fzfManager fzf.FzfManager
+	gitManager git.GitManager
+	// 破壊的なアクションの実行前に、失われる可能性がある内容を表示して確認するか
+	confirmDestructive bool
+}
+
+func NewGitUndoUsecase(fm fzf.FzfManager, gm git.GitManager, confirmDestructive bool) GitUndoUsecase {
+	return GitUndoUsecase{
+		fzfManager:         fm,
+		gitManager:         gm,
+		confirmDestructive: confirmDestructive,
+	}
+}
+
+func (guu GitUndoUsecase) InteractiveUndo() error {
+	snapshots, err := guu.gitManager.GetSnapshots()
+	if err != nil {
+		return err
+	}
+	if len(snapshots) == 0 {
+		fmt.Println("No snapshots recorded yet.")
+		return nil
+	}
+
+	targetSnapshot, err := guu.fzfManager.SelectSnapshot(snapshots)
+	if err != nil {
+		return err
+	}
+	// スナップショットの選択をキャンセルした等の理由でnilとなった場合は何もしない
+	if targetSnapshot == nil {
+		return nil
+	}
+
+	description := fmt.Sprintf("restore the state before '%s' (%s)", targetSnapshot.Command, targetSnapshot.Id)
+	ok, err := confirmAction(guu.fzfManager, guu.gitManager, guu.confirmDestructive, model.UndoActionType, description, targetSnapshot.GetDestructiveScope())
+	if err != nil || !ok {
+		return err
+	}
+
+	// 復元自体も取り消せるように現在の状態を記録しておく
+	if _, err := guu.gitManager.RecordSnapshot(fmt.Sprintf("gitman undo %s", targetSnapshot.Id)); err != nil {
+		return err
+	}
+
+	return guu.gitManager.RestoreSnapshot(targetSnapshot)
+}
@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"gitman/domain/model"
+	"gitman/infrastructure/fzf"
+	"gitman/infrastructure/git"
+)
+
+type GitWorktreeUsecase struct {
+	fzfManager fzf.FzfManager
+	gitManager git.GitManager
+	// 破壊的なアクションの実行前に、失われる可能性がある内容を表示して確認するか
+	confirmDestructive bool
+}
+
+func NewGitWorktreeUsecase(fm fzf.FzfManager, gm git.GitManager, confirmDestructive bool) GitWorktreeUsecase {
+	return GitWorktreeUsecase{
+		fzfManager:         fm,
+		gitManager:         gm,
+		confirmDestructive: confirmDestructive,
+	}
+}
+
+func (gwu GitWorktreeUsecase) InteractiveWorktreeAction() error {
+	targetWorktree, err := gwu.getWorktree()
+	if err != nil {
+		return err
+	}
+	// ワークツリーの選択をキャンセルした等の理由でnilとなった場合は何もしない
+	if targetWorktree == nil {
+		return nil
+	}
+
+	// メインのワークツリーやロックの状態に応じて実行できるアクションのみ選択させる
+	state, err := getRepoState(gwu.gitManager)
+	if err != nil {
+		return err
+	}
+	targetWorktree.ActionTypes = targetWorktree.GetAvailableActionTypes(state)
+
+	actionType, err := gwu.fzfManager.SelectWorktreeAction(targetWorktree)
+	if err != nil {
+		return err
+	}
+	if actionType.IsEqual(model.WorktreeActionTypes.Unknown) {
+		return nil
+	}
+
+	// ブランチからの作成はブランチの「新しいワークツリーで開く」と同じ処理を行う
+	if actionType.IsEqual(model.WorktreeActionTypes.Add) {
+		return gwu.addWorktreeFromBranch()
+	}
+
+	ok, err := confirmAction(gwu.fzfManager, gwu.gitManager, gwu.confirmDestructive, actionType, targetWorktree.GetFullCommand(actionType), targetWorktree.GetDestructiveScope(actionType))
+	if err != nil || !ok {
+		return err
+	}
+
+	return gwu.gitManager.ExecuteWorktreeActionCommand(actionType, targetWorktree)
+}
+
+// ユーザにブランチと作成先のパスを選択させ、ワークツリーを作成する
+func (gwu GitWorktreeUsecase) addWorktreeFromBranch() error {
+	branches, err := gwu.gitManager.GetBranches()
+	if err != nil {
+		return err
+	}
+
+	// --query などは最初のワークツリーの選択のためのオプションのため、ブランチの選択には使わない
+	targetBranch, err := gwu.fzfManager.WithoutSelectOptions().SelectBranch(branches)
+	if err != nil {
+		return err
+	}
+	if targetBranch == nil {
+		return nil
+	}
+	targetBranch = model.ResolveWorktreeBranch(branches, targetBranch)
+
+	path, err := inputWorktreePath(gwu.fzfManager, gwu.gitManager, targetBranch)
+	if err != nil {
+		return err
+	}
+	if path == "" {
+		return nil
+	}
+
+	return gwu.gitManager.ExecuteBranchActionCommand(targetBranch.GetWorktreeActionType(path), targetBranch)
+}
+
+// ユーザに対象となるワークツリーを選択させる
+func (gwu GitWorktreeUsecase) getWorktree() (*model.Worktree, error) {
+	worktrees, err := gwu.gitManager.GetWorktrees()
+	if err != nil {
+		return nil, err
+	}
+
+	selectedWorktree, err := gwu.fzfManager.SelectWorktree(worktrees)
+	if err != nil {
+		return nil, err
+	}
+	if selectedWorktree == nil {
+		return nil, nil
+	}
+	return selectedWorktree, nil
+}
+
+// ワークツリーの作成先のパスを入力させる
+// リポジトリと同じ階層のディレクトリを初期値として表示する
+func inputWorktreePath(fm fzf.FzfManager, gm git.GitManager, branch *model.Branch) (string, error) {
+	topLevelDir, err := gm.GetTopLevelDir()
+	if err != nil {
+		return "", err
+	}
+
+	return fm.InputText("worktree path> ", model.DefaultWorktreePath(topLevelDir, branch.GetLocalName()))
+}
+
+// worktrees を fzf を起動せずに指定した形式で出力する
+func (gwu GitWorktreeUsecase) ListWorktrees(format string) error {
+	worktrees, err := gwu.gitManager.GetWorktrees()
+	if err != nil {
+		return err
+	}
+	return printList(worktrees, format)
+}
@@ -0,0 +1,30 @@
+package usecase
+
+import (
+	"fmt"
+	"gitman/domain/model"
+)
+
+type listable interface {
+	GetListItem() model.ListItem
+}
+
+// fzf を起動せずに、取得した項目を指定した形式 (json / tsv) で標準出力に出力する
+func printList[T listable](items []T, format string) error {
+	listFormat, err := model.ParseListFormat(format)
+	if err != nil {
+		return err
+	}
+
+	listItems := make([]model.ListItem, 0, len(items))
+	for _, item := range items {
+		listItems = append(listItems, item.GetListItem())
+	}
+
+	out, err := model.FormatList(listItems, listFormat)
+	if err != nil {
+		return err
+	}
+	fmt.Print(out)
+	return nil
+}
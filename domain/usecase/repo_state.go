@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"gitman/common"
+	"gitman/domain/model"
+	"gitman/infrastructure/git"
+	"strings"
+)
+
+// 選択させるアクションを絞り込むためのリポジトリの状態を取得する
+func getRepoState(gm git.GitManager) (model.RepoState, error) {
+	state, err := gm.GetRepoState()
+	if err != nil {
+		return state, err
+	}
+	state.ProtectedBranches = getProtectedBranchPatterns()
+	return state, nil
+}
+
+// 強制的な push を表示しない保護されたブランチのパターンを返す
+func getProtectedBranchPatterns() []string {
+	var patterns []string
+	for _, pattern := range strings.Split(common.GetEnvWithString("GITMAN_PROTECTED_BRANCHES", "main,master"), ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns
+}
@@ -16,11 +16,14 @@ func main() {
 	// Setting log level (all logging must be after this line)
 	common.SetupGlobalLogger(opts.Debug)
 
-	container := di.NewContainer()
+	container, err := di.NewContainer(opts)
+	if err != nil {
+		slog.Error("failed to initialize gitman", "error", err)
+		os.Exit(1)
+	}
 
 	// executing the command
-	err := cli.New(opts, container).Handle()
-	if err != nil {
+	if err := cli.New(opts, container).Handle(); err != nil {
 		slog.Error("failed to execute gitman", "error", err)
 		os.Exit(1)
 	}
@@ -1,3 +1,5 @@
 module gitman
 
 go 1.24.2
+
+require github.com/BurntSushi/toml v1.6.0
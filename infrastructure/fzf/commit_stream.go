@@ -0,0 +1,55 @@
+package fzf
+
+import (
+	"gitman/domain/model"
+	"io"
+	"iter"
+	"sync"
+)
+
+// git log から読み込んだコミットを fzf に渡しながら、選択後に検索できるように保持する
+type commitStream struct {
+	mu      sync.Mutex
+	commits []*model.Commit
+	err     error
+}
+
+// commits を読み込んだ順に fzf の標準入力に書き込む
+// fzf が終了して書き込めなくなった場合は読み込みをやめる (git log も終了する)
+// git log が失敗した場合は空の候補のまま fzf が残らないように abort を呼ぶ
+func (cs *commitStream) feed(commits iter.Seq2[*model.Commit, error], w io.WriteCloser, abort func()) {
+	defer w.Close()
+
+	for commit, err := range commits {
+		if err != nil {
+			cs.mu.Lock()
+			cs.err = err
+			cs.mu.Unlock()
+			abort()
+			return
+		}
+
+		// fzf に表示される前に保持しておく
+		cs.mu.Lock()
+		cs.commits = append(cs.commits, commit)
+		cs.mu.Unlock()
+
+		if _, err := io.WriteString(w, commit.GetFzfLine()+"\n"); err != nil {
+			return
+		}
+	}
+}
+
+// これまでに読み込んだコミットを git log の表示順で返す
+func (cs *commitStream) getCommits() []*model.Commit {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	return cs.commits
+}
+
+// git log の読み込み中に発生したエラーを返す
+func (cs *commitStream) getErr() error {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	return cs.err
+}
@@ -0,0 +1,97 @@
+package fzf
+
+import (
+	"errors"
+	"gitman/domain/model"
+	"io"
+	"iter"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// 書き込まれた内容を保持する fzf の標準入力の代わり
+type nopWriteCloser struct {
+	strings.Builder
+}
+
+func (w *nopWriteCloser) Close() error {
+	return nil
+}
+
+var _ io.WriteCloser = (*nopWriteCloser)(nil)
+
+func TestCommitStream_feed(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		commits iter.Seq2[*model.Commit, error]
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantInput   string
+		wantCommits []*model.Commit
+		wantErr     error
+		wantAborted bool
+	}{
+		{
+			name: "読み込んだコミットをfzfに渡して保持すること",
+			args: args{
+				commits: func(yield func(*model.Commit, error) bool) {
+					if !yield(&model.Commit{Id: "4a77a8e", Message: "second"}, nil) {
+						return
+					}
+					yield(&model.Commit{Id: "75d39af", Message: "first"}, nil)
+				},
+			},
+			wantInput: "4a77a8e second\n75d39af first\n",
+			wantCommits: []*model.Commit{
+				{Id: "4a77a8e", Message: "second"},
+				{Id: "75d39af", Message: "first"},
+			},
+			wantErr:     nil,
+			wantAborted: false,
+		},
+		{
+			name: "git logが失敗した場合はエラーを保持してfzfを終了させること",
+			args: args{
+				commits: func(yield func(*model.Commit, error) bool) {
+					if !yield(&model.Commit{Id: "4a77a8e", Message: "second"}, nil) {
+						return
+					}
+					yield(nil, errors.New("failed to execute git log command"))
+				},
+			},
+			wantInput: "4a77a8e second\n",
+			wantCommits: []*model.Commit{
+				{Id: "4a77a8e", Message: "second"},
+			},
+			wantErr:     errors.New("failed to execute git log command"),
+			wantAborted: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var stream commitStream
+			var in nopWriteCloser
+			aborted := false
+			stream.feed(tt.args.commits, &in, func() {
+				aborted = true
+			})
+			if got := in.String(); got != tt.wantInput {
+				t.Errorf("feed() input = %q, want %q", got, tt.wantInput)
+			}
+			if got := stream.getCommits(); !reflect.DeepEqual(got, tt.wantCommits) {
+				t.Errorf("getCommits() = %v, want %v", got, tt.wantCommits)
+			}
+			if !reflect.DeepEqual(stream.getErr(), tt.wantErr) {
+				t.Errorf("getErr() = %v, want %v", stream.getErr(), tt.wantErr)
+			}
+			if aborted != tt.wantAborted {
+				t.Errorf("aborted = %v, want %v", aborted, tt.wantAborted)
+			}
+		})
+	}
+}
@@ -1,12 +1,38 @@
 package fzf
 
-import "gitman/domain/model"
+import (
+	"gitman/domain/model"
+	"iter"
+)
 
 type FzfManager interface {
-	SelectCommit(commits []*model.Commit) (*model.Commit, error)
+	SelectCommits(commits iter.Seq2[*model.Commit, error]) (model.Commits, error)
 	SelectCommitAction(commit *model.Commit) (model.ActionType, error)
+	SelectCommitsAction(commits model.Commits, actionTypes []model.ActionType) (model.ActionType, error)
 	SelectBranch(branches []*model.Branch) (*model.Branch, error)
+	SelectBranches(branches []*model.Branch) (model.Branches, error)
 	SelectBranchAction(branch *model.Branch) (model.ActionType, error)
-	SelectReflog(reflogs []*model.Reflog) (*model.Reflog, error)
+	SelectBranchesAction(branches model.Branches, actionTypes []model.ActionType) (model.ActionType, error)
+	SelectPruneCandidates(candidates []*model.PruneCandidate) ([]*model.PruneCandidate, error)
+	SelectReflogs(reflogs []*model.Reflog) (model.Reflogs, error)
 	SelectReflogAction(reflog *model.Reflog) (model.ActionType, error)
+	SelectReflogsAction(reflogs model.Reflogs, actionTypes []model.ActionType) (model.ActionType, error)
+	SelectStash(stashes []*model.Stash) (*model.Stash, error)
+	SelectStashAction(stash *model.Stash) (model.ActionType, error)
+	SelectTags(tags []*model.Tag) (model.Tags, error)
+	SelectTagAction(tag *model.Tag) (model.ActionType, error)
+	SelectTagsAction(tags model.Tags, actionTypes []model.ActionType) (model.ActionType, error)
+	SelectWorktree(worktrees []*model.Worktree) (*model.Worktree, error)
+	SelectWorktreeAction(worktree *model.Worktree) (model.ActionType, error)
+	SelectRemote(remotes []*model.Remote) (*model.Remote, error)
+	SelectRemoteAction(remote *model.Remote) (model.ActionType, error)
+	SelectFileStatuses(files []*model.FileStatus) (model.FileStatuses, error)
+	SelectFileStatusAction(files model.FileStatuses, actionTypes []model.ActionType) (model.ActionType, error)
+	SelectConflictedFiles(files []*model.FileStatus) (model.FileStatuses, error)
+	SelectConflictAction(conflicts model.Conflicts, actionTypes []model.ActionType) (model.ActionType, error)
+	SelectSnapshot(snapshots []*model.Snapshot) (*model.Snapshot, error)
+	WithoutSelectOptions() FzfManager
+	GetSelectOptions() SelectOptions
+	InputText(prompt string, defaultValue string) (string, error)
+	Confirm(message string) (bool, error)
 }
@@ -5,17 +5,20 @@ import (
 	"fmt"
 	"gitman/common"
 	"gitman/domain/model"
+	"iter"
 	"log/slog"
 	"os/exec"
 	"strings"
+	"syscall"
 )
 
 type FzfManagerImpl struct {
-	fzfLayout string
+	fzfLayout     string
+	selectOptions SelectOptions
 }
 
 // NewFzfManager は FzfManagerImpl を返す
-func NewFzfManager() (FzfManager, error) {
+func NewFzfManager(selectOptions SelectOptions) (FzfManager, error) {
 	isValid, err := isValidFzf()
 	if !isValid {
 		return nil, err
@@ -35,7 +38,8 @@ func NewFzfManager() (FzfManager, error) {
 	}
 
 	return &FzfManagerImpl{
-		fzfLayout: fzfLayout,
+		fzfLayout:     fzfLayout,
+		selectOptions: selectOptions,
 	}, nil
 }
 
@@ -57,9 +61,11 @@ func isValidFzf() (bool, error) {
 	return true, nil
 }
 
-func (fm FzfManagerImpl) SelectCommit(commits []*model.Commit) (*model.Commit, error) {
+// git log の出力を読み込みながら fzf に渡すため、履歴が大きくてもすぐに選択を始められる
+func (fm FzfManagerImpl) SelectCommits(commits iter.Seq2[*model.Commit, error]) (model.Commits, error) {
 	cmd := exec.Command("fzf",
 		"--ansi",
+		"--multi", // TABで複数選択
 		"--prompt=gitman-log> ",
 		"--layout="+fm.fzfLayout,
 		"--preview", "echo {} | awk '{print $1}' | xargs git show --color=always --stat -p",
@@ -68,17 +74,32 @@ func (fm FzfManagerImpl) SelectCommit(commits []*model.Commit) (*model.Commit, e
 		"--bind", "pgdn:preview-page-down,pgup:preview-page-up",
 		"--bind", "ctrl-s:toggle-preview",
 	)
+	cmd.Args = append(cmd.Args, fm.selectOptions.fzfArgs()...)
 
-	var in bytes.Buffer
-	for _, commit := range commits {
-		in.WriteString(commit.RawCommitLog + "\n")
+	in, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("fzf failed: %w", err)
 	}
-	cmd.Stdin = &in
 
 	var out bytes.Buffer
 	cmd.Stdout = &out
 
-	if err := cmd.Run(); err != nil {
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("fzf failed: %w", err)
+	}
+
+	// fzf の起動後に git log の出力を流し込む
+	var stream commitStream
+	go stream.feed(commits, in, func() {
+		// 端末の表示を元に戻してから終了できるように SIGTERM で終了させる
+		_ = cmd.Process.Signal(syscall.SIGTERM)
+	})
+
+	if err := cmd.Wait(); err != nil {
+		// git log が失敗して fzf を終了させた場合は git log のエラーを返す
+		if streamErr := stream.getErr(); streamErr != nil {
+			return nil, streamErr
+		}
 		if exitErr, ok := err.(*exec.ExitError); ok {
 			// ユーザーがキャンセルした場合（ESCキーやCtrl+C）
 			if exitErr.ExitCode() == 1 || exitErr.ExitCode() == 130 {
@@ -89,20 +110,26 @@ func (fm FzfManagerImpl) SelectCommit(commits []*model.Commit) (*model.Commit, e
 		return nil, fmt.Errorf("fzf failed: %w", err)
 	}
 
-	selected := strings.TrimSpace(out.String())
-	if selected == "" {
-		return nil, nil // 選択なしはエラーにせず空文字
+	// 選択された行が1行ずつ出力される
+	var commitIds []string
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		commitIds = append(commitIds, strings.Fields(line)[0])
 	}
+	if len(commitIds) == 0 {
+		return nil, nil // 選択なしはエラーにせず nil を返す
+	}
+	slog.Debug("selected commitIds", "commitIds", commitIds)
 
-	commitId := strings.Fields(selected)[0]
-	slog.Debug("selected commitId", "commitId", commitId)
-
-	selectedCommit, err := model.FindCommitById(commits, commitId)
+	// 選択されたコミットは表示済みのため、読み込み済みのコミットから探せる
+	selectedCommits, err := model.FindCommitsByIds(stream.getCommits(), commitIds)
 	if err != nil {
 		return nil, err
 	}
 
-	return selectedCommit, nil
+	return selectedCommits, nil
 }
 
 func (fm FzfManagerImpl) SelectCommitAction(commit *model.Commit) (model.ActionType, error) {
@@ -131,6 +158,15 @@ func (fm FzfManagerImpl) SelectCommitAction(commit *model.Commit) (model.ActionT
 		in.WriteString(commit.GetFzfInputForSelectActionType(actionType))
 	}
 
+	// アクション名が指定された場合は fzf を開かずに候補から選択する
+	if fm.selectOptions.Action != "" {
+		selected, err := fm.selectOptions.findActionLine(&in)
+		if err != nil {
+			return model.CommitActionTypes.Unknown, err
+		}
+		return model.ParseSelectedCommitActionType(selected)
+	}
+
 	slog.Debug("fzf input", "input", in.String())
 	cmd.Stdin = &in
 
@@ -161,8 +197,33 @@ func (fm FzfManagerImpl) SelectCommitAction(commit *model.Commit) (model.ActionT
 }
 
 func (fm FzfManagerImpl) SelectBranch(branches []*model.Branch) (*model.Branch, error) {
+	selected, err := fm.selectBranches(branches, false)
+	if err != nil || len(selected) == 0 {
+		return nil, err
+	}
+	return selected[0], nil
+}
+
+func (fm FzfManagerImpl) SelectBranches(branches []*model.Branch) (model.Branches, error) {
+	return fm.selectBranches(branches, true)
+}
+
+func (fm FzfManagerImpl) selectBranches(branches []*model.Branch, multi bool) (model.Branches, error) {
+	mode := "--no-multi"
+	if multi {
+		mode = "--multi" // TABで複数選択
+	}
+
+	// クエリと完全に一致する候補がある場合は fzf を開かずに選択する
+	if fm.selectOptions.Query != "" {
+		if branch, err := model.FindBranchByBranchName(branches, fm.selectOptions.Query); err == nil {
+			return model.Branches{branch}, nil
+		}
+	}
+
 	cmd := exec.Command("fzf",
 		"--ansi",
+		mode,
 		"--prompt=gitman-branch> ",
 		"--layout="+fm.fzfLayout,
 		"--preview", "echo {} | awk '{print $1}' | xargs git log --oneline --graph --decorate",
@@ -175,9 +236,10 @@ func (fm FzfManagerImpl) SelectBranch(branches []*model.Branch) (*model.Branch,
 	// 入力データの準備
 	var in bytes.Buffer
 	for _, branch := range branches {
-		in.WriteString(branch.RawGirBranchMessage + "\n")
+		in.WriteString(branch.GetFzfLine() + "\n")
 	}
 
+	cmd.Args = append(cmd.Args, fm.selectOptions.fzfArgs()...)
 	cmd.Stdin = &in
 
 	var out bytes.Buffer
@@ -193,19 +255,78 @@ func (fm FzfManagerImpl) SelectBranch(branches []*model.Branch) (*model.Branch,
 		}
 		return nil, fmt.Errorf("fzf failed: %w", err)
 	}
-	selected := strings.TrimSpace(out.String())
-	if selected == "" {
-		return nil, nil // 選択なしはエラーにせず空文字
+	// 選択された行が1行ずつ出力される
+	var selectedBranches model.Branches
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		branchName := strings.Fields(line)[0]
+		branch, err := model.FindBranchByBranchName(branches, branchName)
+		if err != nil {
+			return nil, err
+		}
+		selectedBranches = append(selectedBranches, branch)
 	}
 
-	branchName := strings.Fields(selected)[0]
-	branch, err := model.FindBranchByBranchName(branches, branchName)
-	if err != nil {
-		return nil, err
+	slog.Debug("Selected branches", "branches", selectedBranches)
+	return selectedBranches, nil
+}
+
+// 削除候補のブランチを全て選択した状態で表示し、削除するブランチを選択させる
+func (fm FzfManagerImpl) SelectPruneCandidates(candidates []*model.PruneCandidate) ([]*model.PruneCandidate, error) {
+	cmd := exec.Command("fzf",
+		"--ansi",
+		"--multi", // TABで選択を解除
+		"--bind", "load:select-all",
+		"--prompt=gitman-branch-prune> ",
+		"--header=selected branches will be deleted (TAB to deselect)",
+		"--layout="+fm.fzfLayout,
+		"--preview", "echo {} | awk '{print $1}' | xargs git log --oneline --graph --decorate",
+		"--preview-window=down:65%:nowrap",
+		"--bind", "ctrl-d:preview-down,ctrl-u:preview-up",
+		"--bind", "pgdn:preview-page-down,pgup:preview-page-up",
+		"--bind", "ctrl-s:toggle-preview",
+	)
+
+	// 入力データの準備
+	var in bytes.Buffer
+	for _, candidate := range candidates {
+		in.WriteString(candidate.GetFzfLine() + "\n")
+	}
+
+	cmd.Args = append(cmd.Args, fm.selectOptions.fzfArgs()...)
+	cmd.Stdin = &in
+
+	var out bytes.Buffer
+	cmd.Stdout = &out
+
+	if err := cmd.Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			// ユーザーがキャンセルした場合（ESCキーやCtrl+C）
+			if exitErr.ExitCode() == 1 || exitErr.ExitCode() == 130 {
+				slog.Debug("User cancelled prune branch selection")
+				return nil, nil
+			}
+		}
+		return nil, fmt.Errorf("fzf failed: %w", err)
+	}
+
+	// 選択された行が1行ずつ出力される
+	var selected []*model.PruneCandidate
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		candidate, err := model.FindPruneCandidateByBranchName(candidates, strings.Fields(line)[0])
+		if err != nil {
+			return nil, err
+		}
+		selected = append(selected, candidate)
 	}
-	slog.Debug("Selected branch", "branch", branch.Name)
-	return branch, nil
 
+	slog.Debug("Selected prune candidates", "candidates", selected)
+	return selected, nil
 }
 
 func (fm FzfManagerImpl) SelectBranchAction(branch *model.Branch) (model.ActionType, error) {
@@ -234,6 +355,15 @@ func (fm FzfManagerImpl) SelectBranchAction(branch *model.Branch) (model.ActionT
 		in.WriteString(branch.GetFzfInputForSelectActionType(actionType))
 	}
 
+	// アクション名が指定された場合は fzf を開かずに候補から選択する
+	if fm.selectOptions.Action != "" {
+		selected, err := fm.selectOptions.findActionLine(&in)
+		if err != nil {
+			return model.BranchActionTypes.Unknown, err
+		}
+		return model.ParseSelectedBranchActionType(selected)
+	}
+
 	slog.Debug("fzf input", "input", in.String())
 	cmd.Stdin = &in
 
@@ -263,9 +393,17 @@ func (fm FzfManagerImpl) SelectBranchAction(branch *model.Branch) (model.ActionT
 	return SelectedActionType, nil
 }
 
-func (fm FzfManagerImpl) SelectReflog(reflogs []*model.Reflog) (*model.Reflog, error) {
+func (fm FzfManagerImpl) SelectReflogs(reflogs []*model.Reflog) (model.Reflogs, error) {
+	// クエリと完全に一致する候補がある場合は fzf を開かずに選択する
+	if fm.selectOptions.Query != "" {
+		if reflog, err := model.FindReflogById(reflogs, fm.selectOptions.Query); err == nil {
+			return model.Reflogs{reflog}, nil
+		}
+	}
+
 	cmd := exec.Command("fzf",
 		"--ansi",
+		"--multi", // TABで複数選択
 		"--prompt=gitman-reflog> ",
 		"--layout="+fm.fzfLayout,
 		"--preview", "echo {} | awk '{print $1}' | xargs git show --stat --oneline",
@@ -278,9 +416,10 @@ func (fm FzfManagerImpl) SelectReflog(reflogs []*model.Reflog) (*model.Reflog, e
 	// 入力データの準備
 	var in bytes.Buffer
 	for _, reflog := range reflogs {
-		in.WriteString(reflog.RawReflog + "\n")
+		in.WriteString(reflog.GetFzfLine() + "\n")
 	}
 
+	cmd.Args = append(cmd.Args, fm.selectOptions.fzfArgs()...)
 	cmd.Stdin = &in
 
 	var out bytes.Buffer
@@ -297,22 +436,31 @@ func (fm FzfManagerImpl) SelectReflog(reflogs []*model.Reflog) (*model.Reflog, e
 		return nil, fmt.Errorf("fzf failed: %w", err)
 	}
 
-	selected := strings.TrimSpace(out.String())
-	if selected == "" {
-		return nil, nil // 選択なしはエラーにせず nil を返す
+	// 選択された行が1行ずつ出力される
+	// 同じコミットが複数回 reflog に現れることがあるため、選択された行の ref@{n} でreflogを検索
+	var headPoints []string
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("failed to parse selected reflog: %s", line)
+		}
+		headPoints = append(headPoints, fields[1])
+	}
+	// 選択なしはエラーにせず nil を返す
+	if len(headPoints) == 0 {
+		return nil, nil
 	}
 
-	// 選択された行からcommit IDを取得
-	reflogId := strings.Fields(selected)[0]
-
-	// commit IDでreflogを検索
-	reflog, err := model.FindReflogById(reflogs, reflogId)
+	selectedReflogs, err := model.FindReflogsByHeadPoints(reflogs, headPoints)
 	if err != nil {
 		return nil, err
 	}
 
-	slog.Debug("Selected reflog", "id", reflog.Id, "headPoint", reflog.HeadPoint, "message", reflog.Message)
-	return reflog, nil
+	slog.Debug("Selected reflogs", "reflogs", selectedReflogs)
+	return selectedReflogs, nil
 }
 
 func (fm FzfManagerImpl) SelectReflogAction(reflog *model.Reflog) (model.ActionType, error) {
@@ -341,6 +489,15 @@ func (fm FzfManagerImpl) SelectReflogAction(reflog *model.Reflog) (model.ActionT
 		in.WriteString(fzfInput)
 	}
 
+	// アクション名が指定された場合は fzf を開かずに候補から選択する
+	if fm.selectOptions.Action != "" {
+		selected, err := fm.selectOptions.findActionLine(&in)
+		if err != nil {
+			return model.ReflogActionTypes.Unknown, err
+		}
+		return model.ParseSelectedReflogActionType(selected)
+	}
+
 	slog.Debug("fzf input", "input", in.String())
 	cmd.Stdin = &in
 
@@ -369,3 +526,910 @@ func (fm FzfManagerImpl) SelectReflogAction(reflog *model.Reflog) (model.ActionT
 
 	return selectedActionType, nil
 }
+
+func (fm FzfManagerImpl) SelectStash(stashes []*model.Stash) (*model.Stash, error) {
+	// クエリと完全に一致する候補がある場合は fzf を開かずに選択する
+	if fm.selectOptions.Query != "" {
+		if stash, err := model.FindStashById(stashes, fm.selectOptions.Query); err == nil {
+			return stash, nil
+		}
+	}
+
+	cmd := exec.Command("fzf",
+		"--ansi",
+		"--prompt=gitman-stash> ",
+		"--layout="+fm.fzfLayout,
+		"--preview", "echo {} | cut -d: -f1 | xargs git stash show --color=always -p",
+		"--preview-window=right:60%:wrap",                 // 右側に60%、折り返し表示
+		"--bind", "ctrl-d:preview-down,ctrl-u:preview-up", // ctrl+d / ctrl+u で移動
+		"--bind", "pgdn:preview-page-down,pgup:preview-page-up",
+		"--bind", "ctrl-s:toggle-preview",
+	)
+
+	// 入力データの準備
+	var in bytes.Buffer
+	for _, stash := range stashes {
+		in.WriteString(stash.RawStash + "\n")
+	}
+
+	cmd.Args = append(cmd.Args, fm.selectOptions.fzfArgs()...)
+	cmd.Stdin = &in
+
+	var out bytes.Buffer
+	cmd.Stdout = &out
+
+	if err := cmd.Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			// ユーザーがキャンセルした場合（ESCキーやCtrl+C）
+			if exitErr.ExitCode() == 1 || exitErr.ExitCode() == 130 {
+				slog.Debug("User cancelled stash selection")
+				return nil, nil
+			}
+		}
+		return nil, fmt.Errorf("fzf failed: %w", err)
+	}
+
+	selected := strings.TrimSpace(out.String())
+	if selected == "" {
+		return nil, nil // 選択なしはエラーにせず nil を返す
+	}
+
+	stashId := model.ParseSelectedStashId(selected)
+	stash, err := model.FindStashById(stashes, stashId)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Selected stash", "id", stash.Id, "branch", stash.Branch, "message", stash.Message)
+	return stash, nil
+}
+
+func (fm FzfManagerImpl) SelectStashAction(stash *model.Stash) (model.ActionType, error) {
+	if stash == nil {
+		return model.StashActionTypes.Unknown, fmt.Errorf("stash cannot be nil")
+	}
+
+	// fzfコマンドの基本設定
+	cmd := exec.Command("fzf",
+		"--ansi",
+		"--layout="+fm.fzfLayout,
+		"--prompt=gitman-stash> ",
+		"--delimiter", "\t", // タブを区切りに指定
+		"--with-nth=1",                           // 1列目 (ActionName) だけを候補リストに表示
+		"--preview", "printf '%s\n%s\n' {2} {3}", // 2列目=fullCommand, 3列目=Help
+		"--preview-window=right:65%:wrap",
+		"--border",
+	)
+
+	// 入力データの準備
+	var in bytes.Buffer
+	slog.Debug("ActionTypes", "stash.ActionTypes", stash.ActionTypes)
+	for _, actionType := range stash.ActionTypes {
+		// fzfに渡す形式: "表示名\tフルコマンド\t説明文"
+		in.WriteString(stash.GetFzfInputForSelectActionType(actionType))
+	}
+
+	// アクション名が指定された場合は fzf を開かずに候補から選択する
+	if fm.selectOptions.Action != "" {
+		selected, err := fm.selectOptions.findActionLine(&in)
+		if err != nil {
+			return model.StashActionTypes.Unknown, err
+		}
+		return model.ParseSelectedStashActionType(selected)
+	}
+
+	slog.Debug("fzf input", "input", in.String())
+	cmd.Stdin = &in
+
+	var out bytes.Buffer
+	var errOut bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &errOut
+
+	// コマンド実行
+	if err := cmd.Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			// ユーザーがキャンセルした場合（ESCキーやCtrl+C）
+			if exitErr.ExitCode() == 1 || exitErr.ExitCode() == 130 {
+				slog.Debug("User cancelled stash action selection")
+				return model.StashActionTypes.Unknown, nil
+			}
+		}
+		return model.StashActionTypes.Unknown, fmt.Errorf("fzf failed: %w, stderr: %s", err, errOut.String())
+	}
+
+	selected := strings.TrimSpace(out.String())
+	selectedActionType, err := model.ParseSelectedStashActionType(selected)
+	if err != nil {
+		return model.StashActionTypes.Unknown, fmt.Errorf("failed to parse selected stash action type: %w", err)
+	}
+
+	return selectedActionType, nil
+}
+
+func (fm FzfManagerImpl) SelectSnapshot(snapshots []*model.Snapshot) (*model.Snapshot, error) {
+	// クエリと完全に一致する候補がある場合は fzf を開かずに選択する
+	if fm.selectOptions.Query != "" {
+		if snapshot, err := model.FindSnapshotById(snapshots, fm.selectOptions.Query); err == nil {
+			return snapshot, nil
+		}
+	}
+
+	cmd := exec.Command("fzf",
+		"--ansi",
+		"--prompt=gitman-undo> ",
+		"--layout="+fm.fzfLayout,
+		"--delimiter", "\t", // タブを区切りに指定
+		"--with-nth=1",                   // 1列目 (日時、ブランチ、コマンド) だけを候補リストに表示
+		"--preview", "printf '%b\n' {3}", // 3列目=スナップショットの詳細
+		"--preview-window=down:50%:wrap",
+		"--bind", "ctrl-s:toggle-preview",
+	)
+
+	// 入力データの準備
+	var in bytes.Buffer
+	for _, snapshot := range snapshots {
+		in.WriteString(snapshot.GetFzfLine() + "\n")
+	}
+
+	cmd.Args = append(cmd.Args, fm.selectOptions.fzfArgs()...)
+	cmd.Stdin = &in
+
+	var out bytes.Buffer
+	cmd.Stdout = &out
+
+	if err := cmd.Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			// ユーザーがキャンセルした場合（ESCキーやCtrl+C）
+			if exitErr.ExitCode() == 1 || exitErr.ExitCode() == 130 {
+				slog.Debug("User cancelled snapshot selection")
+				return nil, nil
+			}
+		}
+		return nil, fmt.Errorf("fzf failed: %w", err)
+	}
+
+	selected := strings.TrimSpace(out.String())
+	if selected == "" {
+		return nil, nil // 選択なしはエラーにせず nil を返す
+	}
+
+	snapshot, err := model.FindSnapshotById(snapshots, model.ParseSelectedSnapshotId(selected))
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Selected snapshot", "id", snapshot.Id, "command", snapshot.Command)
+	return snapshot, nil
+}
+
+// InputText は fzf をテキスト入力欄として使い、入力された文字列を返す
+// defaultValue は入力欄の初期値として表示する。キャンセルされた場合は空文字を返す
+func (fm FzfManagerImpl) InputText(prompt string, defaultValue string) (string, error) {
+	cmd := exec.Command("fzf",
+		"--layout="+fm.fzfLayout,
+		"--prompt="+prompt,
+		"--query="+defaultValue,
+		"--print-query", // 候補がなくても入力された文字列を出力する
+		"--no-info",
+		"--height=3",
+	)
+
+	// 候補は不要なため空の入力を渡す
+	cmd.Stdin = &bytes.Buffer{}
+
+	var out bytes.Buffer
+	var errOut bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &errOut
+
+	if err := cmd.Run(); err != nil {
+		exitErr, ok := err.(*exec.ExitError)
+		if !ok {
+			return "", fmt.Errorf("fzf failed: %w, stderr: %s", err, errOut.String())
+		}
+		switch exitErr.ExitCode() {
+		case 1:
+			// 候補に一致しない場合も--print-queryにより入力値は出力されている
+		case 130:
+			// ユーザーがキャンセルした場合（ESCキーやCtrl+C）
+			slog.Debug("User cancelled text input")
+			return "", nil
+		default:
+			return "", fmt.Errorf("fzf failed: %w, stderr: %s", err, errOut.String())
+		}
+	}
+
+	// 1行目がクエリ(入力値)
+	input := strings.TrimSpace(strings.SplitN(out.String(), "\n", 2)[0])
+	slog.Debug("Input text", "prompt", prompt, "input", input)
+	return input, nil
+}
+
+func (fm FzfManagerImpl) SelectTags(tags []*model.Tag) (model.Tags, error) {
+	// クエリと完全に一致する候補がある場合は fzf を開かずに選択する
+	if fm.selectOptions.Query != "" {
+		if tag, err := model.FindTagByName(tags, fm.selectOptions.Query); err == nil {
+			return model.Tags{tag}, nil
+		}
+	}
+
+	cmd := exec.Command("fzf",
+		"--ansi",
+		"--multi", // TABで複数選択
+		"--prompt=gitman-tag> ",
+		"--layout="+fm.fzfLayout,
+		// 注釈付きタグのメッセージとタグからのログを表示する
+		"--preview", "git tag -l --format='%(contents)' {1}; git log --oneline --graph --decorate --color=always {1}",
+		"--preview-window=down:65%:nowrap",                // 下側に65%、折り返しなし
+		"--bind", "ctrl-d:preview-down,ctrl-u:preview-up", // ctrl+d / ctrl+u で移動
+		"--bind", "pgdn:preview-page-down,pgup:preview-page-up",
+		"--bind", "ctrl-s:toggle-preview",
+	)
+
+	// 入力データの準備
+	var in bytes.Buffer
+	for _, tag := range tags {
+		in.WriteString(tag.GetFzfLine() + "\n")
+	}
+
+	cmd.Args = append(cmd.Args, fm.selectOptions.fzfArgs()...)
+	cmd.Stdin = &in
+
+	var out bytes.Buffer
+	cmd.Stdout = &out
+
+	if err := cmd.Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			// ユーザーがキャンセルした場合（ESCキーやCtrl+C）
+			if exitErr.ExitCode() == 1 || exitErr.ExitCode() == 130 {
+				slog.Debug("User cancelled tag selection")
+				return nil, nil
+			}
+		}
+		return nil, fmt.Errorf("fzf failed: %w", err)
+	}
+
+	// 選択された行が1行ずつ出力される
+	var selectedTags model.Tags
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		tag, err := model.FindTagByName(tags, strings.Fields(line)[0])
+		if err != nil {
+			return nil, err
+		}
+		selectedTags = append(selectedTags, tag)
+	}
+
+	slog.Debug("Selected tags", "tags", selectedTags)
+	return selectedTags, nil
+}
+
+func (fm FzfManagerImpl) SelectTagAction(tag *model.Tag) (model.ActionType, error) {
+	if tag == nil {
+		return model.TagActionTypes.Unknown, fmt.Errorf("tag cannot be nil")
+	}
+
+	// fzfコマンドの基本設定
+	cmd := exec.Command("fzf",
+		"--ansi",
+		"--layout="+fm.fzfLayout,
+		"--prompt=gitman-tag> ",
+		"--delimiter", "\t", // タブを区切りに指定
+		"--with-nth=1",                           // 1列目 (ActionName) だけを候補リストに表示
+		"--preview", "printf '%s\n%s\n' {2} {3}", // 2列目=fullCommand, 3列目=Help
+		"--preview-window=right:65%:wrap",
+		"--border",
+	)
+
+	// 入力データの準備
+	var in bytes.Buffer
+	slog.Debug("ActionTypes", "tag.ActionTypes", tag.ActionTypes)
+	for _, actionType := range tag.ActionTypes {
+		// fzfに渡す形式: "表示名\tフルコマンド\t説明文"
+		in.WriteString(tag.GetFzfInputForSelectActionType(actionType))
+	}
+
+	// アクション名が指定された場合は fzf を開かずに候補から選択する
+	if fm.selectOptions.Action != "" {
+		selected, err := fm.selectOptions.findActionLine(&in)
+		if err != nil {
+			return model.TagActionTypes.Unknown, err
+		}
+		return model.ParseSelectedTagActionType(selected)
+	}
+
+	slog.Debug("fzf input", "input", in.String())
+	cmd.Stdin = &in
+
+	var out bytes.Buffer
+	var errOut bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &errOut
+
+	// コマンド実行
+	if err := cmd.Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			// ユーザーがキャンセルした場合（ESCキーやCtrl+C）
+			if exitErr.ExitCode() == 1 || exitErr.ExitCode() == 130 {
+				slog.Debug("User cancelled tag action selection")
+				return model.TagActionTypes.Unknown, nil
+			}
+		}
+		return model.TagActionTypes.Unknown, fmt.Errorf("fzf failed: %w, stderr: %s", err, errOut.String())
+	}
+
+	selected := strings.TrimSpace(out.String())
+	selectedActionType, err := model.ParseSelectedTagActionType(selected)
+	if err != nil {
+		return model.TagActionTypes.Unknown, fmt.Errorf("failed to parse selected tag action type: %w", err)
+	}
+
+	return selectedActionType, nil
+}
+
+func (fm FzfManagerImpl) SelectWorktree(worktrees []*model.Worktree) (*model.Worktree, error) {
+	// クエリと完全に一致する候補がある場合は fzf を開かずに選択する
+	if fm.selectOptions.Query != "" {
+		if worktree, err := model.FindWorktreeByPath(worktrees, fm.selectOptions.Query); err == nil {
+			return worktree, nil
+		}
+	}
+
+	cmd := exec.Command("fzf",
+		"--ansi",
+		"--prompt=gitman-worktree> ",
+		"--layout="+fm.fzfLayout,
+		"--delimiter", "\t", // タブを区切りに指定 (1列目=パス)
+		"--preview", "git -C {1} status --short --branch; git -C {1} log --oneline --graph --decorate --color=always -n 30",
+		"--preview-window=down:65%:nowrap",                // 下側に65%、折り返しなし
+		"--bind", "ctrl-d:preview-down,ctrl-u:preview-up", // ctrl+d / ctrl+u で移動
+		"--bind", "pgdn:preview-page-down,pgup:preview-page-up",
+		"--bind", "ctrl-s:toggle-preview",
+	)
+
+	// 入力データの準備
+	var in bytes.Buffer
+	for _, worktree := range worktrees {
+		in.WriteString(worktree.GetFzfLine() + "\n")
+	}
+
+	cmd.Args = append(cmd.Args, fm.selectOptions.fzfArgs()...)
+	cmd.Stdin = &in
+
+	var out bytes.Buffer
+	cmd.Stdout = &out
+
+	if err := cmd.Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			// ユーザーがキャンセルした場合（ESCキーやCtrl+C）
+			if exitErr.ExitCode() == 1 || exitErr.ExitCode() == 130 {
+				slog.Debug("User cancelled worktree selection")
+				return nil, nil
+			}
+		}
+		return nil, fmt.Errorf("fzf failed: %w", err)
+	}
+
+	selected := strings.TrimSpace(out.String())
+	if selected == "" {
+		return nil, nil // 選択なしはエラーにせず nil を返す
+	}
+
+	path := model.ParseSelectedWorktreePath(selected)
+	worktree, err := model.FindWorktreeByPath(worktrees, path)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Selected worktree", "path", worktree.Path, "branch", worktree.Branch)
+	return worktree, nil
+}
+
+func (fm FzfManagerImpl) SelectWorktreeAction(worktree *model.Worktree) (model.ActionType, error) {
+	if worktree == nil {
+		return model.WorktreeActionTypes.Unknown, fmt.Errorf("worktree cannot be nil")
+	}
+
+	// fzfコマンドの基本設定
+	cmd := exec.Command("fzf",
+		"--ansi",
+		"--layout="+fm.fzfLayout,
+		"--prompt=gitman-worktree> ",
+		"--delimiter", "\t", // タブを区切りに指定
+		"--with-nth=1",                           // 1列目 (ActionName) だけを候補リストに表示
+		"--preview", "printf '%s\n%s\n' {2} {3}", // 2列目=fullCommand, 3列目=Help
+		"--preview-window=right:65%:wrap",
+		"--border",
+	)
+
+	// 入力データの準備
+	var in bytes.Buffer
+	slog.Debug("ActionTypes", "worktree.ActionTypes", worktree.ActionTypes)
+	for _, actionType := range worktree.ActionTypes {
+		// fzfに渡す形式: "表示名\tフルコマンド\t説明文"
+		in.WriteString(worktree.GetFzfInputForSelectActionType(actionType))
+	}
+
+	// アクション名が指定された場合は fzf を開かずに候補から選択する
+	if fm.selectOptions.Action != "" {
+		selected, err := fm.selectOptions.findActionLine(&in)
+		if err != nil {
+			return model.WorktreeActionTypes.Unknown, err
+		}
+		return model.ParseSelectedWorktreeActionType(selected)
+	}
+
+	slog.Debug("fzf input", "input", in.String())
+	cmd.Stdin = &in
+
+	var out bytes.Buffer
+	var errOut bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &errOut
+
+	// コマンド実行
+	if err := cmd.Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			// ユーザーがキャンセルした場合（ESCキーやCtrl+C）
+			if exitErr.ExitCode() == 1 || exitErr.ExitCode() == 130 {
+				slog.Debug("User cancelled worktree action selection")
+				return model.WorktreeActionTypes.Unknown, nil
+			}
+		}
+		return model.WorktreeActionTypes.Unknown, fmt.Errorf("fzf failed: %w, stderr: %s", err, errOut.String())
+	}
+
+	selected := strings.TrimSpace(out.String())
+	selectedActionType, err := model.ParseSelectedWorktreeActionType(selected)
+	if err != nil {
+		return model.WorktreeActionTypes.Unknown, fmt.Errorf("failed to parse selected worktree action type: %w", err)
+	}
+
+	return selectedActionType, nil
+}
+
+func (fm FzfManagerImpl) SelectRemote(remotes []*model.Remote) (*model.Remote, error) {
+	// クエリと完全に一致する候補がある場合は fzf を開かずに選択する
+	if fm.selectOptions.Query != "" {
+		if remote, err := model.FindRemoteByName(remotes, fm.selectOptions.Query); err == nil {
+			return remote, nil
+		}
+	}
+
+	cmd := exec.Command("fzf",
+		"--ansi",
+		"--prompt=gitman-remote> ",
+		"--layout="+fm.fzfLayout,
+		"--delimiter", "\t", // タブを区切りに指定 (1列目=リモート名)
+		"--preview", "git remote show {1}",
+		"--preview-window=down:65%:nowrap",                // 下側に65%、折り返しなし
+		"--bind", "ctrl-d:preview-down,ctrl-u:preview-up", // ctrl+d / ctrl+u で移動
+		"--bind", "pgdn:preview-page-down,pgup:preview-page-up",
+		"--bind", "ctrl-s:toggle-preview",
+	)
+
+	// 入力データの準備
+	var in bytes.Buffer
+	for _, remote := range remotes {
+		in.WriteString(remote.GetFzfLine() + "\n")
+	}
+
+	cmd.Args = append(cmd.Args, fm.selectOptions.fzfArgs()...)
+	cmd.Stdin = &in
+
+	var out bytes.Buffer
+	cmd.Stdout = &out
+
+	if err := cmd.Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			// ユーザーがキャンセルした場合（ESCキーやCtrl+C）
+			if exitErr.ExitCode() == 1 || exitErr.ExitCode() == 130 {
+				slog.Debug("User cancelled remote selection")
+				return nil, nil
+			}
+		}
+		return nil, fmt.Errorf("fzf failed: %w", err)
+	}
+
+	selected := strings.TrimSpace(out.String())
+	if selected == "" {
+		return nil, nil // 選択なしはエラーにせず nil を返す
+	}
+
+	remote, err := model.FindRemoteByName(remotes, model.ParseSelectedRemoteName(selected))
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Selected remote", "name", remote.Name, "url", remote.FetchURL)
+	return remote, nil
+}
+
+func (fm FzfManagerImpl) SelectRemoteAction(remote *model.Remote) (model.ActionType, error) {
+	if remote == nil {
+		return model.RemoteActionTypes.Unknown, fmt.Errorf("remote cannot be nil")
+	}
+
+	// fzfコマンドの基本設定
+	cmd := exec.Command("fzf",
+		"--ansi",
+		"--layout="+fm.fzfLayout,
+		"--prompt=gitman-remote> ",
+		"--delimiter", "\t", // タブを区切りに指定
+		"--with-nth=1",                           // 1列目 (ActionName) だけを候補リストに表示
+		"--preview", "printf '%s\n%s\n' {2} {3}", // 2列目=fullCommand, 3列目=Help
+		"--preview-window=right:65%:wrap",
+		"--border",
+	)
+
+	// 入力データの準備
+	var in bytes.Buffer
+	slog.Debug("ActionTypes", "remote.ActionTypes", remote.ActionTypes)
+	for _, actionType := range remote.ActionTypes {
+		// fzfに渡す形式: "表示名\tフルコマンド\t説明文"
+		in.WriteString(remote.GetFzfInputForSelectActionType(actionType))
+	}
+
+	// アクション名が指定された場合は fzf を開かずに候補から選択する
+	if fm.selectOptions.Action != "" {
+		selected, err := fm.selectOptions.findActionLine(&in)
+		if err != nil {
+			return model.RemoteActionTypes.Unknown, err
+		}
+		return model.ParseSelectedRemoteActionType(selected)
+	}
+
+	slog.Debug("fzf input", "input", in.String())
+	cmd.Stdin = &in
+
+	var out bytes.Buffer
+	var errOut bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &errOut
+
+	// コマンド実行
+	if err := cmd.Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			// ユーザーがキャンセルした場合（ESCキーやCtrl+C）
+			if exitErr.ExitCode() == 1 || exitErr.ExitCode() == 130 {
+				slog.Debug("User cancelled remote action selection")
+				return model.RemoteActionTypes.Unknown, nil
+			}
+		}
+		return model.RemoteActionTypes.Unknown, fmt.Errorf("fzf failed: %w, stderr: %s", err, errOut.String())
+	}
+
+	selected := strings.TrimSpace(out.String())
+	selectedActionType, err := model.ParseSelectedRemoteActionType(selected)
+	if err != nil {
+		return model.RemoteActionTypes.Unknown, fmt.Errorf("failed to parse selected remote action type: %w", err)
+	}
+
+	return selectedActionType, nil
+}
+
+func (fm FzfManagerImpl) SelectFileStatuses(files []*model.FileStatus) (model.FileStatuses, error) {
+	return fm.selectFileStatuses("gitman-status> ", files)
+}
+
+// 競合しているファイルを選択させる (プレビューには競合している箇所を表示する)
+func (fm FzfManagerImpl) SelectConflictedFiles(files []*model.FileStatus) (model.FileStatuses, error) {
+	return fm.selectFileStatuses("gitman-conflicts> ", files)
+}
+
+func (fm FzfManagerImpl) selectFileStatuses(prompt string, files []*model.FileStatus) (model.FileStatuses, error) {
+	// クエリと完全に一致する候補がある場合は fzf を開かずに選択する
+	if fm.selectOptions.Query != "" {
+		if file, err := model.FindFileStatusByPath(files, fm.selectOptions.Query); err == nil {
+			return model.FileStatuses{file}, nil
+		}
+	}
+
+	cmd := exec.Command("fzf",
+		"--ansi",
+		"--multi", // TABで複数選択
+		"--prompt="+prompt,
+		"--layout="+fm.fzfLayout,
+		"--delimiter", "\t", // タブを区切りに指定
+		"--with-nth=1", // 1列目 (状態とパス) だけを候補リストに表示
+		// 3列目=ファイルの状態に応じた差分コマンド (パスはルートからの相対パス)
+		"--preview", "cd \"$(git rev-parse --show-toplevel)\" && eval {3}",
+		"--preview-window=right:60%:wrap",                 // 右側に60%、折り返し表示
+		"--bind", "ctrl-d:preview-down,ctrl-u:preview-up", // ctrl+d / ctrl+u で移動
+		"--bind", "pgdn:preview-page-down,pgup:preview-page-up",
+		"--bind", "ctrl-s:toggle-preview",
+	)
+
+	// 入力データの準備
+	var in bytes.Buffer
+	for _, file := range files {
+		in.WriteString(file.GetFzfLine() + "\n")
+	}
+
+	cmd.Args = append(cmd.Args, fm.selectOptions.fzfArgs()...)
+	cmd.Stdin = &in
+
+	var out bytes.Buffer
+	cmd.Stdout = &out
+
+	if err := cmd.Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			// ユーザーがキャンセルした場合（ESCキーやCtrl+C）
+			if exitErr.ExitCode() == 1 || exitErr.ExitCode() == 130 {
+				slog.Debug("User cancelled file selection")
+				return nil, nil
+			}
+		}
+		return nil, fmt.Errorf("fzf failed: %w", err)
+	}
+
+	// 選択された行が1行ずつ出力される
+	var selectedFiles model.FileStatuses
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		file, err := model.FindFileStatusByPath(files, model.ParseSelectedFileStatusPath(line))
+		if err != nil {
+			return nil, err
+		}
+		selectedFiles = append(selectedFiles, file)
+	}
+
+	slog.Debug("Selected files", "files", selectedFiles)
+	return selectedFiles, nil
+}
+
+func (fm FzfManagerImpl) SelectFileStatusAction(files model.FileStatuses, actionTypes []model.ActionType) (model.ActionType, error) {
+	if len(files) == 0 {
+		return model.FileStatusActionTypes.Unknown, fmt.Errorf("files cannot be empty")
+	}
+
+	// fzfコマンドの基本設定
+	cmd := exec.Command("fzf",
+		"--ansi",
+		"--layout="+fm.fzfLayout,
+		"--prompt=gitman-status> ",
+		"--delimiter", "\t", // タブを区切りに指定
+		"--with-nth=1",                           // 1列目 (ActionName) だけを候補リストに表示
+		"--preview", "printf '%s\n%s\n' {2} {3}", // 2列目=fullCommand, 3列目=Help
+		"--preview-window=right:65%:wrap",
+		"--border",
+	)
+
+	// 入力データの準備
+	var in bytes.Buffer
+	for _, actionType := range actionTypes {
+		// fzfに渡す形式: "表示名\tフルコマンド\t説明文"
+		in.WriteString(files.GetFzfInputForSelectActionType(actionType))
+	}
+
+	// アクション名が指定された場合は fzf を開かずに候補から選択する
+	if fm.selectOptions.Action != "" {
+		selected, err := fm.selectOptions.findActionLine(&in)
+		if err != nil {
+			return model.FileStatusActionTypes.Unknown, err
+		}
+		return model.ParseSelectedFileStatusActionType(selected)
+	}
+
+	slog.Debug("fzf input", "input", in.String())
+	cmd.Stdin = &in
+
+	var out bytes.Buffer
+	var errOut bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &errOut
+
+	// コマンド実行
+	if err := cmd.Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			// ユーザーがキャンセルした場合（ESCキーやCtrl+C）
+			if exitErr.ExitCode() == 1 || exitErr.ExitCode() == 130 {
+				slog.Debug("User cancelled file action selection")
+				return model.FileStatusActionTypes.Unknown, nil
+			}
+		}
+		return model.FileStatusActionTypes.Unknown, fmt.Errorf("fzf failed: %w, stderr: %s", err, errOut.String())
+	}
+
+	selected := strings.TrimSpace(out.String())
+	selectedActionType, err := model.ParseSelectedFileStatusActionType(selected)
+	if err != nil {
+		return model.FileStatusActionTypes.Unknown, fmt.Errorf("failed to parse selected file action type: %w", err)
+	}
+
+	return selectedActionType, nil
+}
+
+func (fm FzfManagerImpl) SelectCommitsAction(commits model.Commits, actionTypes []model.ActionType) (model.ActionType, error) {
+	// 入力データの準備 (複数のコミットに実行できるアクションのみ)
+	var in bytes.Buffer
+	for _, actionType := range actionTypes {
+		// fzfに渡す形式: "表示名\tフルコマンド\t説明文"
+		in.WriteString(commits.GetFzfInputForSelectActionType(actionType))
+	}
+
+	selected, err := fm.selectActionLine("gitman-log> ", &in)
+	if err != nil {
+		return model.CommitActionTypes.Unknown, err
+	}
+
+	selectedActionType, err := model.ParseSelectedCommitActionType(selected)
+	if err != nil {
+		return model.CommitActionTypes.Unknown, fmt.Errorf("failed to parse selected commit action type: %w", err)
+	}
+	return selectedActionType, nil
+}
+
+func (fm FzfManagerImpl) SelectBranchesAction(branches model.Branches, actionTypes []model.ActionType) (model.ActionType, error) {
+	// 入力データの準備 (複数のブランチに実行できるアクションのみ)
+	var in bytes.Buffer
+	for _, actionType := range actionTypes {
+		// fzfに渡す形式: "表示名\tフルコマンド\t説明文"
+		in.WriteString(branches.GetFzfInputForSelectActionType(actionType))
+	}
+
+	selected, err := fm.selectActionLine("gitman-branch> ", &in)
+	if err != nil {
+		return model.BranchActionTypes.Unknown, err
+	}
+
+	selectedActionType, err := model.ParseSelectedBranchActionType(selected)
+	if err != nil {
+		return model.BranchActionTypes.Unknown, fmt.Errorf("failed to parse selected branch action type: %w", err)
+	}
+	return selectedActionType, nil
+}
+
+func (fm FzfManagerImpl) SelectReflogsAction(reflogs model.Reflogs, actionTypes []model.ActionType) (model.ActionType, error) {
+	// 入力データの準備 (複数の reflog に実行できるアクションのみ)
+	var in bytes.Buffer
+	for _, actionType := range actionTypes {
+		// fzfに渡す形式: "表示名\tフルコマンド\t説明文"
+		in.WriteString(reflogs.GetFzfInputForSelectActionType(actionType))
+	}
+
+	selected, err := fm.selectActionLine("gitman-reflog> ", &in)
+	if err != nil {
+		return model.ReflogActionTypes.Unknown, err
+	}
+
+	selectedActionType, err := model.ParseSelectedReflogActionType(selected)
+	if err != nil {
+		return model.ReflogActionTypes.Unknown, fmt.Errorf("failed to parse selected reflog action type: %w", err)
+	}
+	return selectedActionType, nil
+}
+
+func (fm FzfManagerImpl) SelectConflictAction(conflicts model.Conflicts, actionTypes []model.ActionType) (model.ActionType, error) {
+	// 入力データの準備 (選択されたファイルと途中の操作に対して実行できるアクションのみ)
+	var in bytes.Buffer
+	for _, actionType := range actionTypes {
+		// fzfに渡す形式: "表示名\tフルコマンド\t説明文"
+		in.WriteString(conflicts.GetFzfInputForSelectActionType(actionType))
+	}
+
+	selected, err := fm.selectActionLine("gitman-conflicts> ", &in)
+	if err != nil {
+		return model.ConflictActionTypes.Unknown, err
+	}
+
+	selectedActionType, err := model.ParseSelectedConflictActionType(selected)
+	if err != nil {
+		return model.ConflictActionTypes.Unknown, fmt.Errorf("failed to parse selected conflict action type: %w", err)
+	}
+	return selectedActionType, nil
+}
+
+func (fm FzfManagerImpl) SelectTagsAction(tags model.Tags, actionTypes []model.ActionType) (model.ActionType, error) {
+	// 入力データの準備 (複数のタグに実行できるアクションのみ)
+	var in bytes.Buffer
+	for _, actionType := range actionTypes {
+		// fzfに渡す形式: "表示名\tフルコマンド\t説明文"
+		in.WriteString(tags.GetFzfInputForSelectActionType(actionType))
+	}
+
+	selected, err := fm.selectActionLine("gitman-tag> ", &in)
+	if err != nil {
+		return model.TagActionTypes.Unknown, err
+	}
+
+	selectedActionType, err := model.ParseSelectedTagActionType(selected)
+	if err != nil {
+		return model.TagActionTypes.Unknown, fmt.Errorf("failed to parse selected tag action type: %w", err)
+	}
+	return selectedActionType, nil
+}
+
+// WithoutSelectOptions は --query や --select-1 などの選択のオプションを使わない FzfManager を返す
+// 最初の選択のためのオプションが、続けて開く選択 (例: 上流ブランチ、push 先のリモート) に適用されないようにする
+func (fm FzfManagerImpl) WithoutSelectOptions() FzfManager {
+	fm.selectOptions = SelectOptions{}
+	return &fm
+}
+
+// GetSelectOptions は --query や --exit-0 などの選択のオプションを返す
+// 候補がない場合など、fzf を開く前に処理を決める場合に使う
+func (fm FzfManagerImpl) GetSelectOptions() SelectOptions {
+	return fm.selectOptions
+}
+
+// Confirm は message を表示して yes/no を選択させ、yes が選択された場合に true を返す
+// キャンセルされた場合は false を返す
+func (fm FzfManagerImpl) Confirm(message string) (bool, error) {
+	cmd := exec.Command("fzf",
+		"--layout="+fm.fzfLayout,
+		"--prompt=confirm> ",
+		"--header="+message,
+		"--no-multi",
+		"--no-info",
+		"--height=~10",
+	)
+
+	// 誤操作を防ぐため no を先頭にする
+	cmd.Stdin = strings.NewReader("no\nyes\n")
+
+	var out bytes.Buffer
+	var errOut bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &errOut
+
+	if err := cmd.Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			// ユーザーがキャンセルした場合（ESCキーやCtrl+C）
+			if exitErr.ExitCode() == 1 || exitErr.ExitCode() == 130 {
+				slog.Debug("User cancelled confirmation")
+				return false, nil
+			}
+		}
+		return false, fmt.Errorf("fzf failed: %w, stderr: %s", err, errOut.String())
+	}
+
+	return strings.TrimSpace(out.String()) == "yes", nil
+}
+
+// selectActionLine は "表示名\tフルコマンド\t説明文" 形式の候補からアクションを選択させ、選択された行を返す
+// キャンセルされた場合は空文字を返す
+func (fm FzfManagerImpl) selectActionLine(prompt string, in *bytes.Buffer) (string, error) {
+	// アクション名が指定された場合は fzf を開かずに候補から選択する
+	if fm.selectOptions.Action != "" {
+		return fm.selectOptions.findActionLine(in)
+	}
+
+	cmd := exec.Command("fzf",
+		"--ansi",
+		"--layout="+fm.fzfLayout,
+		"--prompt="+prompt,
+		"--delimiter", "\t", // タブを区切りに指定
+		"--with-nth=1",                           // 1列目 (ActionName) だけを候補リストに表示
+		"--preview", "printf '%s\n%s\n' {2} {3}", // 2列目=fullCommand, 3列目=Help
+		"--preview-window=right:65%:wrap",
+		"--border",
+	)
+
+	slog.Debug("fzf input", "input", in.String())
+	cmd.Stdin = in
+
+	var out bytes.Buffer
+	var errOut bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &errOut
+
+	// コマンド実行
+	if err := cmd.Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			// ユーザーがキャンセルした場合（ESCキーやCtrl+C）
+			if exitErr.ExitCode() == 1 || exitErr.ExitCode() == 130 {
+				slog.Debug("User cancelled action selection")
+				return "", nil
+			}
+		}
+		return "", fmt.Errorf("fzf failed: %w, stderr: %s", err, errOut.String())
+	}
+
+	return strings.TrimSpace(out.String()), nil
+}
@@ -0,0 +1,44 @@
+package fzf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// スクリプトから fzf を開かずに選択するためのオプション
+type SelectOptions struct {
+	// 候補の初期クエリ (完全に一致する候補がある場合は fzf を開かずに選択する)
+	Query string
+	// 候補が1つだけの場合は自動で選択する
+	Select1 bool
+	// 候補が1つもない場合は何も選択せずに終了する
+	Exit0 bool
+	// 実行するアクション名 (指定された場合は fzf を開かずに選択する)
+	Action string
+}
+
+// 候補を選択する fzf に追加する引数を返す
+func (so SelectOptions) fzfArgs() []string {
+	var args []string
+	if so.Query != "" {
+		args = append(args, "--query", so.Query)
+	}
+	if so.Select1 {
+		args = append(args, "--select-1")
+	}
+	if so.Exit0 {
+		args = append(args, "--exit-0")
+	}
+	return args
+}
+
+// "表示名\tフルコマンド\t説明文" 形式の候補から Action と名前が一致する行を返す
+func (so SelectOptions) findActionLine(in *bytes.Buffer) (string, error) {
+	for _, line := range strings.Split(in.String(), "\n") {
+		if strings.Split(line, "\t")[0] == so.Action {
+			return line, nil
+		}
+	}
+	return "", fmt.Errorf("action is not available: %s", so.Action)
+}
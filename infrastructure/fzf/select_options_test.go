@@ -0,0 +1,117 @@
+package fzf
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestSelectOptions_fzfArgs(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name          string
+		selectOptions SelectOptions
+		want          []string
+	}{
+		{
+			name:          "オプションが指定されていない場合は引数を追加しないこと",
+			selectOptions: SelectOptions{},
+			want:          nil,
+		},
+		{
+			name: "クエリと自動選択のオプションをfzfの引数に変換すること",
+			selectOptions: SelectOptions{
+				Query:   "feature",
+				Select1: true,
+				Exit0:   true,
+			},
+			want: []string{"--query", "feature", "--select-1", "--exit-0"},
+		},
+		{
+			name: "アクション名はfzfの引数に含めないこと",
+			selectOptions: SelectOptions{
+				Action: "switch",
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.selectOptions.fzfArgs(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SelectOptions.fzfArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectOptions_findActionLine(t *testing.T) {
+	t.Parallel()
+	in := "switch\tDescription : Switch to the branch\tCommand     : git switch main\n" +
+		"switch track\tDescription : Track the remote branch\tCommand     : git switch --track origin/main\n"
+	type args struct {
+		in string
+	}
+	tests := []struct {
+		name           string
+		selectOptions  SelectOptions
+		args           args
+		want           string
+		wantErr        bool
+		wantErrMessage error
+	}{
+		{
+			name: "アクション名が完全に一致する行を返すこと",
+			selectOptions: SelectOptions{
+				Action: "switch",
+			},
+			args: args{
+				in: in,
+			},
+			want:           "switch\tDescription : Switch to the branch\tCommand     : git switch main",
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "空白を含むアクション名も一致する行を返すこと",
+			selectOptions: SelectOptions{
+				Action: "switch track",
+			},
+			args: args{
+				in: in,
+			},
+			want:           "switch track\tDescription : Track the remote branch\tCommand     : git switch --track origin/main",
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "前方一致するだけのアクション名の場合、errorを返却すること",
+			selectOptions: SelectOptions{
+				Action: "swi",
+			},
+			args: args{
+				in: in,
+			},
+			want:           "",
+			wantErr:        true,
+			wantErrMessage: fmt.Errorf("action is not available: %s", "swi"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := tt.selectOptions.findActionLine(bytes.NewBufferString(tt.args.in))
+			if (err != nil) != tt.wantErr || err != nil && err.Error() != tt.wantErrMessage.Error() {
+				t.Errorf("SelectOptions.findActionLine() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("SelectOptions.findActionLine() error message = %v, wantErrMessage %v", err, tt.wantErrMessage)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("SelectOptions.findActionLine() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,12 +1,45 @@
 package git
 
-import "gitman/domain/model"
+import (
+	"context"
+	"gitman/domain/model"
+	"iter"
+)
 
 type GitManager interface {
-	GetCommits() ([]*model.Commit, error)
+	GetCommits(filter model.CommitFilter) ([]*model.Commit, error)
+	StreamCommits(ctx context.Context, filter model.CommitFilter) iter.Seq2[*model.Commit, error]
+	ResolveCommitId(rev string) (string, error)
 	GetBranches() ([]*model.Branch, error)
-	GetReflogs() ([]*model.Reflog, error)
+	GetMergedBranchNames(base string) ([]string, error)
+	GetDefaultBranch() (string, error)
+	FetchPrune() error
+	GetReflogs(filter model.ReflogFilter) ([]*model.Reflog, error)
+	GetStashes() ([]*model.Stash, error)
+	GetTags() ([]*model.Tag, error)
+	GetWorktrees() ([]*model.Worktree, error)
+	GetRemotes() ([]*model.Remote, error)
+	GetPushRemote() (string, error)
+	GetTopLevelDir() (string, error)
+	GetFileStatuses() ([]*model.FileStatus, error)
+	GetRepoState() (model.RepoState, error)
+	CheckBranchName(name string) error
+	CheckTagName(name string) error
+	GetDestructiveSummary(scope model.DestructiveScope) (*model.DestructiveSummary, error)
+	GetSnapshots() ([]*model.Snapshot, error)
+	RecordSnapshot(command string) (*model.Snapshot, error)
+	RestoreSnapshot(snapshot *model.Snapshot) error
 	ExecuteCommitActionCommand(actionType model.ActionType, commit *model.Commit) error
+	ExecuteCommitsActionCommand(actionType model.ActionType, commits model.Commits) error
 	ExecuteBranchActionCommand(actionType model.ActionType, branch *model.Branch) error
+	ExecuteBranchesActionCommand(actionType model.ActionType, branches model.Branches) error
 	ExecuteReflogActionCommand(actionType model.ActionType, reflog *model.Reflog) error
+	ExecuteReflogsActionCommand(actionType model.ActionType, reflogs model.Reflogs) error
+	ExecuteStashActionCommand(actionType model.ActionType, stash *model.Stash) error
+	ExecuteTagActionCommand(actionType model.ActionType, tag *model.Tag) error
+	ExecuteTagsActionCommand(actionType model.ActionType, tags model.Tags) error
+	ExecuteWorktreeActionCommand(actionType model.ActionType, worktree *model.Worktree) error
+	ExecuteRemoteActionCommand(actionType model.ActionType, remote *model.Remote) error
+	ExecuteFileStatusActionCommand(actionType model.ActionType, files model.FileStatuses) error
+	ExecuteConflictActionCommand(actionType model.ActionType, conflicts model.Conflicts) error
 }
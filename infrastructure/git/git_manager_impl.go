@@ -1,26 +1,49 @@
 package git
 
 import (
+	"bufio"
+	"bytes"
+	"context"
 	"fmt"
 	"gitman/common"
 	"gitman/domain/model"
+	"io"
+	"iter"
 	"log/slog"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"strconv"
 	"strings"
 )
 
-type GitManagerImpl struct{}
+type GitManagerImpl struct {
+	// trueの場合はアクションを実行せず、実行するコマンドを出力するだけにする
+	dryRun       bool
+	dryRunOutput io.Writer
+}
 
-func NewGitManager() (GitManager, error) {
+func NewGitManager(dryRun bool) (GitManager, error) {
 	isValid, err := validGit()
 	if isValid {
 		return nil, err
 	}
 
+	if dryRun {
+		return NewDryRunGitManager(os.Stdout), nil
+	}
 	return &GitManagerImpl{}, nil
 }
 
+// アクションを実行せず、実行するはずだったコマンドを1行ずつ out に書き出す GitManager を返す
+// 引数はシェルにそのまま貼り付けられるようにクォートするため、テストで組み立てられた引数を検証する用途にも使える
+func NewDryRunGitManager(out io.Writer) GitManager {
+	return &GitManagerImpl{
+		dryRun:       true,
+		dryRunOutput: out,
+	}
+}
+
 func validGit() (bool, error) {
 	_, err := exec.Command("git", "--version").Output()
 	if err != nil {
@@ -29,8 +52,29 @@ func validGit() (bool, error) {
 	return false, nil
 }
 
-func (gm GitManagerImpl) ExecuteCommitActionCommand(actionType model.ActionType, commit *model.Commit) error {
-	cmd := exec.Command(actionType.Command, commit.GetOptionsWithCommitId(actionType)...)
+// アクションを指定したディレクトリで実行する (空文字の場合はカレントディレクトリ)
+// スナップショットが必要なアクションの場合は、実行前にリポジトリの状態を記録する
+func (gm GitManagerImpl) executeAction(dir string, actionType model.ActionType, options []string) error {
+	if actionType.Snapshot {
+		if _, err := gm.RecordSnapshot(getCommandLine(actionType.Command, options)); err != nil {
+			return err
+		}
+	}
+	return gm.executeCommandInDir(dir, actionType.Command, options)
+}
+
+// 指定したディレクトリでコマンドを実行する (空文字の場合はカレントディレクトリ)
+// dry-runの場合は実行せずにコマンドを出力する
+func (gm GitManagerImpl) executeCommandInDir(dir string, command string, options []string) error {
+	if gm.dryRun {
+		if _, err := fmt.Fprintln(gm.dryRunOutput, getCommandLine(command, options)); err != nil {
+			return fmt.Errorf("failed to print command: %w", err)
+		}
+		return nil
+	}
+
+	cmd := exec.Command(command, options...)
+	cmd.Dir = dir
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
@@ -43,56 +87,193 @@ func (gm GitManagerImpl) ExecuteCommitActionCommand(actionType model.ActionType,
 	return nil
 }
 
-func (gm GitManagerImpl) GetCommits() ([]*model.Commit, error) {
-	logDisplayLimit := common.GetEnvWithString("GITMAN_LOG_DISPLAY_LIMIT", "100")
-	cmd := exec.Command("git", "log", "--oneline", "--decorate", "-n", logDisplayLimit)
-	out, err := cmd.CombinedOutput()
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute git log command: %w", err)
+// コマンドと引数をシェルで実行できる1行にする
+// 空白や記号を含む引数はシングルクォートで囲み、引数の区切りが分かるようにする
+func getCommandLine(command string, options []string) string {
+	args := make([]string, 0, len(options)+1)
+	for _, arg := range append([]string{command}, options...) {
+		args = append(args, quoteArg(arg))
 	}
+	return strings.Join(args, " ")
+}
 
-	commitLog := strings.TrimSpace(string(out))
-	commits, err := model.ParseCommits(commitLog)
-	if err != nil {
-		return nil, err
+// シェルで特別な意味を持たない文字だけの引数はそのまま、それ以外はシングルクォートで囲む
+func quoteArg(arg string) string {
+	if arg != "" && strings.Trim(arg, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789_@%+=:,./-") == "" {
+		return arg
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
+func (gm GitManagerImpl) ExecuteCommitActionCommand(actionType model.ActionType, commit *model.Commit) error {
+	return gm.executeAction("", actionType, commit.GetOptionsWithCommitId(actionType))
+}
+
+func (gm GitManagerImpl) ExecuteCommitsActionCommand(actionType model.ActionType, commits model.Commits) error {
+	return gm.executeAction("", actionType, commits.GetOptionsWithCommitIds(actionType))
+}
+
+func (gm GitManagerImpl) GetCommits(filter model.CommitFilter) ([]*model.Commit, error) {
+	var commits []*model.Commit
+	for commit, err := range gm.StreamCommits(context.Background(), filter) {
+		if err != nil {
+			return nil, err
+		}
+		commits = append(commits, commit)
 	}
 
 	slog.Debug("get commitIds from git", "commitIds", commits)
 	return commits, nil
 }
 
+// git log の出力を読み込んだ順にコミットを返す
+// 途中で読み込みをやめた場合や ctx がキャンセルされた場合は git log を終了する
+func (gm GitManagerImpl) StreamCommits(ctx context.Context, filter model.CommitFilter) iter.Seq2[*model.Commit, error] {
+	return func(yield func(*model.Commit, error) bool) {
+		// 複数選択したコミットを表示順の逆 (古い順) に cherry-pick などで扱うため、--topo-order で親より先に子を出力する
+		// commit-graph があれば --topo-order でも全履歴を読み込まずに出力が始まる
+		options := []string{"log", "-z", "--topo-order", "--format=" + model.CommitFormat}
+		// 表示件数は指定された場合だけ制限する
+		if logDisplayLimit := common.GetEnvWithString("GITMAN_LOG_DISPLAY_LIMIT", ""); logDisplayLimit != "" {
+			options = append(options, "-n", logDisplayLimit)
+		}
+		cmd := exec.CommandContext(ctx, "git", append(options, filter.GetLogOptions()...)...)
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+
+		stdout, err := cmd.StdoutPipe()
+		if err != nil {
+			yield(nil, fmt.Errorf("failed to execute git log command: %w", err))
+			return
+		}
+		if err := cmd.Start(); err != nil {
+			yield(nil, fmt.Errorf("failed to execute git log command: %w", err))
+			return
+		}
+		stop := func() {
+			_ = cmd.Process.Kill()
+			_ = cmd.Wait()
+		}
+
+		scanner := bufio.NewScanner(stdout)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		scanner.Split(scanNulSeparated)
+		for scanner.Scan() {
+			commit, err := model.ParseCommit(scanner.Text())
+			if err != nil {
+				stop()
+				yield(nil, err)
+				return
+			}
+			if commit == nil {
+				continue
+			}
+			if !yield(commit, nil) {
+				stop()
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			stop()
+			yield(nil, fmt.Errorf("failed to read git log output: %w", err))
+			return
+		}
+
+		if err := cmd.Wait(); err != nil {
+			// 呼び出し元がキャンセルした場合はエラーにしない
+			if ctx.Err() != nil {
+				return
+			}
+			yield(nil, fmt.Errorf("failed to execute git log command: %w, stderr: %s", err, strings.TrimSpace(stderr.String())))
+		}
+	}
+}
+
+// NUL区切りの出力を1件ずつに分割する bufio.SplitFunc
+func scanNulSeparated(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if i := bytes.IndexByte(data, 0); i >= 0 {
+		return i + 1, data[:i], nil
+	}
+	if atEOF {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
+
 func (gm GitManagerImpl) GetBranches() ([]*model.Branch, error) {
-	cmd := exec.Command("git", "branch", "--all", "--verbose")
+	cmd := exec.Command("git", "for-each-ref", "refs/heads", "refs/remotes", "--format="+model.BranchFormat)
 	out, err := cmd.Output()
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute git branch command: %w", err)
+		return nil, fmt.Errorf("failed to execute git for-each-ref command: %w", err)
 	}
 
-	reflogs, err := model.ParseBranches(string(out))
+	branches, err := model.ParseBranches(string(out))
 	if err != nil {
 		return nil, err
 	}
-	return reflogs, nil
+	return branches, nil
 }
 
-func (gm GitManagerImpl) ExecuteBranchActionCommand(actionType model.ActionType, branch *model.Branch) error {
-	cmd := exec.Command(actionType.Command, branch.GetOptionsWithBranchInfo(actionType)...)
-	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+// 基準のブランチにマージ済みのローカルブランチ名を返す
+func (gm GitManagerImpl) GetMergedBranchNames(base string) ([]string, error) {
+	out, err := exec.Command("git", "for-each-ref", "refs/heads", "--merged="+base, "--format=%(refname:short)").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute git for-each-ref command: %w", err)
+	}
+	return strings.Fields(string(out)), nil
+}
 
-	// 実行
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to execute command: %w", err)
+// リモートのデフォルトブランチ (例: origin/main) を返す
+// origin/HEAD が設定されていない場合はエラーを返す
+func (gm GitManagerImpl) GetDefaultBranch() (string, error) {
+	out, err := exec.Command("git", "symbolic-ref", "--quiet", "--short", "refs/remotes/origin/HEAD").Output()
+	if err != nil {
+		// 基準のブランチを間違えるとマージされていないブランチを削除候補にしてしまうため、HEAD などで代用しない
+		if _, ok := err.(*exec.ExitError); ok {
+			return "", fmt.Errorf("failed to find the default branch: origin/HEAD is not set (specify --base or run git remote set-head origin --auto)")
+		}
+		return "", fmt.Errorf("failed to execute git symbolic-ref command: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// 削除されたリモートブランチのリモート追跡ブランチを削除する
+// dry-runの場合はリポジトリを変更しないため実行しない
+func (gm GitManagerImpl) FetchPrune() error {
+	if gm.dryRun {
+		slog.Debug("skip git fetch --prune in dry-run")
+		return nil
 	}
 
+	cmd := exec.Command("git", "fetch", "--all", "--prune", "--quiet")
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to execute git fetch command: %w", err)
+	}
 	return nil
 }
 
-func (gm GitManagerImpl) GetReflogs() ([]*model.Reflog, error) {
-	cmd := exec.Command("git", "reflog", "-n", "50")
+func (gm GitManagerImpl) ExecuteBranchActionCommand(actionType model.ActionType, branch *model.Branch) error {
+	return gm.executeAction("", actionType, branch.GetOptionsWithBranchInfo(actionType))
+}
+
+func (gm GitManagerImpl) ExecuteBranchesActionCommand(actionType model.ActionType, branches model.Branches) error {
+	return gm.executeAction("", actionType, branches.GetOptionsWithBranchNames(actionType))
+}
+
+func (gm GitManagerImpl) GetReflogs(filter model.ReflogFilter) ([]*model.Reflog, error) {
+	// --date を指定すると reflog のセレクタが ref@{n} ではなく ref@{日時} になり、日時も取得できる
+	options := []string{"reflog", "show", "-z", "--date=iso-strict", "--format=" + model.ReflogFormat}
+	options = append(options, "-n", common.GetEnvWithString("GITMAN_REFLOG_DISPLAY_LIMIT", "50"))
+	cmd := exec.Command("git", append(options, filter.GetReflogOptions()...)...)
 	out, err := cmd.Output()
 	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("failed to execute git reflog command: %w, stderr: %s", err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
 		return nil, fmt.Errorf("failed to execute git reflog command: %w", err)
 	}
 
@@ -106,15 +287,330 @@ func (gm GitManagerImpl) GetReflogs() ([]*model.Reflog, error) {
 }
 
 func (gm GitManagerImpl) ExecuteReflogActionCommand(actionType model.ActionType, reflog *model.Reflog) error {
-	cmd := exec.Command(actionType.Command, reflog.GetOptionsWithReflogId(actionType)...)
-	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	return gm.executeAction("", actionType, reflog.GetOptionsWithReflogId(actionType))
+}
 
-	// 実行
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to execute command: %w", err)
+func (gm GitManagerImpl) ExecuteReflogsActionCommand(actionType model.ActionType, reflogs model.Reflogs) error {
+	return gm.executeAction("", actionType, reflogs.GetOptionsWithReflogIds(actionType))
+}
+
+func (gm GitManagerImpl) GetStashes() ([]*model.Stash, error) {
+	cmd := exec.Command("git", "stash", "list")
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute git stash command: %w", err)
 	}
 
+	stashes, err := model.ParseStashes(string(out))
+	if err != nil {
+		return nil, err
+	}
+	return stashes, nil
+}
+
+func (gm GitManagerImpl) ExecuteStashActionCommand(actionType model.ActionType, stash *model.Stash) error {
+	return gm.executeAction("", actionType, stash.GetOptionsWithStashId(actionType))
+}
+
+func (gm GitManagerImpl) GetTags() ([]*model.Tag, error) {
+	cmd := exec.Command("git", "for-each-ref", "refs/tags", "--sort=-creatordate", "--format="+model.TagFormat)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute git for-each-ref command: %w", err)
+	}
+
+	tags, err := model.ParseTags(string(out))
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+func (gm GitManagerImpl) ExecuteTagActionCommand(actionType model.ActionType, tag *model.Tag) error {
+	return gm.executeAction("", actionType, tag.GetOptionsWithTagName(actionType))
+}
+
+func (gm GitManagerImpl) ExecuteTagsActionCommand(actionType model.ActionType, tags model.Tags) error {
+	return gm.executeAction("", actionType, tags.GetOptionsWithTagNames(actionType))
+}
+
+func (gm GitManagerImpl) GetWorktrees() ([]*model.Worktree, error) {
+	cmd := exec.Command("git", "worktree", "list", "--porcelain")
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute git worktree command: %w", err)
+	}
+
+	worktrees, err := model.ParseWorktrees(string(out))
+	if err != nil {
+		return nil, err
+	}
+	return worktrees, nil
+}
+
+// コミットを指す rev (例: 短縮ハッシュ) を完全なコミットIDに変換する
+// コミットが存在しない、または短縮ハッシュが複数のコミットに一致する場合は空文字を返す
+func (gm GitManagerImpl) ResolveCommitId(rev string) (string, error) {
+	out, err := exec.Command("git", "rev-parse", "--verify", "--quiet", rev+"^{commit}").Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to execute git rev-parse command: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+func (gm GitManagerImpl) GetTopLevelDir() (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--show-toplevel")
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to execute git rev-parse command: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+func (gm GitManagerImpl) ExecuteWorktreeActionCommand(actionType model.ActionType, worktree *model.Worktree) error {
+	return gm.executeAction("", actionType, worktree.GetOptionsWithWorktreeInfo(actionType))
+}
+
+func (gm GitManagerImpl) GetRemotes() ([]*model.Remote, error) {
+	cmd := exec.Command("git", "remote", "-v")
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute git remote command: %w", err)
+	}
+
+	remotes, err := model.ParseRemotes(string(out))
+	if err != nil {
+		return nil, err
+	}
+	return remotes, nil
+}
+
+// タグを push するリモートを取得する
+// branch.<name>.pushRemote, remote.pushDefault, branch.<name>.remote の順に設定を確認し、
+// 設定がない場合はリモートが1つだけならそのリモート、なければ origin を使う (決められない場合は空文字)
+func (gm GitManagerImpl) GetPushRemote() (string, error) {
+	out, err := exec.Command("git", "remote").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to execute git remote command: %w", err)
+	}
+	remotes := strings.Fields(string(out))
+
+	var configured []string
+	// detached HEAD の場合はブランチの設定がないため remote.pushDefault だけを確認する
+	branch, err := exec.Command("git", "symbolic-ref", "--quiet", "--short", "HEAD").Output()
+	if err != nil {
+		if _, ok := err.(*exec.ExitError); !ok {
+			return "", fmt.Errorf("failed to execute git symbolic-ref command: %w", err)
+		}
+	}
+	keys := []string{"remote.pushDefault"}
+	if name := strings.TrimSpace(string(branch)); name != "" {
+		keys = []string{"branch." + name + ".pushRemote", "remote.pushDefault", "branch." + name + ".remote"}
+	}
+	for _, key := range keys {
+		value, err := gm.getConfig(key)
+		if err != nil {
+			return "", err
+		}
+		configured = append(configured, value)
+	}
+
+	return model.ResolvePushRemote(configured, remotes), nil
+}
+
+// git config の値を取得する (設定されていない場合は空文字)
+func (gm GitManagerImpl) getConfig(key string) (string, error) {
+	out, err := exec.Command("git", "config", "--get", key).Output()
+	if err != nil {
+		if _, ok := err.(*exec.ExitError); ok {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to execute git config command: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+func (gm GitManagerImpl) ExecuteRemoteActionCommand(actionType model.ActionType, remote *model.Remote) error {
+	return gm.executeAction("", actionType, remote.GetOptionsWithRemoteName(actionType))
+}
+
+func (gm GitManagerImpl) GetFileStatuses() ([]*model.FileStatus, error) {
+	cmd := exec.Command("git", "status", "--porcelain=v2", "-z", "--untracked-files=all")
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute git status command: %w", err)
+	}
+
+	fileStatuses, err := model.ParseFileStatuses(string(out))
+	if err != nil {
+		return nil, err
+	}
+	return fileStatuses, nil
+}
+
+func (gm GitManagerImpl) ExecuteFileStatusActionCommand(actionType model.ActionType, files model.FileStatuses) error {
+	// git status のパスはリポジトリのルートからの相対パスのため、ルートで実行する
+	topLevelDir, err := gm.GetTopLevelDir()
+	if err != nil {
+		return err
+	}
+
+	return gm.executeAction(topLevelDir, actionType, files.GetOptionsWithPaths(actionType))
+}
+
+func (gm GitManagerImpl) ExecuteConflictActionCommand(actionType model.ActionType, conflicts model.Conflicts) error {
+	// パスはリポジトリのルートからの相対パスのため、ルートで実行する
+	topLevelDir, err := gm.GetTopLevelDir()
+	if err != nil {
+		return err
+	}
+	return gm.executeAction(topLevelDir, actionType, conflicts.GetOptionsWithConflicts(actionType))
+}
+
+// ブランチ名として使える名前か git check-ref-format で検証する
+func (gm GitManagerImpl) CheckBranchName(name string) error {
+	if err := exec.Command("git", "check-ref-format", "--branch", name).Run(); err != nil {
+		if _, ok := err.(*exec.ExitError); ok {
+			return fmt.Errorf("invalid branch name: %s", name)
+		}
+		return fmt.Errorf("failed to execute git check-ref-format command: %w", err)
+	}
+	return nil
+}
+
+func (gm GitManagerImpl) CheckTagName(name string) error {
+	if err := exec.Command("git", "check-ref-format", "refs/tags/"+name).Run(); err != nil {
+		if _, ok := err.(*exec.ExitError); ok {
+			return fmt.Errorf("invalid tag name: %s", name)
+		}
+		return fmt.Errorf("failed to execute git check-ref-format command: %w", err)
+	}
 	return nil
 }
+
+// アクションを表示するか判定するためのリポジトリの状態を取得する
+func (gm GitManagerImpl) GetRepoState() (model.RepoState, error) {
+	// 追跡していないファイルは rebase 等の妨げにならないため含めない
+	out, err := exec.Command("git", "status", "--porcelain=v2", "-z", "--untracked-files=no").Output()
+	if err != nil {
+		return model.RepoState{}, fmt.Errorf("failed to execute git status command: %w", err)
+	}
+	fileStatuses, err := model.ParseFileStatuses(string(out))
+	if err != nil {
+		return model.RepoState{}, err
+	}
+
+	operation, err := gm.getOperationInProgress()
+	if err != nil {
+		return model.RepoState{}, err
+	}
+
+	return model.NewRepoState(fileStatuses, operation), nil
+}
+
+// git rev-parse で取得した .git 以下のパスを絶対パスで返す
+// --path-format=absolute は git 2.31 以降でしか使えないため、相対パスの場合は作業ディレクトリから解決する
+func getAbsoluteGitPath(options ...string) (string, error) {
+	out, err := exec.Command("git", append([]string{"rev-parse"}, options...)...).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to execute git rev-parse command: %w", err)
+	}
+	path := strings.TrimSpace(string(out))
+	if filepath.IsAbs(path) {
+		return path, nil
+	}
+	return filepath.Abs(path)
+}
+
+// 途中で止まっている操作の名前を返す (何もない場合は空文字)
+// git が .git ディレクトリに作成する作業中のファイルの有無で判定する
+func (gm GitManagerImpl) getOperationInProgress() (string, error) {
+	gitDir, err := getAbsoluteGitPath("--git-dir")
+	if err != nil {
+		return "", err
+	}
+
+	operations := []struct {
+		path string
+		name string
+	}{
+		{path: "rebase-merge", name: "rebase"},
+		{path: "rebase-apply", name: "rebase"},
+		{path: "MERGE_HEAD", name: "merge"},
+		{path: "CHERRY_PICK_HEAD", name: "cherry-pick"},
+		{path: "REVERT_HEAD", name: "revert"},
+	}
+	for _, operation := range operations {
+		if _, err := os.Stat(filepath.Join(gitDir, operation.path)); err == nil {
+			return operation.name, nil
+		}
+	}
+	return "", nil
+}
+
+// 破壊的なアクションで失われる可能性がある内容として、scope で指定されたワークツリーの未コミットの変更、
+// スタッシュの内容、参照の未プッシュのコミット数と、強制的な push で上書きされるコミット数を取得する
+func (gm GitManagerImpl) GetDestructiveSummary(scope model.DestructiveScope) (*model.DestructiveSummary, error) {
+	var statusShort string
+	if scope.Worktree != "" {
+		args := []string{"-C", scope.Worktree, "status", "--short"}
+		if len(scope.Paths) > 0 {
+			args = append(args, "--")
+			for _, path := range scope.Paths {
+				// パスはリポジトリのルートからの相対パスのため、カレントディレクトリに関係なく文字どおりに一致させる
+				args = append(args, ":(top,literal)"+path)
+			}
+		}
+		out, err := exec.Command("git", args...).Output()
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute git status command: %w", err)
+		}
+		statusShort = string(out)
+	}
+
+	var stashFiles string
+	if scope.Stash != "" {
+		out, err := exec.Command("git", "stash", "show", "--include-untracked", "--name-status", scope.Stash).Output()
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute git stash show command: %w", err)
+		}
+		stashFiles = string(out)
+	}
+
+	var unpushedCommits []model.UnpushedCommitCount
+	for _, ref := range scope.UnpushedRefs {
+		count, err := countCommits(ref, "--not", "--remotes")
+		if err != nil {
+			return nil, err
+		}
+		unpushedCommits = append(unpushedCommits, model.UnpushedCommitCount{Ref: ref, Count: count})
+	}
+
+	var overwrittenCommits []model.OverwrittenCommitCount
+	for _, overwritten := range scope.OverwrittenRefs {
+		count, err := countCommits(overwritten.Ref + ".." + overwritten.Upstream)
+		if err != nil {
+			return nil, err
+		}
+		overwrittenCommits = append(overwrittenCommits, model.OverwrittenCommitCount{Ref: overwritten.Ref, Upstream: overwritten.Upstream, Count: count})
+	}
+
+	return model.NewDestructiveSummary(scope, statusShort, stashFiles, unpushedCommits, overwrittenCommits), nil
+}
+
+// git rev-list --count で revs に含まれるコミット数を数える
+func countCommits(revs ...string) (int, error) {
+	out, err := exec.Command("git", append([]string{"rev-list", "--count"}, revs...)...).Output()
+	if err != nil {
+		return 0, fmt.Errorf("failed to execute git rev-list command: %w", err)
+	}
+
+	count, err := strconv.Atoi(strings.TrimSpace(string(out)))
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse commit count of %s: %w", strings.Join(revs, " "), err)
+	}
+	return count, nil
+}
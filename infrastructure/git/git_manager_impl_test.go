@@ -0,0 +1,199 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"gitman/domain/model"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGetCommandLine(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		command string
+		options []string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "記号を含まない引数はそのまま連結すること",
+			args: args{
+				command: "git",
+				options: []string{"reset", "--hard", "1a2b3c4"},
+			},
+			want: "git reset --hard 1a2b3c4",
+		},
+		{
+			name: "空白を含む引数はシングルクォートで囲むこと",
+			args: args{
+				command: "git",
+				options: []string{"restore", "--", "my file.txt"},
+			},
+			want: "git restore -- 'my file.txt'",
+		},
+		{
+			name: "シングルクォートを含む引数はエスケープすること",
+			args: args{
+				command: "sh",
+				options: []string{"-c", `printf '%s\n' "$@" >> .gitignore`, "sh", "/it's"},
+			},
+			want: `sh -c 'printf '\''%s\n'\'' "$@" >> .gitignore' sh '/it'\''s'`,
+		},
+		{
+			name: "空の引数は空のクォートにすること",
+			args: args{
+				command: "git",
+				options: []string{"commit", "-m", ""},
+			},
+			want: "git commit -m ''",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := getCommandLine(tt.args.command, tt.args.options); got != tt.want {
+				t.Errorf("getCommandLine() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewDryRunGitManager(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		execute func(gm GitManager) error
+	}
+	tests := []struct {
+		name           string
+		args           args
+		want           string
+		wantErr        bool
+		wantErrMessage error
+	}{
+		{
+			name: "複数のブランチに対するコマンドを実行せずに出力すること",
+			args: args{
+				execute: func(gm GitManager) error {
+					return gm.ExecuteBranchesActionCommand(model.BranchActionTypes.Delete, model.Branches{
+						model.NewBranch(false, "feature/a", "aaaaaaa", "a"),
+						model.NewBranch(false, "feature/b", "bbbbbbb", "b"),
+					})
+				},
+			},
+			want:           "git branch -d feature/a feature/b\n",
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "空白を含むパスを1つの引数として出力すること",
+			args: args{
+				execute: func(gm GitManager) error {
+					return gm.ExecuteFileStatusActionCommand(model.FileStatusActionTypes.Stage, model.FileStatuses{
+						model.NewFileStatus(".", "M", "docs/my notes.md", ""),
+					})
+				},
+			},
+			want:           "git add -- 'docs/my notes.md'\n",
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+		{
+			name: "スナップショットが必要なアクションでもリポジトリに書き込まずに出力すること",
+			args: args{
+				execute: func(gm GitManager) error {
+					return gm.ExecuteReflogActionCommand(model.ReflogActionTypes.ResetHard, model.NewReflog("1a2b3c4", "HEAD@{1}", "commit: init"))
+				},
+			},
+			want:           "git reset --hard 1a2b3c4\n",
+			wantErr:        false,
+			wantErrMessage: nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var out bytes.Buffer
+			err := tt.args.execute(NewDryRunGitManager(&out))
+			if (err != nil) != tt.wantErr || err != nil && err.Error() != tt.wantErrMessage.Error() {
+				t.Errorf("NewDryRunGitManager() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("NewDryRunGitManager() error message = %v, wantErrMessage %v", err, tt.wantErrMessage)
+				return
+			}
+			if got := out.String(); got != tt.want {
+				t.Errorf("NewDryRunGitManager() output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScanNulSeparated(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		output     string
+		bufferSize int
+	}
+	tests := []struct {
+		name string
+		args args
+		want []string
+	}{
+		{
+			name: "NUL区切りの出力を1件ずつに分割すること",
+			args: args{
+				output:     "a\x00b\x00",
+				bufferSize: 64,
+			},
+			want: []string{"a", "b"},
+		},
+		{
+			name: "最後にNULがない場合も残りを1件として返すこと",
+			args: args{
+				output:     "a\x00\nb",
+				bufferSize: 64,
+			},
+			want: []string{"a", "\nb"},
+		},
+		{
+			name: "バッファより長い出力も途中で分割しないこと",
+			args: args{
+				output:     strings.Repeat("a", 100) + "\x00" + strings.Repeat("b", 100) + "\x00",
+				bufferSize: 16,
+			},
+			want: []string{strings.Repeat("a", 100), strings.Repeat("b", 100)},
+		},
+		{
+			name: "空の出力の場合は何も返さないこと",
+			args: args{
+				output:     "",
+				bufferSize: 64,
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			scanner := bufio.NewScanner(strings.NewReader(tt.args.output))
+			scanner.Buffer(make([]byte, 0, tt.args.bufferSize), 1024)
+			scanner.Split(scanNulSeparated)
+			var got []string
+			for scanner.Scan() {
+				got = append(got, scanner.Text())
+			}
+			if err := scanner.Err(); err != nil {
+				t.Fatalf("scanner.Err() = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("scanNulSeparated() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"gitman/common"
+	"gitman/domain/model"
+	"io/fs"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// スナップショットを記録するジャーナルのパスを返す (.git/gitman/journal.jsonl)
+func (gm GitManagerImpl) getJournalPath() (string, error) {
+	return getAbsoluteGitPath("--git-path", "gitman/journal.jsonl")
+}
+
+func (gm GitManagerImpl) GetSnapshots() ([]*model.Snapshot, error) {
+	journalPath, err := gm.getJournalPath()
+	if err != nil {
+		return nil, err
+	}
+
+	journal, err := os.ReadFile(journalPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return []*model.Snapshot{}, nil
+		}
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+
+	return model.ParseSnapshots(string(journal))
+}
+
+// 現在のHEAD、ブランチの先端、未コミットの変更をジャーナルに記録する
+func (gm GitManagerImpl) RecordSnapshot(command string) (*model.Snapshot, error) {
+	// dry-runではリポジトリに何も書き込まない
+	if gm.dryRun {
+		slog.Debug("skip snapshot because of dry-run", "command", command)
+		return nil, nil
+	}
+
+	headOut, err := exec.Command("git", "rev-parse", "--verify", "--quiet", "HEAD").Output()
+	if err != nil {
+		// コミットが1つもないリポジトリでは復元する状態がないため記録しない
+		slog.Debug("skip snapshot because HEAD does not exist", "error", err)
+		return nil, nil
+	}
+	head := strings.TrimSpace(string(headOut))
+
+	// detached HEAD の場合は終了コードが1になるため空文字とする
+	headRefOut, _ := exec.Command("git", "symbolic-ref", "--quiet", "--short", "HEAD").Output()
+	headRef := strings.TrimSpace(string(headRefOut))
+
+	tipsOut, err := exec.Command("git", "for-each-ref", "refs/heads", "--format=%(refname:short) %(objectname)").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute git for-each-ref command: %w", err)
+	}
+
+	// 未コミットの変更をスタッシュリストに積まずにコミットとして保存する
+	stashOut, err := exec.Command("git", "stash", "create").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute git stash create command: %w", err)
+	}
+
+	snapshot := model.NewSnapshot(time.Now(), command, head, headRef, model.ParseBranchTips(string(tipsOut)), strings.TrimSpace(string(stashOut)))
+
+	// git stash create のコミットはどこからも参照されず gc で消えるため参照を作っておく
+	if snapshot.Stash != "" {
+		if err := exec.Command("git", "update-ref", snapshot.GetRefName(), snapshot.Stash).Run(); err != nil {
+			return nil, fmt.Errorf("failed to keep snapshot commit: %w", err)
+		}
+	}
+
+	if err := gm.appendJournal(snapshot); err != nil {
+		return nil, err
+	}
+	if err := gm.pruneSnapshots(); err != nil {
+		return nil, err
+	}
+
+	slog.Debug("recorded snapshot", "snapshot", snapshot)
+	return snapshot, nil
+}
+
+func (gm GitManagerImpl) appendJournal(snapshot *model.Snapshot) error {
+	journalPath, err := gm.getJournalPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(journalPath), 0o755); err != nil {
+		return fmt.Errorf("failed to create journal directory: %w", err)
+	}
+
+	line, err := snapshot.MarshalJournalLine()
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(journalPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open journal: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(line); err != nil {
+		return fmt.Errorf("failed to write journal: %w", err)
+	}
+	return nil
+}
+
+// 保持する数 (GITMAN_SNAPSHOT_LIMIT) を超えた古いスナップショットをジャーナルと参照から削除する
+func (gm GitManagerImpl) pruneSnapshots() error {
+	limit, err := strconv.Atoi(common.GetEnvWithString("GITMAN_SNAPSHOT_LIMIT", "50"))
+	if err != nil {
+		return fmt.Errorf("invalid GITMAN_SNAPSHOT_LIMIT: %w", err)
+	}
+
+	snapshots, err := gm.GetSnapshots()
+	if err != nil {
+		return err
+	}
+	kept, expired := model.SplitSnapshotsByLimit(snapshots, limit)
+	if len(expired) == 0 {
+		return nil
+	}
+
+	// ジャーナルは古いものから順に書き込む
+	var journal strings.Builder
+	for i := len(kept) - 1; i >= 0; i-- {
+		line, err := kept[i].MarshalJournalLine()
+		if err != nil {
+			return err
+		}
+		journal.WriteString(line)
+	}
+
+	// 書き込み途中で中断されてもジャーナルが壊れないように一時ファイルから置き換える
+	journalPath, err := gm.getJournalPath()
+	if err != nil {
+		return err
+	}
+	tmpPath := journalPath + ".tmp"
+	if err := os.WriteFile(tmpPath, []byte(journal.String()), 0o644); err != nil {
+		return fmt.Errorf("failed to write journal: %w", err)
+	}
+	if err := os.Rename(tmpPath, journalPath); err != nil {
+		return fmt.Errorf("failed to replace journal: %w", err)
+	}
+
+	for _, snapshot := range expired {
+		if snapshot.Stash == "" {
+			continue
+		}
+		if err := exec.Command("git", "update-ref", "-d", snapshot.GetRefName()).Run(); err != nil {
+			return fmt.Errorf("failed to delete snapshot ref %s: %w", snapshot.GetRefName(), err)
+		}
+	}
+
+	slog.Debug("pruned snapshots", "expired", expired)
+	return nil
+}
+
+// 他のワークツリーでチェックアウトされているブランチ名と、そのワークツリーのパスの対応を返す
+func (gm GitManagerImpl) getBranchesCheckedOutInOtherWorktrees() (map[string]string, error) {
+	topLevelDir, err := gm.GetTopLevelDir()
+	if err != nil {
+		return nil, err
+	}
+	worktrees, err := gm.GetWorktrees()
+	if err != nil {
+		return nil, err
+	}
+
+	checkedOut := map[string]string{}
+	for _, worktree := range worktrees {
+		if worktree.Branch == "" || filepath.Clean(worktree.Path) == filepath.Clean(topLevelDir) {
+			continue
+		}
+		checkedOut[worktree.Branch] = worktree.Path
+	}
+	return checkedOut, nil
+}
+
+// スナップショットの状態にブランチの先端、HEAD、作業ツリーを戻す
+// スナップショット以降に作成されたブランチはそのまま残す
+// 他のワークツリーでチェックアウトされているブランチは、そのワークツリーを壊さないように戻さない
+func (gm GitManagerImpl) RestoreSnapshot(snapshot *model.Snapshot) error {
+	checkedOut, err := gm.getBranchesCheckedOutInOtherWorktrees()
+	if err != nil {
+		return err
+	}
+	// HEADが指していたブランチは他のワークツリーでチェックアウトされているとチェックアウトできないため、何も変更せずに中止する
+	if path, ok := checkedOut[snapshot.HeadRef]; ok {
+		return fmt.Errorf("cannot restore snapshot %s: branch %s is checked out in another worktree: %s", snapshot.Id, snapshot.HeadRef, path)
+	}
+
+	branchNames, skipped := snapshot.GetRestorableBranchNames(checkedOut)
+	for _, name := range skipped {
+		slog.Warn("skip restoring the branch checked out in another worktree", "branch", name, "worktree", checkedOut[name])
+	}
+
+	for _, name := range branchNames {
+		if err := gm.executeCommandInDir("", "git", []string{"update-ref", "refs/heads/" + name, snapshot.Branches[name]}); err != nil {
+			return err
+		}
+	}
+
+	checkout := []string{"checkout", "--force", snapshot.HeadRef}
+	if snapshot.HeadRef == "" {
+		checkout = []string{"checkout", "--force", "--detach", snapshot.Head}
+	}
+	if err := gm.executeCommandInDir("", "git", checkout); err != nil {
+		return err
+	}
+
+	if err := gm.executeCommandInDir("", "git", []string{"reset", "--hard", snapshot.Head}); err != nil {
+		return err
+	}
+
+	if snapshot.Stash != "" {
+		if err := gm.executeCommandInDir("", "git", []string{"stash", "apply", snapshot.Stash}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -34,23 +34,101 @@ func (c Cli) Handle() error {
 		fmt.Printf("gitman version %s\n", common.GetVersionFromGit())
 
 	case c.options.Log:
+		if c.options.List {
+			return c.container.GitCommitUsecase.ListCommits(c.options.Format)
+		}
 		err := c.container.GitCommitUsecase.InteractiveCommitAction()
 		if err != nil {
 			return err
 		}
 
+	case c.options.Branch && c.options.Prune:
+		if c.options.List {
+			return c.container.GitBranchUsecase.ListPruneCandidates(c.options.Format)
+		}
+		err := c.container.GitBranchUsecase.InteractivePruneBranches()
+		if err != nil {
+			return err
+		}
+
 	case c.options.Branch:
+		if c.options.List {
+			return c.container.GitBranchUsecase.ListBranches(c.options.Format)
+		}
 		err := c.container.GitBranchUsecase.InteractiveBranchAction()
 		if err != nil {
 			return err
 		}
 
 	case c.options.Reflog:
+		if c.options.List {
+			return c.container.GitReflogUsecase.ListReflogs(c.options.Format)
+		}
 		err := c.container.GitReflogUsecase.InteractiveReflogAction()
 		if err != nil {
 			return err
 		}
 
+	case c.options.Stash:
+		if c.options.List {
+			return c.container.GitStashUsecase.ListStashes(c.options.Format)
+		}
+		err := c.container.GitStashUsecase.InteractiveStashAction()
+		if err != nil {
+			return err
+		}
+
+	case c.options.Tag:
+		if c.options.List {
+			return c.container.GitTagUsecase.ListTags(c.options.Format)
+		}
+		err := c.container.GitTagUsecase.InteractiveTagAction()
+		if err != nil {
+			return err
+		}
+
+	case c.options.Worktree:
+		if c.options.List {
+			return c.container.GitWorktreeUsecase.ListWorktrees(c.options.Format)
+		}
+		err := c.container.GitWorktreeUsecase.InteractiveWorktreeAction()
+		if err != nil {
+			return err
+		}
+
+	case c.options.Remote:
+		if c.options.List {
+			return c.container.GitRemoteUsecase.ListRemotes(c.options.Format)
+		}
+		err := c.container.GitRemoteUsecase.InteractiveRemoteAction()
+		if err != nil {
+			return err
+		}
+
+	case c.options.Status:
+		if c.options.List {
+			return c.container.GitStatusUsecase.ListFileStatuses(c.options.Format)
+		}
+		err := c.container.GitStatusUsecase.InteractiveStatusAction()
+		if err != nil {
+			return err
+		}
+
+	case c.options.Conflicts:
+		if c.options.List {
+			return c.container.GitConflictUsecase.ListConflicts(c.options.Format)
+		}
+		err := c.container.GitConflictUsecase.InteractiveConflictAction()
+		if err != nil {
+			return err
+		}
+
+	case c.options.Undo:
+		err := c.container.GitUndoUsecase.InteractiveUndo()
+		if err != nil {
+			return err
+		}
+
 	default:
 		fmt.Println("Oops! No arguments were given.")
 		fmt.Println("Use 'gitman --help' to see available commands.")